@@ -13,10 +13,20 @@ import (
 	"refleks/internal/benchmarks"
 	"refleks/internal/cache"
 	"refleks/internal/constants"
+	"refleks/internal/freshness"
+	"refleks/internal/goals"
+	"refleks/internal/kovaaks"
+	"refleks/internal/leaderboard"
 	"refleks/internal/models"
+	"refleks/internal/plans"
 	"refleks/internal/process"
+	"refleks/internal/profiles"
 	"refleks/internal/scenarios"
 	appsettings "refleks/internal/settings"
+	"refleks/internal/stats"
+	"refleks/internal/steam"
+	"refleks/internal/team"
+	"refleks/internal/traceanalysis"
 	"refleks/internal/traces"
 	"refleks/internal/tracking"
 	"refleks/internal/updater"
@@ -30,10 +40,18 @@ type App struct {
 	settingsSvc    *appsettings.Service
 	benchmarkSvc   *benchmarks.Service
 	scenarioSvc    *scenarios.Service
+	leaderboardSvc *leaderboard.Service
+	teamSvc        *team.Service
+	plansSvc       *plans.Service
+	goalsSvc       *goals.Service
+	statsSvc       *stats.Service
 	updaterSvc     *updater.Service
 	cacheSvc       *cache.Service
+	kovaaksClient  *kovaaks.Client
+	revalidator    *freshness.Scheduler
 	tracesSvc      *traces.Service
 	autostartSvc   *autostart.Service
+	profilesSvc    *profiles.Service
 	processWatcher *process.Watcher
 	watcherCancel  context.CancelFunc
 	isQuitting     bool
@@ -59,22 +77,71 @@ func (a *App) startup(ctx context.Context) {
 
 	// Initialize Core Services
 	a.cacheSvc = cache.NewService()
+	a.kovaaksClient = kovaaks.NewClient()
+	a.cacheSvc.RegisterOnClear(a.kovaaksClient.ClearCache)
+	a.cacheSvc.RegisterOnReload(a.kovaaksClient.ClearCache)
+	a.profilesSvc = profiles.NewService(a.settingsSvc)
 	a.tracesSvc = traces.NewService()
 	a.updaterSvc = updater.NewService(constants.GitHubOwner, constants.GitHubRepo, constants.AppVersion)
 
 	// Configure traces storage directory
 	tracesDir := appsettings.ExpandPathPlaceholders(settings.TracesDir)
 	a.tracesSvc.SetBaseDir(tracesDir)
+	a.tracesSvc.SetStatsDir(appsettings.StatsDirOf(settings))
 
 	// Initialize Domain Services
-	a.benchmarkSvc = benchmarks.NewService(a.settingsSvc, a.cacheSvc)
-	a.scenarioSvc = scenarios.NewService(a.settingsSvc)
+	a.benchmarkSvc = benchmarks.NewService(a.ctx, a.settingsSvc, a.cacheSvc, a.kovaaksClient)
+	a.scenarioSvc = scenarios.NewService(a.ctx, a.settingsSvc, a.cacheSvc, a.kovaaksClient)
+	a.leaderboardSvc = leaderboard.NewService(a.ctx, a.cacheSvc, a.kovaaksClient)
+	a.teamSvc = team.NewService(a.ctx, a.settingsSvc, a.cacheSvc, a.benchmarkSvc, a.kovaaksClient)
+	a.leaderboardSvc.SetFriendNameSource(a.teamSvc.NameOf)
+
+	// Offline-first: background revalidation of remote data, refreshed immediately when connectivity returns
+	a.kovaaksClient.SetOfflineMode(settings.OfflineMode)
+	a.revalidator = freshness.NewScheduler(
+		time.Duration(constants.RevalidateIntervalMinutes)*time.Minute,
+		time.Duration(constants.RevalidateJitterSeconds)*time.Second,
+	)
+	a.revalidator.Register(a.benchmarkSvc.RevalidateStale)
+	a.revalidator.Register(a.scenarioSvc.RevalidateStale)
+	a.revalidator.Register(a.leaderboardSvc.RevalidateStale)
+	a.revalidator.SetOnRun(func(refreshed int, triggered bool) {
+		if refreshed > 0 || triggered {
+			runtime.EventsEmit(a.ctx, constants.EventCacheRevalidated, map[string]any{"refreshed": refreshed})
+		}
+	})
+	a.kovaaksClient.OnConnectivityChange(func(st models.ConnectivityStatus) {
+		runtime.EventsEmit(a.ctx, constants.EventConnectivityChanged, st)
+		if st.Online {
+			runtime.LogInfo(a.ctx, "Kovaak's API reachable again, revalidating cached data")
+			a.revalidator.Trigger()
+		}
+	})
+	go a.revalidator.Start(a.ctx)
 
 	// Initialize Tracking Service (coordinates Watcher + Mouse)
 	a.trackingSvc = tracking.NewService(a.ctx, a.settingsSvc, a.benchmarkSvc, a.tracesSvc)
+	a.trackingSvc.SetRunFilter(a.profilesSvc.AdmitRun)
 
 	// Initialize AI Service
-	a.aiSvc = ai.NewService(a.ctx, a.settingsSvc)
+	a.aiSvc = ai.NewService(a.ctx, a.settingsSvc, a.cacheSvc, a.leaderboardSvc, a.tracesSvc)
+	a.aiSvc.SetHistorySource(a.trackingSvc.GetHistory)
+	a.aiSvc.SetRankChangesSource(a.teamSvc.RankChanges)
+
+	// Initialize Plans Service (runs parsed by the watcher count towards active plans)
+	a.plansSvc = plans.NewService(a.ctx, a.settingsSvc)
+	a.trackingSvc.RegisterOnScenarioParsed(a.plansSvc.RecordRun)
+
+	// Initialize Goals Service (re-evaluated as runs and benchmark progress come in, and periodically)
+	a.goalsSvc = goals.NewService(a.ctx, a.benchmarkSvc)
+	a.goalsSvc.SetHistorySource(a.trackingSvc.GetHistory)
+	a.trackingSvc.RegisterOnScenarioParsed(a.goalsSvc.OnRun)
+	a.trackingSvc.RegisterOnBenchmarkProgress(a.goalsSvc.OnBenchmarkProgress)
+	go a.goalsSvc.RunEvaluator(a.ctx, time.Duration(constants.GoalEvaluateIntervalMinutes)*time.Minute)
+
+	// Initialize Stats Service
+	a.statsSvc = stats.NewService()
+	a.statsSvc.SetHistorySource(a.trackingSvc.GetHistory)
 
 	// Initialize Autostart Service
 	a.autostartSvc = autostart.NewService()
@@ -89,6 +156,21 @@ func (a *App) startup(ctx context.Context) {
 		runtime.LogWarningf(a.ctx, "Auto-start watcher failed: %v", err)
 	}
 
+	// Runs played elsewhere: restore synced remote runs, then keep them in sync with the revalidator
+	a.trackingSvc.AddRemoteRecords(a.scenarioSvc.RemoteRecords())
+	a.cacheSvc.RegisterOnClear(a.trackingSvc.ClearRemoteRecords)
+	a.revalidator.Register(func(ctx context.Context) int {
+		n, _ := a.SyncRemoteScores()
+		return n
+	})
+
+	// Apply the trace retention policy in the background
+	go a.tracesSvc.RunPruner(a.ctx,
+		time.Duration(constants.TracePruneIntervalHours)*time.Hour,
+		func() models.TraceRetentionPolicy { return a.settingsSvc.Get().TraceRetention },
+		a.onTracesPruned,
+	)
+
 	// Fire-and-forget benchmark cache warmup/sync
 	go func() {
 		time.Sleep(1 * time.Second)
@@ -98,6 +180,19 @@ func (a *App) startup(ctx context.Context) {
 		}
 	}()
 
+	// Fire-and-forget sync of runs played on other machines
+	go func() {
+		time.Sleep(3 * time.Second)
+		n, err := a.SyncRemoteScores()
+		if err != nil {
+			runtime.LogDebugf(a.ctx, "remote scores sync: %v", err)
+			return
+		}
+		if n > 0 {
+			runtime.LogInfof(a.ctx, "synced %d remote runs into history", n)
+		}
+	}()
+
 	// Fire-and-forget check for app updates
 	go func() {
 		time.Sleep(2 * time.Second)
@@ -128,11 +223,38 @@ func (a *App) GetRecentScenarios(limit int) []models.ScenarioRecord {
 	return a.trackingSvc.GetRecent(limit)
 }
 
-// GetLastScenarioScores fetches the last 10 scores for a given scenario from KovaaK's API.
-func (a *App) GetLastScenarioScores(scenarioName string) ([]models.KovaaksLastScore, error) {
+// GetLastScenarioScores fetches the last 10 scores for a given scenario from KovaaK's API,
+// with freshness metadata (served from cache when offline).
+func (a *App) GetLastScenarioScores(scenarioName string) (models.LastScoresResult, error) {
 	return a.scenarioSvc.GetLastScores(scenarioName)
 }
 
+// GetScenarioLeaderboard returns a page of the scenario's global leaderboard.
+// When friends (usernames or Steam IDs) are given, only those players are listed.
+func (a *App) GetScenarioLeaderboard(scenarioName string, page int, pageSize int, friends []string) (models.LeaderboardPage, error) {
+	return a.leaderboardSvc.GetLeaderboard(scenarioName, page, pageSize, friends)
+}
+
+// GetScenarioPercentile returns the global rank and percentile of a score on a scenario.
+func (a *App) GetScenarioPercentile(scenarioName string, score float64) (models.ScenarioPercentile, error) {
+	return a.leaderboardSvc.GetPercentile(scenarioName, score)
+}
+
+// GetScenarioPercentiles returns live leaderboard score thresholds (p50..p99, globalHighscore) for a scenario.
+func (a *App) GetScenarioPercentiles(scenarioName string) (map[string]float64, error) {
+	return a.leaderboardSvc.GetPercentiles(scenarioName)
+}
+
+// SyncRemoteScores reconciles Kovaak's last scores of recently played scenarios with local
+// history and adds runs missing locally (marked source=remote). Returns how many were added.
+func (a *App) SyncRemoteScores() (int, error) {
+	added, err := a.scenarioSvc.ReconcileRemote(a.trackingSvc.GetRecent(0))
+	if err != nil {
+		return 0, err
+	}
+	return a.trackingSvc.AddRemoteRecords(added), nil
+}
+
 // GetBenchmarks returns the embedded benchmarks list for the Explore UI.
 func (a *App) GetBenchmarks() ([]models.Benchmark, error) {
 	return a.benchmarkSvc.GetBenchmarks()
@@ -149,9 +271,9 @@ func (a *App) GetBenchmarkProgress(benchmarkId int) (models.BenchmarkProgress, e
 	// 2. Trigger background refresh (if it was cached)
 	if cached {
 		go func() {
-			fresh, _, err := a.benchmarkSvc.GetBenchmarkProgress(benchmarkId, false)
-			if err == nil {
-				// Emit event with fresh data so frontend can update
+			fresh, stillCached, err := a.benchmarkSvc.GetBenchmarkProgress(benchmarkId, false)
+			if err == nil || stillCached {
+				// Emit event with fresh data (or the stale data carrying the refresh error) so frontend can update
 				runtime.EventsEmit(a.ctx, fmt.Sprintf("%s%d", constants.EventBenchmarkProgressPrefix, benchmarkId), fresh)
 			}
 		}()
@@ -170,6 +292,38 @@ func (a *App) RefreshAllBenchmarkProgresses() (map[int]models.BenchmarkProgress,
 	return a.benchmarkSvc.RefreshAllBenchmarkProgresses()
 }
 
+// --- Team IPC ---
+
+// GetTeammates returns the teammate roster.
+func (a *App) GetTeammates() []models.Teammate {
+	return a.teamSvc.GetRoster()
+}
+
+// AddTeammate adds a teammate by SteamID64 or Kovaak's/Steam persona name.
+func (a *App) AddTeammate(idOrName string) (models.Teammate, error) {
+	return a.teamSvc.AddTeammate(idOrName)
+}
+
+// RemoveTeammate removes a teammate from the roster.
+func (a *App) RemoveTeammate(steamID string) error {
+	return a.teamSvc.RemoveTeammate(steamID)
+}
+
+// GetTeamProgress returns benchmark progress for the user and every teammate.
+func (a *App) GetTeamProgress(benchmarkId int) ([]models.TeammateProgress, error) {
+	return a.teamSvc.GetTeamProgress(benchmarkId)
+}
+
+// CompareWithTeammate returns per-scenario score, rank and energy gaps against a teammate.
+func (a *App) CompareWithTeammate(benchmarkId int, steamID string) (models.TeamComparison, error) {
+	return a.teamSvc.CompareWithTeammate(benchmarkId, steamID)
+}
+
+// GetMostImproved ranks the team by benchmark progress gained over the last days (7 when 0).
+func (a *App) GetMostImproved(benchmarkId int, days int) ([]models.TeamImprovement, error) {
+	return a.teamSvc.GetMostImproved(benchmarkId, days)
+}
+
 // --- Settings IPC ---
 
 // GetSettings returns the current settings.
@@ -179,7 +333,33 @@ func (a *App) GetSettings() models.Settings {
 
 // UpdateSettings updates settings and persists them; applies to watcher if needed.
 func (a *App) UpdateSettings(s models.Settings) error {
-	return a.trackingSvc.UpdateSettings(s)
+	if err := a.trackingSvc.UpdateSettings(s); err != nil {
+		return err
+	}
+	a.kovaaksClient.SetOfflineMode(a.settingsSvc.Get().OfflineMode)
+	return nil
+}
+
+// --- Connectivity IPC ---
+
+// GetConnectivityStatus reports whether the Kovaak's API is reachable and if offline mode is on.
+func (a *App) GetConnectivityStatus() models.ConnectivityStatus {
+	return a.kovaaksClient.Connectivity()
+}
+
+// SetOfflineMode toggles offline mode. While enabled, remote data is served from cache only.
+// Turning it off triggers an immediate revalidation of cached data.
+func (a *App) SetOfflineMode(enabled bool) error {
+	settings := a.settingsSvc.Get()
+	settings.OfflineMode = enabled
+	if err := a.settingsSvc.Update(settings); err != nil {
+		return err
+	}
+	a.kovaaksClient.SetOfflineMode(enabled)
+	if !enabled {
+		a.revalidator.Trigger()
+	}
+	return nil
 }
 
 // Favorites helpers
@@ -207,7 +387,10 @@ func (a *App) ResetSettings(resetConfig, resetFavorites, resetScenarioNotes, res
 		newSettings.MouseBufferMinutes = defaults.MouseBufferMinutes
 		newSettings.MaxExistingOnStart = defaults.MaxExistingOnStart
 		newSettings.GeminiAPIKey = defaults.GeminiAPIKey
+		newSettings.AI = defaults.AI
 		newSettings.AutostartEnabled = defaults.AutostartEnabled
+		newSettings.OfflineMode = defaults.OfflineMode
+		a.kovaaksClient.SetOfflineMode(newSettings.OfflineMode)
 
 		// Sync autostart state
 		if newSettings.AutostartEnabled {
@@ -246,7 +429,51 @@ func (a *App) GetDefaultSettings() models.Settings {
 	return appsettings.Sanitize(appsettings.Default())
 }
 
+// GetSteamAccounts returns the Steam accounts that have logged in on this machine.
+func (a *App) GetSteamAccounts() ([]models.SteamAccount, error) {
+	return steam.ListAccounts(a.settingsSvc.Get())
+}
+
+// GetProfiles returns the local profiles (default plus one per Steam account); the active one is flagged.
+func (a *App) GetProfiles() []models.Profile {
+	return a.profilesSvc.List()
+}
+
+// SwitchProfile activates another profile: settings, notes, favorites, caches and history are
+// reloaded from its data, and the watcher and benchmark services restart on them.
+func (a *App) SwitchProfile(id string) (models.Profile, error) {
+	var p models.Profile
+	err := a.trackingSvc.ReloadProfile(func() error {
+		var err error
+		if p, err = a.profilesSvc.Switch(id); err != nil {
+			return err
+		}
+		// Drop in-memory caches of the previous profile before the watcher feeds runs into them;
+		// they are reloaded lazily from the new profile's cache
+		a.cacheSvc.Reload()
+		a.plansSvc.Reload()
+		a.goalsSvc.Reload()
+		return nil
+	})
+	if err != nil {
+		return models.Profile{}, err
+	}
+	a.kovaaksClient.SetOfflineMode(a.settingsSvc.Get().OfflineMode)
+	a.trackingSvc.AddRemoteRecords(a.scenarioSvc.RemoteRecords())
+	a.revalidator.Trigger()
+
+	runtime.LogInfof(a.ctx, "switched to profile %s", p.ID)
+	runtime.EventsEmit(a.ctx, constants.EventProfileChanged, p)
+	return p, nil
+}
+
+// SuggestPaths returns the Steam and Kovaak's stats locations detected on this machine (all Steam libraries).
+func (a *App) SuggestPaths() models.PathSuggestions {
+	return appsettings.SuggestPaths()
+}
+
 // LaunchKovaaksScenario opens the Steam deep-link to launch a given scenario in Kovaak's.
+// Active training plans that include the scenario advance to their next item.
 func (a *App) LaunchKovaaksScenario(name string, mode string) error {
 	n := url.PathEscape(name)
 	if n == "" {
@@ -258,6 +485,9 @@ func (a *App) LaunchKovaaksScenario(name string, mode string) error {
 	m := url.PathEscape(mode)
 	deeplink := fmt.Sprintf("steam://run/%d/?action=jump-to-scenario;name=%s;mode=%s", constants.KovaaksSteamAppID, n, m)
 	runtime.BrowserOpenURL(a.ctx, deeplink)
+	if a.plansSvc != nil {
+		a.plansSvc.Advance(name)
+	}
 	return nil
 }
 
@@ -305,12 +535,162 @@ func (a *App) GenerateSessionInsights(sessionId string, records []models.Scenari
 	return reqID, nil
 }
 
-// CancelSessionInsights cancels a running AI stream by requestId.
+// EstimateSessionInsights reports the estimated size of the prompt GenerateSessionInsights would send,
+// after summarising it to fit the configured token budget.
+func (a *App) EstimateSessionInsights(sessionId string, records []models.ScenarioRecord, prompt string, options models.AIOptions) (models.AIPromptEstimate, error) {
+	if sessionId == "" {
+		sessionId = "session"
+	}
+	return a.aiSvc.EstimateSessionInsights(sessionId, records, prompt, options), nil
+}
+
+// CancelSessionInsights cancels a running AI stream or training plan generation by requestId.
 func (a *App) CancelSessionInsights(requestId string) error {
 	a.aiSvc.Cancel(requestId)
 	return nil
 }
 
+// GenerateTrainingReview starts a streaming AI review of the training between two dates (YYYY-MM-DD, inclusive).
+// Empty dates default to the last few weeks. Follow-ups use AskSessionFollowUp with the returned sessionId.
+func (a *App) GenerateTrainingReview(from, to, prompt string, options models.AIOptions) (models.AIRequest, error) {
+	start, end, err := ai.ReviewRange(from, to, time.Now())
+	if err != nil {
+		return models.AIRequest{}, err
+	}
+	reqID := a.aiSvc.NewRequestID()
+	sessionID := a.aiSvc.GenerateTrainingReview(reqID, start, end, prompt, options)
+	return models.AIRequest{RequestID: reqID, SessionID: sessionID}, nil
+}
+
+// AskSessionFollowUp continues a session's stored AI conversation with a question and returns the requestId of the stream.
+func (a *App) AskSessionFollowUp(sessionId string, records []models.ScenarioRecord, question string, options models.AIOptions) (string, error) {
+	if sessionId == "" {
+		sessionId = "session"
+	}
+	reqID := a.aiSvc.NewRequestID()
+	if err := a.aiSvc.AskFollowUp(reqID, sessionId, records, question, options); err != nil {
+		return "", err
+	}
+	return reqID, nil
+}
+
+// ListAIConversations returns the stored AI conversations, most recent first.
+func (a *App) ListAIConversations() ([]models.AIConversationSummary, error) {
+	return a.aiSvc.Conversations()
+}
+
+// GetAIConversation returns the stored AI conversation of a session, to resume it.
+func (a *App) GetAIConversation(sessionId string) (models.AIConversation, error) {
+	return a.aiSvc.Conversation(sessionId)
+}
+
+// DeleteAIConversation removes the stored AI conversation of a session.
+func (a *App) DeleteAIConversation(sessionId string) error {
+	return a.aiSvc.DeleteConversation(sessionId)
+}
+
+// --- Training Plans ---
+
+// GenerateTrainingPlan asks the AI for a structured training plan based on the given session records
+// and stores it. It blocks until the plan has been generated and validated; CancelSessionInsights with
+// the caller-chosen requestId stops it.
+func (a *App) GenerateTrainingPlan(requestId string, records []models.ScenarioRecord, prompt string, options models.AIOptions) (models.Plan, error) {
+	if requestId == "" {
+		requestId = a.aiSvc.NewRequestID()
+	}
+	p, err := a.aiSvc.GenerateTrainingPlan(requestId, records, prompt, options)
+	if err != nil {
+		return models.Plan{}, err
+	}
+	return a.plansSvc.Save(p)
+}
+
+// GetPlans returns the stored training plans, newest first.
+func (a *App) GetPlans() []models.Plan {
+	return a.plansSvc.List()
+}
+
+// SavePlan creates (empty id) or updates a training plan.
+func (a *App) SavePlan(plan models.Plan) (models.Plan, error) {
+	return a.plansSvc.Save(plan)
+}
+
+// DeletePlan removes a training plan.
+func (a *App) DeletePlan(id string) error {
+	return a.plansSvc.Delete(id)
+}
+
+// SetPlanActive activates or deactivates a training plan; runs played while it is active count towards it.
+func (a *App) SetPlanActive(id string, active bool) (models.Plan, error) {
+	return a.plansSvc.SetActive(id, active)
+}
+
+// GetPlanProgress returns the progress of a training plan in its current (daily/weekly) period.
+func (a *App) GetPlanProgress(id string) (models.PlanProgress, error) {
+	return a.plansSvc.Progress(id)
+}
+
+// GetPlanAdherence returns completion stats (completion %, streaks) of a training plan over its scheduled periods.
+func (a *App) GetPlanAdherence(id string) (models.PlanAdherence, error) {
+	return a.plansSvc.Adherence(id)
+}
+
+// LaunchPlan opens a training plan in Kovaak's. Plans with a sharecode open the shared playlist;
+// others are written to Kovaak's playlists folder and the game jumps to the next unfinished scenario.
+func (a *App) LaunchPlan(id string) (models.PlanLaunch, error) {
+	p, err := a.plansSvc.Get(id)
+	if err != nil {
+		return models.PlanLaunch{}, err
+	}
+	if p.Sharecode != "" {
+		return models.PlanLaunch{}, a.LaunchKovaaksPlaylist(p.Sharecode)
+	}
+	path, err := a.plansSvc.WritePlaylist(p)
+	if err != nil {
+		// The deep-link still works without the playlist file
+		runtime.LogWarningf(a.ctx, "write playlist for plan %s: %v", p.ID, err)
+	}
+	item := 0
+	if next := plans.ProgressOf(p).Next; next >= 0 {
+		item = next
+	}
+	scenario := p.Items[item].Scenario
+	if err := a.LaunchKovaaksScenario(scenario, ""); err != nil {
+		return models.PlanLaunch{}, err
+	}
+	return models.PlanLaunch{PlaylistPath: path, Scenario: scenario}, nil
+}
+
+// --- Activity Stats ---
+
+// GetActivityStats returns practice statistics over the run history: playtime, daily/weekly streaks,
+// runs per scenario, hour and weekday heatmaps and weekly score consistency per scenario.
+func (a *App) GetActivityStats() models.ActivityStats {
+	return a.statsSvc.Activity()
+}
+
+// --- Goals ---
+
+// GetGoals returns the user's goals.
+func (a *App) GetGoals() []models.Goal {
+	return a.goalsSvc.List()
+}
+
+// SaveGoal creates (empty id) or updates a goal.
+func (a *App) SaveGoal(goal models.Goal) (models.Goal, error) {
+	return a.goalsSvc.Save(goal)
+}
+
+// DeleteGoal removes a goal.
+func (a *App) DeleteGoal(id string) error {
+	return a.goalsSvc.Delete(id)
+}
+
+// GetGoalStatuses evaluates every goal now: current value, progress, forecast date and pace.
+func (a *App) GetGoalStatuses() []models.GoalStatus {
+	return a.goalsSvc.Evaluate()
+}
+
 // SaveScenarioNote persists a user note and sensitivity for a scenario.
 func (a *App) SaveScenarioNote(scenario, notes, sens string) error {
 	return a.trackingSvc.SaveScenarioNote(scenario, notes, sens)
@@ -343,6 +723,95 @@ func (a *App) GetScenarioTrace(fileName string) (string, error) {
 	return traces.EncodeTraceBase64(data.MouseTrace)
 }
 
+// GetTraceMetrics returns movement metrics (speed, flicks, smoothness, click timing) for a scenario's trace.
+func (a *App) GetTraceMetrics(fileName string) (models.TraceMetrics, error) {
+	if !a.tracesSvc.Exists(fileName) {
+		return models.TraceMetrics{}, fmt.Errorf("trace not found")
+	}
+	return a.tracesSvc.Metrics(fileName)
+}
+
+// GetTraceMetricsHistory returns trace metrics of a scenario's stored runs, most recent first.
+func (a *App) GetTraceMetricsHistory(scenarioName string, limit int) ([]models.TraceMetricsHistoryEntry, error) {
+	return a.tracesSvc.MetricsHistory(scenarioName, limit)
+}
+
+// GetKillSegments splits a scenario's trace at each kill and measures every target segment
+// (reaction, time to first shot, overshoot, micro-corrections, path efficiency).
+func (a *App) GetKillSegments(fileName string) (models.KillSegmentation, error) {
+	rec, ok := a.trackingSvc.FindRecent(fileName)
+	if !ok {
+		return models.KillSegmentation{}, fmt.Errorf("scenario not found")
+	}
+	if !a.tracesSvc.Exists(fileName) {
+		return models.KillSegmentation{}, fmt.Errorf("trace not found")
+	}
+	data, err := a.tracesSvc.Load(fileName)
+	if err != nil {
+		return models.KillSegmentation{}, err
+	}
+	return traceanalysis.SegmentRecord(data.MouseTrace, rec), nil
+}
+
+// MigrateTraces rewrites stored mouse traces (legacy JSON, binary v1) in the current compact format.
+func (a *App) MigrateTraces() (models.TraceMigrationResult, error) {
+	return a.tracesSvc.Migrate()
+}
+
+// ExportTraces writes the selected stored traces as CSV or NDJSON for use in external tools.
+func (a *App) ExportTraces(opts models.TraceExportOptions) (models.TraceExportResult, error) {
+	return a.tracesSvc.Export(opts)
+}
+
+// PruneTraces applies the configured trace retention policy now and reports the reclaimed space.
+func (a *App) PruneTraces() (models.TracePruneResult, error) {
+	res, err := a.tracesSvc.Prune(a.settingsSvc.Get().TraceRetention)
+	if err != nil {
+		return res, err
+	}
+	a.onTracesPruned(res)
+	return res, nil
+}
+
+// GetTracesStorageStats summarises stored mouse traces and the retention policy.
+func (a *App) GetTracesStorageStats() (models.TracesStorageStats, error) {
+	return a.tracesSvc.StorageStats(a.settingsSvc.Get().TraceRetention)
+}
+
+// ListTraces returns the stored traces of a scenario (all scenarios when empty), most recent first.
+func (a *App) ListTraces(scenarioName string) ([]models.TraceIndexEntry, error) {
+	return a.tracesSvc.List(scenarioName)
+}
+
+// GetOrphanTraces returns stored traces whose stats CSV no longer exists.
+func (a *App) GetOrphanTraces() ([]models.TraceIndexEntry, error) {
+	return a.tracesSvc.Orphans()
+}
+
+// RebuildTraceIndex rescans the traces directory and rewrites its index.
+func (a *App) RebuildTraceIndex() (int, error) {
+	n, err := a.tracesSvc.RebuildIndex()
+	if err != nil {
+		return 0, err
+	}
+	if a.trackingSvc != nil {
+		a.trackingSvc.ReloadTraces()
+	}
+	return n, nil
+}
+
+// onTracesPruned refreshes recent scenarios that lost their trace and notifies the frontend.
+func (a *App) onTracesPruned(res models.TracePruneResult) {
+	if res.Deleted == 0 {
+		return
+	}
+	runtime.LogInfof(a.ctx, "pruned %d traces, reclaimed %d bytes", res.Deleted, res.BytesReclaimed)
+	if a.trackingSvc != nil {
+		a.trackingSvc.ReloadTraces()
+	}
+	runtime.EventsEmit(a.ctx, constants.EventTracesPruned, res)
+}
+
 // --- Autostart & Monitoring ---
 
 func (a *App) SetAutostart(enabled bool) error {
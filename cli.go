@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+	"refleks/internal/traces"
+)
+
+// newCLITracesService returns a traces service pointed at the configured traces directory,
+// for headless commands that run without the Wails runtime.
+func newCLITracesService() *traces.Service {
+	settingsSvc := appsettings.NewService()
+	if err := settingsSvc.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "settings load failed, using defaults: %v\n", err)
+	}
+	svc := traces.NewService()
+	svc.SetBaseDir(appsettings.ExpandPathPlaceholders(settingsSvc.Get().TracesDir))
+	svc.SetStatsDir(appsettings.StatsDirOf(settingsSvc.Get()))
+	return svc
+}
+
+// runMigrateTraces rewrites stored traces in the current format and returns the process exit code.
+func runMigrateTraces() int {
+	res, err := newCLITracesService().Migrate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "trace migration failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("traces scanned: %d, migrated: %d, skipped: %d, failed: %d\n", res.Scanned, res.Migrated, res.Skipped, res.Failed)
+	if res.Migrated > 0 {
+		fmt.Printf("size: %d -> %d bytes\n", res.BytesBefore, res.BytesAfter)
+	}
+	for _, e := range res.Errors {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	if res.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runExportTraces writes the selected traces to CSV or NDJSON files and returns the process exit code.
+func runExportTraces(opts models.TraceExportOptions) int {
+	res, err := newCLITracesService().Export(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "trace export failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("traces exported: %d, failed: %d\n", res.Exported, res.Failed)
+	if res.Exported > 0 {
+		fmt.Printf("output: %s\n", res.Dir)
+	}
+	for _, e := range res.Errors {
+		fmt.Fprintln(os.Stderr, e)
+	}
+	if res.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
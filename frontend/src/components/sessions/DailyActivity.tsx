@@ -1,16 +1,17 @@
 import { Calendar, Flame } from 'lucide-react'
 import { useMemo } from 'react'
+import { useActivityStats } from '../../hooks/useActivityStats'
 import { calculateDailyActivity } from '../../lib/analysis/activity'
 import { formatDuration } from '../../lib/utils'
 import type { Session } from '../../types/domain'
 
 type DailyActivityProps = {
   currentSession: Session | null
-  allSessions: Session[]
 }
 
-export function DailyActivity({ currentSession, allSessions }: DailyActivityProps) {
-  const stats = useMemo(() => calculateDailyActivity(currentSession, allSessions), [currentSession, allSessions])
+export function DailyActivity({ currentSession }: DailyActivityProps) {
+  const activity = useActivityStats()
+  const stats = useMemo(() => calculateDailyActivity(currentSession, activity), [currentSession, activity])
 
   if (!currentSession) return null
 
@@ -0,0 +1,31 @@
+import type { Freshness } from '../../types/ipc'
+
+type FreshnessBadgeProps = { freshness?: Freshness }
+
+function formatAge(iso: string): string {
+  const t = Date.parse(iso)
+  if (!Number.isFinite(t) || t <= 0) return 'unknown time'
+  const mins = Math.max(0, Math.round((Date.now() - t) / 60000))
+  if (mins < 1) return 'just now'
+  if (mins < 60) return `${mins} min ago`
+  const hours = Math.round(mins / 60)
+  if (hours < 48) return `${hours} h ago`
+  return `${Math.round(hours / 24)} days ago`
+}
+
+// Shows whether remote data is current. Renders nothing for fresh live/cached data.
+export function FreshnessBadge({ freshness }: FreshnessBadgeProps) {
+  if (!freshness || !freshness.stale) return null
+  const age = formatAge(freshness.fetchedAt)
+  const title = freshness.lastError
+    ? `Showing cached data from ${age}. Last refresh failed: ${freshness.lastError}`
+    : `Showing cached data from ${age}. It will refresh in the background.`
+  return (
+    <span
+      className="inline-flex items-center px-2 py-0.5 rounded-full border text-[10px] leading-none bg-surface-3 text-secondary border-primary"
+      title={title}
+    >
+      {freshness.lastError ? 'Offline' : 'Cached'} · {age}
+    </span>
+  )
+}
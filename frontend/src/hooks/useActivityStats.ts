@@ -0,0 +1,36 @@
+import { useEffect, useState } from 'react'
+import { EventsOn } from '../../wailsjs/runtime'
+import { getActivityStats } from '../lib/internal'
+import type { ActivityStats } from '../types/ipc'
+
+// Activity stats from the backend, refreshed (debounced) as runs come in or the profile changes
+export function useActivityStats() {
+  const [stats, setStats] = useState<ActivityStats | null>(null)
+
+  useEffect(() => {
+    let cancelled = false
+    let timer: ReturnType<typeof setTimeout> | null = null
+    const load = () => {
+      getActivityStats()
+        .then((s) => { if (!cancelled) setStats(s) })
+        .catch(() => { })
+    }
+    const trigger = () => {
+      if (timer) clearTimeout(timer)
+      timer = setTimeout(load, 1000)
+    }
+    load()
+    const offAdd = EventsOn('scenario:added', () => trigger())
+    const offUpd = EventsOn('scenario:updated', () => trigger())
+    const offProfile = EventsOn('profile:changed', () => trigger())
+    return () => {
+      cancelled = true
+      if (timer) clearTimeout(timer)
+      offAdd()
+      offUpd()
+      offProfile()
+    }
+  }, [])
+
+  return stats
+}
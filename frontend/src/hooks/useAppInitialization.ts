@@ -53,10 +53,26 @@ export function useAppInitialization() {
       resetNew()
     })
 
+    const offProfile = EventsOn('profile:changed', (_data: any) => {
+      // Another player's history, notes and session gap
+      getRecentScenarios()
+        .then((arr) => { setScenarios(arr); resetNew() })
+        .catch((err: unknown) => console.warn('GetRecentScenarios failed:', err))
+      getSettings()
+        .then((s) => {
+          if (s) {
+            if (typeof s.sessionGapMinutes === 'number') setSessionGap(s.sessionGapMinutes)
+            setSessionNotes(s.sessionNotes || {})
+          }
+        })
+        .catch(() => { })
+    })
+
     return () => {
       try { off() } catch (e) { /* ignore */ }
       try { offUpd() } catch (e) { /* ignore */ }
       try { offWatcher() } catch (e) { /* ignore */ }
+      try { offProfile() } catch (e) { /* ignore */ }
     }
-  }, [addScenario, updateScenario, incNew, setScenarios, resetNew])
+  }, [addScenario, updateScenario, incNew, setScenarios, resetNew, setSessionGap, setSessionNotes])
 }
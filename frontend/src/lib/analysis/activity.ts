@@ -1,57 +1,28 @@
 import type { Session } from '../../types/domain'
+import type { ActivityStats } from '../../types/ipc'
 
 export interface DailyActivityStats {
   playtimeMs: number
   streak: number
 }
 
-export function calculateDailyActivity(currentSession: Session | null, allSessions: Session[]): DailyActivityStats {
-  if (!currentSession) return { playtimeMs: 0, streak: 0 }
+// Local YYYY-MM-DD, the day key of the backend activity stats
+const dayKey = (d: Date) => `${d.getFullYear()}-${String(d.getMonth() + 1).padStart(2, '0')}-${String(d.getDate()).padStart(2, '0')}`
 
-  const sessionDate = new Date(currentSession.start)
-  const getDateStr = (d: Date) => `${d.getFullYear()}-${d.getMonth() + 1}-${d.getDate()}`
-  const targetDateStr = getDateStr(sessionDate)
-
-  // 1. Daily Playtime
-  let playtimeSeconds = 0
-  const activityDays = new Set<string>()
-
-  for (const sess of allSessions) {
-    // Check if session has items
-    if (!sess.items || sess.items.length === 0) continue
-
-    // For playtime, we only care about items on the target date
-    for (const item of sess.items) {
-      const itemDate = new Date(item.stats['Date Played'])
-      const itemDateStr = getDateStr(itemDate)
+// Playtime of the session's day and the daily streak ending on it, read from the backend activity stats
+export function calculateDailyActivity(currentSession: Session | null, stats: ActivityStats | null): DailyActivityStats {
+  if (!currentSession || !stats) return { playtimeMs: 0, streak: 0 }
 
-      activityDays.add(itemDateStr)
-
-      if (itemDateStr === targetDateStr) {
-        const d = Number(item.stats['Duration'])
-        if (Number.isFinite(d)) {
-          playtimeSeconds += d
-        }
-      }
-    }
-  }
+  const byDay = new Map(stats.days.map(d => [d.date, d]))
+  const sessionDate = new Date(currentSession.start)
+  const playtimeSeconds = byDay.get(dayKey(sessionDate))?.playtimeSec ?? 0
 
-  // 2. Daily Streak
-  // Count consecutive days backwards from targetDateStr (inclusive)
+  // Count consecutive active days backwards from the session's day (inclusive)
   let streak = 0
   const checkDate = new Date(sessionDate)
-
-  // If we played today, streak is at least 1.
-  // We iterate backwards day by day.
-  while (true) {
-    const dateStr = getDateStr(checkDate)
-    if (activityDays.has(dateStr)) {
-      streak++
-      // Move to previous day
-      checkDate.setDate(checkDate.getDate() - 1)
-    } else {
-      break
-    }
+  while (byDay.has(dayKey(checkDate))) {
+    streak++
+    checkDate.setDate(checkDate.getDate() - 1)
   }
 
   return { playtimeMs: playtimeSeconds * 1000, streak }
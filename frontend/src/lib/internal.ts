@@ -1,32 +1,77 @@
 import {
+  AddTeammate as _AddTeammate,
+  AskSessionFollowUp as _AskSessionFollowUp,
   CancelSessionInsights as _CancelSessionInsights,
   CheckForUpdates as _CheckForUpdates,
   ClearCache as _ClearCache,
+  CompareWithTeammate as _CompareWithTeammate,
+  DeleteAIConversation as _DeleteAIConversation,
+  DeleteGoal as _DeleteGoal,
+  DeletePlan as _DeletePlan,
   DownloadAndInstallUpdate as _DownloadAndInstallUpdate,
+  EstimateSessionInsights as _EstimateSessionInsights,
+  ExportTraces as _ExportTraces,
   GenerateSessionInsights as _GenerateSessionInsights,
+  GenerateTrainingPlan as _GenerateTrainingPlan,
+  GenerateTrainingReview as _GenerateTrainingReview,
+  GetAIConversation as _GetAIConversation,
+  GetActivityStats as _GetActivityStats,
   GetAllBenchmarkProgresses as _GetAllBenchmarkProgresses,
   GetBenchmarkProgress as _GetBenchmarkProgress,
   GetBenchmarks as _GetBenchmarks,
+  GetConnectivityStatus as _GetConnectivityStatus,
   GetDefaultSettings as _GetDefaultSettings,
   GetFavoriteBenchmarks as _GetFavoriteBenchmarks,
+  GetGoalStatuses as _GetGoalStatuses,
+  GetGoals as _GetGoals,
+  GetKillSegments as _GetKillSegments,
   GetLastScenarioScores as _GetLastScenarioScores,
+  GetMostImproved as _GetMostImproved,
+  GetOrphanTraces as _GetOrphanTraces,
+  GetPlanAdherence as _GetPlanAdherence,
+  GetPlanProgress as _GetPlanProgress,
+  GetPlans as _GetPlans,
+  GetProfiles as _GetProfiles,
   GetRecentScenarios as _GetRecentScenarios,
+  GetScenarioLeaderboard as _GetScenarioLeaderboard,
+  GetScenarioPercentile as _GetScenarioPercentile,
+  GetScenarioPercentiles as _GetScenarioPercentiles,
   GetSettings as _GetSettings,
+  GetSteamAccounts as _GetSteamAccounts,
+  GetTeamProgress as _GetTeamProgress,
+  GetTeammates as _GetTeammates,
+  GetTraceMetrics as _GetTraceMetrics,
+  GetTraceMetricsHistory as _GetTraceMetricsHistory,
+  GetTracesStorageStats as _GetTracesStorageStats,
   GetVersion as _GetVersion,
   LaunchKovaaksPlaylist as _LaunchKovaaksPlaylist,
   LaunchKovaaksScenario as _LaunchKovaaksScenario,
+  LaunchPlan as _LaunchPlan,
+  ListAIConversations as _ListAIConversations,
+  ListTraces as _ListTraces,
+  MigrateTraces as _MigrateTraces,
+  PruneTraces as _PruneTraces,
   QuitApp as _QuitApp,
+  RebuildTraceIndex as _RebuildTraceIndex,
   RefreshAllBenchmarkProgresses as _RefreshAllBenchmarkProgresses,
+  RemoveTeammate as _RemoveTeammate,
   ResetSettings as _ResetSettings,
+  SaveGoal as _SaveGoal,
+  SavePlan as _SavePlan,
   SaveScenarioNote as _SaveScenarioNote,
   SaveSessionNote as _SaveSessionNote,
   SetAutostart as _SetAutostart,
   SetFavoriteBenchmarks as _SetFavoriteBenchmarks,
+  SetOfflineMode as _SetOfflineMode,
+  SetPlanActive as _SetPlanActive,
   StartWatcher as _StartWatcher,
   StopWatcher as _StopWatcher,
+  SuggestPaths as _SuggestPaths,
+  SwitchProfile as _SwitchProfile,
+  SyncRemoteScores as _SyncRemoteScores,
   UpdateSettings as _UpdateSettings
 } from '../../wailsjs/go/main/App'
-import type { Benchmark, BenchmarkProgress, KovaaksLastScore, ScenarioRecord, Settings, UpdateInfo } from '../types/ipc'
+import type { AIConversation, AIConversationSummary, AIPromptEstimate, AIRequest, ActivityStats, Benchmark, BenchmarkProgress, ConnectivityStatus, Goal, GoalStatus, KillSegmentation, KovaaksLastScore, LastScoresResult, LeaderboardPage, PathSuggestions, Plan, PlanAdherence, PlanLaunch, PlanProgress, Profile, ScenarioPercentile, ScenarioRecord, Settings, SteamAccount, TeamComparison, TeamImprovement, Teammate, TeammateProgress, TraceExportOptions, TraceExportResult, TraceIndexEntry, TraceMetrics, TraceMetricsHistoryEntry, TraceMigrationResult, TracePruneResult, TracesStorageStats, UpdateInfo } from '../types/ipc'
 
 // Typed wrappers around Wails-generated bindings with normalized results
 
@@ -51,9 +96,72 @@ export async function getRecentScenarios(limit = 0): Promise<ScenarioRecord[]> {
   return (Array.isArray(res) ? res : []) as unknown as ScenarioRecord[]
 }
 
+export async function syncRemoteScores(): Promise<number> {
+  const n = await _SyncRemoteScores()
+  return typeof n === 'number' ? n : 0
+}
+
 export async function getLastScenarioScores(scenarioName: string): Promise<KovaaksLastScore[]> {
-  const res = await _GetLastScenarioScores(scenarioName)
-  return (Array.isArray(res) ? res : []) as unknown as KovaaksLastScore[]
+  const res = await getLastScenarioScoresResult(scenarioName)
+  return res.scores
+}
+
+export async function getLastScenarioScoresResult(scenarioName: string): Promise<LastScoresResult> {
+  const res = await _GetLastScenarioScores(scenarioName) as unknown as LastScoresResult
+  return { scores: Array.isArray(res?.scores) ? res.scores : [], freshness: res?.freshness }
+}
+
+export async function getScenarioLeaderboard(scenarioName: string, page = 0, pageSize = 0, friends: string[] = []): Promise<LeaderboardPage> {
+  const res = await _GetScenarioLeaderboard(scenarioName, page, pageSize, friends) as unknown as LeaderboardPage
+  return { ...res, entries: Array.isArray(res?.entries) ? res.entries : [] }
+}
+
+export async function getScenarioPercentile(scenarioName: string, score: number): Promise<ScenarioPercentile> {
+  const res = await _GetScenarioPercentile(scenarioName, score)
+  return res as unknown as ScenarioPercentile
+}
+
+export async function getScenarioPercentiles(scenarioName: string): Promise<Record<string, number>> {
+  const res = await _GetScenarioPercentiles(scenarioName)
+  return (res ?? {}) as Record<string, number>
+}
+
+export async function getTeammates(): Promise<Teammate[]> {
+  const res = await _GetTeammates()
+  return (Array.isArray(res) ? res : []) as unknown as Teammate[]
+}
+
+export async function addTeammate(idOrName: string): Promise<Teammate> {
+  const res = await _AddTeammate(idOrName)
+  return res as unknown as Teammate
+}
+
+export async function removeTeammate(steamId: string): Promise<void> {
+  await _RemoveTeammate(steamId)
+}
+
+export async function getTeamProgress(benchmarkId: number): Promise<TeammateProgress[]> {
+  const res = await _GetTeamProgress(benchmarkId)
+  return (Array.isArray(res) ? res : []) as unknown as TeammateProgress[]
+}
+
+export async function compareWithTeammate(benchmarkId: number, steamId: string): Promise<TeamComparison> {
+  const res = await _CompareWithTeammate(benchmarkId, steamId) as unknown as TeamComparison
+  return { ...res, scenarios: Array.isArray(res?.scenarios) ? res.scenarios : [] }
+}
+
+export async function getMostImproved(benchmarkId: number, days = 0): Promise<TeamImprovement[]> {
+  const res = await _GetMostImproved(benchmarkId, days)
+  return (Array.isArray(res) ? res : []) as unknown as TeamImprovement[]
+}
+
+export async function getConnectivityStatus(): Promise<ConnectivityStatus> {
+  const st = await _GetConnectivityStatus()
+  return st as unknown as ConnectivityStatus
+}
+
+export async function setOfflineMode(enabled: boolean): Promise<void> {
+  await _SetOfflineMode(enabled)
 }
 
 export async function getSettings(): Promise<Settings> {
@@ -66,6 +174,31 @@ export async function getDefaultSettings(): Promise<Settings> {
   return s as unknown as Settings
 }
 
+export async function getSteamAccounts(): Promise<SteamAccount[]> {
+  const res = await _GetSteamAccounts()
+  return (Array.isArray(res) ? res : []) as unknown as SteamAccount[]
+}
+
+export async function getProfiles(): Promise<Profile[]> {
+  const res = await _GetProfiles()
+  return (Array.isArray(res) ? res : []) as unknown as Profile[]
+}
+
+export async function switchProfile(id: string): Promise<Profile> {
+  return await _SwitchProfile(id) as unknown as Profile
+}
+
+export async function suggestPaths(): Promise<PathSuggestions> {
+  const res = await _SuggestPaths() as unknown as PathSuggestions
+  const list = (v: unknown) => (Array.isArray(v) ? v : []) as string[]
+  return {
+    steamInstallDirs: list(res?.steamInstallDirs),
+    libraryDirs: list(res?.libraryDirs),
+    kovaaksDirs: list(res?.kovaaksDirs),
+    statsDirs: list(res?.statsDirs),
+  }
+}
+
 export async function updateSettings(payload: Settings): Promise<void> {
   await _UpdateSettings(payload as any)
 }
@@ -141,10 +274,106 @@ export async function generateSessionInsights(sessionId: string, records: Scenar
   return String(reqId || '')
 }
 
+// Estimated prompt size of generateSessionInsights after fitting the token budget
+export async function estimateSessionInsights(sessionId: string, records: ScenarioRecord[], prompt: string, options: any): Promise<AIPromptEstimate> {
+  return await _EstimateSessionInsights(String(sessionId || 'session'), records as any, String(prompt || ''), options as any) as unknown as AIPromptEstimate
+}
+
+// Review the training between two dates (YYYY-MM-DD, inclusive); empty dates default to the last few weeks
+export async function generateTrainingReview(from: string, to: string, prompt: string, options: any): Promise<AIRequest> {
+  const res = await _GenerateTrainingReview(String(from || ''), String(to || ''), String(prompt || ''), options as any)
+  return { requestId: String(res?.requestId || ''), sessionId: String(res?.sessionId || '') }
+}
+
 export async function cancelSessionInsights(requestId: string): Promise<void> {
   await _CancelSessionInsights(String(requestId || ''))
 }
 
+export async function askSessionFollowUp(sessionId: string, records: ScenarioRecord[], question: string, options: any): Promise<string> {
+  const reqId = await _AskSessionFollowUp(String(sessionId || 'session'), records as any, String(question || ''), options as any)
+  return String(reqId || '')
+}
+
+export async function listAIConversations(): Promise<AIConversationSummary[]> {
+  const res = await _ListAIConversations()
+  return Array.isArray(res) ? (res as unknown as AIConversationSummary[]) : []
+}
+
+export async function getAIConversation(sessionId: string): Promise<AIConversation> {
+  const res = await _GetAIConversation(String(sessionId || '')) as unknown as AIConversation
+  return { ...res, turns: Array.isArray(res?.turns) ? res.turns : [] }
+}
+
+export async function deleteAIConversation(sessionId: string): Promise<void> {
+  await _DeleteAIConversation(String(sessionId || ''))
+}
+
+// Generate and store an AI training plan from session records (blocks until the plan is validated)
+// Blocks until the plan is generated; pass a requestId to be able to stop it with cancelSessionInsights
+export async function generateTrainingPlan(records: ScenarioRecord[], prompt: string, options: any, requestId = ''): Promise<Plan> {
+  return await _GenerateTrainingPlan(String(requestId || ''), records as any, String(prompt || ''), options as any) as unknown as Plan
+}
+
+export async function getPlans(): Promise<Plan[]> {
+  const res = await _GetPlans()
+  return Array.isArray(res) ? (res as unknown as Plan[]) : []
+}
+
+// Create (empty id) or update a training plan
+export async function savePlan(plan: Plan): Promise<Plan> {
+  return await _SavePlan(plan as any) as unknown as Plan
+}
+
+export async function deletePlan(id: string): Promise<void> {
+  await _DeletePlan(String(id || ''))
+}
+
+export async function setPlanActive(id: string, active: boolean): Promise<Plan> {
+  return await _SetPlanActive(String(id || ''), !!active) as unknown as Plan
+}
+
+// Progress in the current daily/weekly period
+export async function getPlanProgress(id: string): Promise<PlanProgress> {
+  return await _GetPlanProgress(String(id || '')) as unknown as PlanProgress
+}
+
+// Completion % and streaks over the plan's scheduled periods
+export async function getPlanAdherence(id: string): Promise<PlanAdherence> {
+  const res = await _GetPlanAdherence(String(id || '')) as unknown as PlanAdherence
+  return { ...res, history: Array.isArray(res?.history) ? res.history : [] }
+}
+
+// Open a plan in Kovaak's: its shared playlist, or the next unfinished scenario
+export async function launchPlan(id: string): Promise<PlanLaunch> {
+  return await _LaunchPlan(String(id || '')) as unknown as PlanLaunch
+}
+
+// Playtime, streaks, heatmaps and consistency over the whole run history
+export async function getActivityStats(): Promise<ActivityStats> {
+  const res = await _GetActivityStats() as unknown as ActivityStats
+  return { ...res, days: Array.isArray(res?.days) ? res.days : [] }
+}
+
+export async function getGoals(): Promise<Goal[]> {
+  const res = await _GetGoals()
+  return Array.isArray(res) ? (res as unknown as Goal[]) : []
+}
+
+// Create (empty id) or update a goal
+export async function saveGoal(goal: Goal): Promise<Goal> {
+  return await _SaveGoal(goal as any) as unknown as Goal
+}
+
+export async function deleteGoal(id: string): Promise<void> {
+  await _DeleteGoal(String(id || ''))
+}
+
+// Evaluate all goals now (progress, forecast date, pace)
+export async function getGoalStatuses(): Promise<GoalStatus[]> {
+  const res = await _GetGoalStatuses()
+  return Array.isArray(res) ? (res as unknown as GoalStatus[]) : []
+}
+
 export async function clearCache(): Promise<void> {
   await _ClearCache()
 }
@@ -153,3 +382,51 @@ export async function getScenarioTrace(fileName: string): Promise<string> {
   // Direct call to avoid build errors before Wails regenerates bindings
   return await (window as any).go.main.App.GetScenarioTrace(fileName)
 }
+
+export async function migrateTraces(): Promise<TraceMigrationResult> {
+  const res = await _MigrateTraces() as unknown as TraceMigrationResult
+  return { ...res, errors: Array.isArray(res?.errors) ? res.errors : [] }
+}
+
+export async function exportTraces(opts: TraceExportOptions): Promise<TraceExportResult> {
+  const res = await _ExportTraces(opts as any) as unknown as TraceExportResult
+  return { ...res, files: Array.isArray(res?.files) ? res.files : [], errors: Array.isArray(res?.errors) ? res.errors : [] }
+}
+
+export async function pruneTraces(): Promise<TracePruneResult> {
+  const res = await _PruneTraces() as unknown as TracePruneResult
+  return { ...res, errors: Array.isArray(res?.errors) ? res.errors : [] }
+}
+
+export async function getTracesStorageStats(): Promise<TracesStorageStats> {
+  return await _GetTracesStorageStats() as unknown as TracesStorageStats
+}
+
+export async function listTraces(scenarioName = ''): Promise<TraceIndexEntry[]> {
+  const res = await _ListTraces(scenarioName)
+  return (Array.isArray(res) ? res : []) as unknown as TraceIndexEntry[]
+}
+
+export async function getOrphanTraces(): Promise<TraceIndexEntry[]> {
+  const res = await _GetOrphanTraces()
+  return (Array.isArray(res) ? res : []) as unknown as TraceIndexEntry[]
+}
+
+export async function rebuildTraceIndex(): Promise<number> {
+  const n = await _RebuildTraceIndex()
+  return typeof n === 'number' ? n : 0
+}
+
+export async function getTraceMetrics(fileName: string): Promise<TraceMetrics> {
+  return await _GetTraceMetrics(fileName) as unknown as TraceMetrics
+}
+
+export async function getTraceMetricsHistory(scenarioName: string, limit = 0): Promise<TraceMetricsHistoryEntry[]> {
+  const res = await _GetTraceMetricsHistory(scenarioName, limit)
+  return (Array.isArray(res) ? res : []) as unknown as TraceMetricsHistoryEntry[]
+}
+
+export async function getKillSegments(fileName: string): Promise<KillSegmentation> {
+  const res = await _GetKillSegments(fileName) as unknown as KillSegmentation
+  return { ...res, segments: Array.isArray(res?.segments) ? res.segments : [], phases: Array.isArray(res?.phases) ? res.phases : [] }
+}
@@ -2,6 +2,7 @@ import { Camera, ChevronLeft, Play, Star } from 'lucide-react';
 import { useEffect, useRef, useState } from 'react';
 import ShareBenchmarkProgress from '../../components/benchmarks/ShareBenchmarkProgress';
 import { Dropdown } from '../../components/shared/Dropdown';
+import { FreshnessBadge } from '../../components/shared/FreshnessBadge';
 import { Tabs } from '../../components/shared/Tabs';
 import { useOpenedBenchmarkProgress } from '../../hooks/useOpenedBenchmarkProgress';
 import { useUIState } from '../../hooks/useUIState';
@@ -119,6 +120,7 @@ export function BenchmarksDetail({ id, bench, favorites, onToggleFav, onBack }:
             onChange={(v: string) => setDifficultyIndex(Number(v))}
             options={bench.difficulties.map((d, i) => ({ label: d.difficultyName, value: i }))}
           />
+          <FreshnessBadge freshness={progress?.freshness} />
         </div>
       ) : <div className="text-sm text-secondary">No difficulties info.</div>}
       <Tabs tabs={[
@@ -89,7 +89,7 @@ export function OverviewTab({ session }: OverviewTabProps) {
       {/* Quick session status bar */}
       <div className="flex flex-wrap gap-3 w-full">
         <SessionStatus currentSession={session} analysis={analysis} recommendation={recommendation} />
-        <DailyActivity currentSession={session} allSessions={allSessions} />
+        <DailyActivity currentSession={session} />
       </div>
 
       {/* Global controls for this tab */}
@@ -13,6 +13,8 @@ export interface ScenarioRecord {
   mouseTrace?: Array<MousePoint>
   traceData?: string
   hasTrace?: boolean
+  // 'remote' for runs synced from Kovaak's without a local stats file (limited stats, no events)
+  source?: 'remote'
 }
 
 export interface BenchmarkDifficulty {
@@ -57,11 +59,27 @@ export interface ProgressCategory {
   groups: ProgressGroup[]
 }
 
+// Freshness describes where remote-backed data came from and how current it is
+export interface Freshness {
+  fetchedAt: string
+  source: 'live' | 'cache'
+  stale: boolean
+  lastError?: string
+}
+
+export interface ConnectivityStatus {
+  online: boolean
+  offlineMode: boolean
+  lastChange: string
+  lastError?: string
+}
+
 export interface BenchmarkProgress {
   overallRank: number
   benchmarkProgress: number
   ranks: RankDef[]
   categories: ProgressCategory[]
+  freshness?: Freshness
 }
 
 import type { Font, Theme } from '../lib/theme'
@@ -72,19 +90,96 @@ export interface Settings {
   personaNameOverride?: string
   statsDir: string
   tracesDir: string
+  traceRetention?: TraceRetentionPolicy
   sessionGapMinutes: number
   theme: Theme
   font: Font
   favoriteBenchmarks?: string[]
+  teammates?: Teammate[]
   mouseTrackingEnabled?: boolean
   mouseBufferMinutes?: number
   maxExistingOnStart?: number
   autostartEnabled?: boolean
+  offlineMode?: boolean
   geminiApiKey?: string
+  ai?: AISettings
   scenarioNotes?: Record<string, ScenarioNote>
   sessionNotes?: Record<string, SessionNote>
 }
 
+export type AIProvider = 'gemini' | 'openai' | 'ollama'
+
+export interface AISettings {
+  provider: AIProvider
+  model?: string
+  baseUrl?: string
+  apiKey?: string
+  promptTokenBudget?: number
+}
+
+export interface AIUsage {
+  promptTokens: number
+  completionTokens: number
+  totalTokens: number
+}
+
+export interface AIPromptEstimate {
+  tokens: number
+  systemTokens: number
+  dataTokens: number
+  historyTokens?: number
+  budget: number
+  runsPerScenario: number
+  scenarios: number
+  scenariosOmitted: number
+  libraryEntries: number
+  summarised: boolean
+  overBudget: boolean
+}
+
+export interface AIRequest {
+  requestId: string
+  sessionId: string
+}
+
+export interface BenchmarkRankChange {
+  benchmarkId: number
+  benchmark: string
+  from: string
+  to: string
+  rankFrom: string
+  rankTo: string
+  progressFrom: number
+  progressTo: number
+}
+
+export interface AITurn {
+  role: 'user' | 'assistant'
+  content: string
+  at: string
+  usage?: AIUsage
+}
+
+export interface AIConversation {
+  sessionId: string
+  options: { maxRunsPerScenario: number; systemPersona: string }
+  provider: string
+  model: string
+  createdAt: string
+  updatedAt: string
+  turns: AITurn[]
+}
+
+export interface AIConversationSummary {
+  sessionId: string
+  provider: string
+  model: string
+  createdAt: string
+  updatedAt: string
+  turns: number
+  preview: string
+}
+
 export interface ScenarioNote {
   notes: string
   sens: string
@@ -113,3 +208,461 @@ export interface KovaaksLastScore {
   type: string
   attributes: KovaaksScoreAttributes
 }
+
+export interface LastScoresResult {
+  scores: KovaaksLastScore[]
+  freshness: Freshness
+}
+
+// Scenario leaderboards (global Kovaak's rankings)
+export interface LeaderboardEntry {
+  rank: number
+  steamId: string
+  username: string
+  score: number
+  country?: string
+}
+
+export interface LeaderboardPage {
+  scenario: string
+  leaderboardId: number
+  page: number
+  pageSize: number
+  total: number
+  friendsOnly: boolean
+  entries: LeaderboardEntry[]
+  freshness: Freshness
+}
+
+export interface ScenarioPercentile {
+  scenario: string
+  score: number
+  rank: number
+  total: number
+  percentile: number
+  freshness: Freshness
+}
+
+// Team roster and comparisons
+export interface Teammate {
+  steamId: string
+  name: string
+}
+
+export interface TeammateProgress {
+  teammate: Teammate
+  self: boolean
+  progress?: BenchmarkProgress
+  error?: string
+}
+
+// Gaps are "mine minus theirs": positive means ahead
+export interface ScenarioComparison {
+  name: string
+  myScore: number
+  theirScore: number
+  scoreGap: number
+  myRank: number
+  theirRank: number
+  rankGap: number
+  myEnergy?: number
+  theirEnergy?: number
+  energyGap?: number
+}
+
+export interface TeamComparison {
+  benchmarkId: number
+  teammate: Teammate
+  overallRankGap: number
+  progressGap: number
+  scenarios: ScenarioComparison[]
+  freshness: Freshness
+}
+
+export interface TeamImprovement {
+  teammate: Teammate
+  self: boolean
+  since: string
+  progressDelta: number
+  rankDelta: number
+  energyDelta: number
+  improvedScenarios: number
+}
+
+export interface TraceMigrationResult {
+  scanned: number
+  migrated: number
+  skipped: number
+  failed: number
+  bytesBefore: number
+  bytesAfter: number
+  errors?: string[]
+}
+
+export interface TraceRetentionPolicy {
+  maxTotalMB: number
+  maxAgeDays: number
+  keepOnlyPB: boolean
+  keepLastPerScenario: number
+}
+
+export interface TracePruneResult {
+  scanned: number
+  deleted: number
+  failed: number
+  bytesReclaimed: number
+  bytesRemaining: number
+  prunedAt: string
+  errors?: string[]
+}
+
+export interface TracesStorageStats {
+  dir: string
+  fileCount: number
+  totalBytes: number
+  scenarioCount: number
+  oldestPlayed?: string
+  newestPlayed?: string
+  policy: TraceRetentionPolicy
+  lastPrune?: TracePruneResult
+}
+
+export interface TraceIndexEntry {
+  name: string
+  statsFile: string
+  scenario: string
+  datePlayed?: string
+  score?: number
+  points: number
+  durationMs: number
+  version: number
+  size: number
+}
+
+export interface MotionProfile {
+  mean: number
+  median: number
+  p90: number
+  peak: number
+}
+
+export interface Flick {
+  onsetMs: number
+  durationMs: number
+  timeToPeakMs: number
+  peakVelocity: number
+  amplitude: number
+  directionDeg: number
+  overshoot: number
+  corrections: number
+  settleTimeMs: number
+  smoothness: number
+}
+
+export interface FlickSummary {
+  count: number
+  avgPeakVelocity: number
+  avgAmplitude: number
+  avgOvershoot: number
+  overshootRate: number
+  avgCorrections: number
+  avgSettleTimeMs: number
+}
+
+export interface ClickTiming {
+  clicks: number
+  whileMoving: number
+  afterStop: number
+  avgDelayAfterStopMs: number
+  medianDelayAfterStopMs: number
+}
+
+export interface DirectionalBias {
+  right: number
+  left: number
+  up: number
+  down: number
+  horizontal: number
+  vertical: number
+  overshootRight: number
+  overshootLeft: number
+  overshootUp: number
+  overshootDown: number
+}
+
+export interface TraceMetrics {
+  algorithmVersion: number
+  points: number
+  durationMs: number
+  pathLength: number
+  speed: MotionProfile
+  acceleration: MotionProfile
+  speedProfile?: number[]
+  profileBinMs: number
+  smoothness: number
+  jerkRms: number
+  flicks?: Flick[]
+  flickSummary: FlickSummary
+  clicks: ClickTiming
+  direction: DirectionalBias
+}
+
+export interface TraceMetricsHistoryEntry {
+  statsFile: string
+  scenario: string
+  datePlayed?: string
+  score?: number
+  metrics: TraceMetrics
+}
+
+export interface KillSegment {
+  kill: number
+  startMs: number
+  endMs: number
+  ttkSec: number
+  shots: number
+  hits: number
+  reactionMs: number
+  timeToFirstShotMs: number
+  pathLength: number
+  distance: number
+  pathEfficiency: number
+  overshoot: number
+  microCorrections: number
+  peakVelocity: number
+}
+
+export interface KillSegmentPhase {
+  phase: 'early' | 'middle' | 'late'
+  kills: number
+  avgReactionMs: number
+  avgTimeToFirstShotMs: number
+  avgMicroCorrections: number
+  avgPathEfficiency: number
+  avgOvershoot: number
+}
+
+export interface KillSegmentation {
+  segments: KillSegment[]
+  phases?: KillSegmentPhase[]
+  unaligned: number
+}
+
+export interface TraceExportOptions {
+  format: 'csv' | 'ndjson' | ''
+  fileNames?: string[]
+  scenario?: string
+  from?: string
+  to?: string
+  outputDir?: string
+}
+
+export interface TraceExportResult {
+  dir: string
+  exported: number
+  failed: number
+  files: string[]
+  errors?: string[]
+}
+
+export interface PathSuggestions {
+  steamInstallDirs: string[]
+  libraryDirs: string[]
+  kovaaksDirs: string[]
+  statsDirs: string[]
+}
+
+export interface SteamAccount {
+  steamId: string
+  accountName: string
+  personaName: string
+  mostRecent: boolean
+  lastLogin: number
+}
+
+export interface Profile {
+  id: string
+  steamId?: string
+  accountName?: string
+  personaName?: string
+  active: boolean
+  exists: boolean
+}
+
+export type PlanSource = 'ai' | 'user'
+
+export type PlanScheduleKind = '' | 'daily' | 'weekly'
+
+export interface PlanSchedule {
+  kind: PlanScheduleKind // weeks start on Monday
+  weekdays?: number[] // daily plans only, 0 = Sunday; empty = every day
+}
+
+export interface PlanItem {
+  scenario: string
+  runs: number // 0 = no run goal
+  targetMinutes?: number // playtime goal, 0 = none
+  focus?: string
+  targetScore?: number // 0 = no score goal
+  sensNote?: string
+}
+
+export interface PlanRun {
+  fileName: string
+  item: number // index into Plan.items
+  score: number
+  seconds: number
+  playedAt: string
+}
+
+// Training routine, optionally scheduled, tracked against the runs played while it is active
+export interface Plan {
+  id: string
+  name: string
+  description?: string
+  source: PlanSource
+  sensitivityNote?: string
+  sharecode?: string // Kovaak's playlist sharecode
+  items: PlanItem[]
+  schedule: PlanSchedule
+  createdAt: string
+  updatedAt: string
+  active: boolean
+  activatedAt?: string
+  runs?: PlanRun[]
+  cursor: number // item launching the plan starts from
+  cursorAt?: string
+}
+
+export interface PlanItemProgress {
+  done: number
+  minutes: number
+  bestScore: number
+  targetHit: boolean
+  complete: boolean
+}
+
+export interface PlanProgress {
+  planId: string
+  periodStart?: string
+  periodEnd?: string
+  scheduled: boolean // false on days a daily plan is not scheduled
+  items: PlanItemProgress[]
+  completed: number
+  percent: number // 0..100
+  next: number // first incomplete item from the cursor, -1 when complete
+}
+
+export interface PlanPeriod {
+  start: string
+  end: string
+  percent: number
+  complete: boolean
+}
+
+export interface PlanAdherence {
+  planId: string
+  periods: number
+  completed: number
+  percent: number // 0..100
+  currentStreak: number
+  bestStreak: number
+  history: PlanPeriod[] // most recent last
+}
+
+export interface PlanLaunch {
+  playlistPath?: string
+  scenario?: string
+}
+
+export type GoalKind = 'score' | 'benchmark' | 'playtime'
+
+// Goal set by the user; only the fields of its kind are used
+export interface Goal {
+  id: string
+  name: string
+  kind: GoalKind
+  scenario?: string // score goals; playtime goals on one scenario
+  targetScore?: number
+  benchmarkId?: number // benchmark goals: one difficulty of a benchmark
+  rank?: string
+  tag?: string // playtime goals on a skill tag (any scenario when scenario and tag are empty)
+  targetHours?: number
+  start: string
+  deadline?: string
+  createdAt: string
+  updatedAt: string
+  achievedAt?: string
+  offPace: boolean
+}
+
+export type GoalPace = 'achieved' | 'on-pace' | 'off-pace' | 'expired' | 'unknown'
+
+export interface GoalStatus {
+  goalId: string
+  current: number // best score, overall rank or hours played
+  target: number
+  percent: number // 0..100
+  achieved: boolean
+  achievedAt?: string
+  forecast?: string // expected date at the recent rate of improvement
+  pace: GoalPace
+  blocking?: string[] // benchmark scenarios still below the target rank
+  evaluatedAt: string
+}
+
+export interface ActivityStreak {
+  current: number // still counts when only the previous period was active
+  best: number
+}
+
+export interface DayActivity {
+  date: string // YYYY-MM-DD, local
+  runs: number
+  playtimeSec: number
+}
+
+export interface ScenarioVolume {
+  scenario: string
+  runs: number
+  playtimeSec: number
+  lastPlayed: string
+}
+
+export interface HeatmapCell {
+  slot: number // hour 0..23 or weekday 0..6 (0 = Sunday)
+  runs: number
+  playtimeSec: number
+  performance: number // mean score relative to each scenario's mean, 100 = average
+}
+
+export interface WeekConsistency {
+  week: string // Monday, YYYY-MM-DD
+  runs: number
+  mean: number
+  stdDev: number
+  cv: number
+}
+
+export interface ScenarioConsistency {
+  scenario: string
+  runs: number
+  meanCv: number // lower is more consistent
+  weeks: WeekConsistency[]
+}
+
+// Practice volume, streaks and consistency over the run history
+export interface ActivityStats {
+  first?: string
+  last?: string
+  runs: number
+  playtimeSec: number
+  activeDays: number
+  dailyStreak: ActivityStreak
+  weeklyStreak: ActivityStreak
+  days: DayActivity[] // oldest first
+  scenarios: ScenarioVolume[] // most played first
+  byHour: HeatmapCell[]
+  byWeekday: HeatmapCell[]
+  consistency: ScenarioConsistency[]
+}
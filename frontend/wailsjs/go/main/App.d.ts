@@ -2,46 +2,126 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 
+export function AddTeammate(arg1:string):Promise<models.Teammate>;
+
+export function AskSessionFollowUp(arg1:string,arg2:Array<models.ScenarioRecord>,arg3:string,arg4:models.AIOptions):Promise<string>;
+
 export function CancelSessionInsights(arg1:string):Promise<void>;
 
 export function CheckForUpdates():Promise<models.UpdateInfo>;
 
 export function ClearCache():Promise<void>;
 
+export function CompareWithTeammate(arg1:number,arg2:string):Promise<models.TeamComparison>;
+
+export function DeleteAIConversation(arg1:string):Promise<void>;
+
+export function DeleteGoal(arg1:string):Promise<void>;
+
+export function DeletePlan(arg1:string):Promise<void>;
+
 export function DownloadAndInstallUpdate(arg1:string):Promise<void>;
 
+export function EstimateSessionInsights(arg1:string,arg2:Array<models.ScenarioRecord>,arg3:string,arg4:models.AIOptions):Promise<models.AIPromptEstimate>;
+
+export function ExportTraces(arg1:models.TraceExportOptions):Promise<models.TraceExportResult>;
+
 export function GenerateSessionInsights(arg1:string,arg2:Array<models.ScenarioRecord>,arg3:string,arg4:models.AIOptions):Promise<string>;
 
+export function GenerateTrainingPlan(arg1:string,arg2:Array<models.ScenarioRecord>,arg3:string,arg4:models.AIOptions):Promise<models.Plan>;
+
+export function GenerateTrainingReview(arg1:string,arg2:string,arg3:string,arg4:models.AIOptions):Promise<models.AIRequest>;
+
+export function GetAIConversation(arg1:string):Promise<models.AIConversation>;
+
+export function GetActivityStats():Promise<models.ActivityStats>;
+
 export function GetAllBenchmarkProgresses():Promise<Record<number, models.BenchmarkProgress>>;
 
 export function GetBenchmarkProgress(arg1:number):Promise<models.BenchmarkProgress>;
 
 export function GetBenchmarks():Promise<Array<models.Benchmark>>;
 
+export function GetConnectivityStatus():Promise<models.ConnectivityStatus>;
+
 export function GetDefaultSettings():Promise<models.Settings>;
 
 export function GetFavoriteBenchmarks():Promise<Array<string>>;
 
-export function GetLastScenarioScores(arg1:string):Promise<Array<models.KovaaksLastScore>>;
+export function GetGoalStatuses():Promise<Array<models.GoalStatus>>;
+
+export function GetGoals():Promise<Array<models.Goal>>;
+
+export function GetKillSegments(arg1:string):Promise<models.KillSegmentation>;
+
+export function GetLastScenarioScores(arg1:string):Promise<models.LastScoresResult>;
+
+export function GetMostImproved(arg1:number,arg2:number):Promise<Array<models.TeamImprovement>>;
+
+export function GetOrphanTraces():Promise<Array<models.TraceIndexEntry>>;
+
+export function GetPlanAdherence(arg1:string):Promise<models.PlanAdherence>;
+
+export function GetPlanProgress(arg1:string):Promise<models.PlanProgress>;
+
+export function GetPlans():Promise<Array<models.Plan>>;
+
+export function GetProfiles():Promise<Array<models.Profile>>;
 
 export function GetRecentScenarios(arg1:number):Promise<Array<models.ScenarioRecord>>;
 
+export function GetScenarioLeaderboard(arg1:string,arg2:number,arg3:number,arg4:Array<string>):Promise<models.LeaderboardPage>;
+
+export function GetScenarioPercentile(arg1:string,arg2:number):Promise<models.ScenarioPercentile>;
+
+export function GetScenarioPercentiles(arg1:string):Promise<Record<string, number>>;
+
 export function GetScenarioTrace(arg1:string):Promise<string>;
 
 export function GetSettings():Promise<models.Settings>;
 
+export function GetSteamAccounts():Promise<Array<models.SteamAccount>>;
+
+export function GetTeamProgress(arg1:number):Promise<Array<models.TeammateProgress>>;
+
+export function GetTeammates():Promise<Array<models.Teammate>>;
+
+export function GetTraceMetrics(arg1:string):Promise<models.TraceMetrics>;
+
+export function GetTraceMetricsHistory(arg1:string,arg2:number):Promise<Array<models.TraceMetricsHistoryEntry>>;
+
+export function GetTracesStorageStats():Promise<models.TracesStorageStats>;
+
 export function GetVersion():Promise<string>;
 
 export function LaunchKovaaksPlaylist(arg1:string):Promise<void>;
 
 export function LaunchKovaaksScenario(arg1:string,arg2:string):Promise<void>;
 
+export function LaunchPlan(arg1:string):Promise<models.PlanLaunch>;
+
+export function ListAIConversations():Promise<Array<models.AIConversationSummary>>;
+
+export function ListTraces(arg1:string):Promise<Array<models.TraceIndexEntry>>;
+
+export function MigrateTraces():Promise<models.TraceMigrationResult>;
+
+export function PruneTraces():Promise<models.TracePruneResult>;
+
 export function QuitApp():Promise<void>;
 
+export function RebuildTraceIndex():Promise<number>;
+
 export function RefreshAllBenchmarkProgresses():Promise<Record<number, models.BenchmarkProgress>>;
 
+export function RemoveTeammate(arg1:string):Promise<void>;
+
 export function ResetSettings(arg1:boolean,arg2:boolean,arg3:boolean,arg4:boolean):Promise<void>;
 
+export function SaveGoal(arg1:models.Goal):Promise<models.Goal>;
+
+export function SavePlan(arg1:models.Plan):Promise<models.Plan>;
+
 export function SaveScenarioNote(arg1:string,arg2:string,arg3:string):Promise<void>;
 
 export function SaveSessionNote(arg1:string,arg2:string,arg3:string):Promise<void>;
@@ -50,10 +130,20 @@ export function SetAutostart(arg1:boolean):Promise<void>;
 
 export function SetFavoriteBenchmarks(arg1:Array<string>):Promise<void>;
 
+export function SetOfflineMode(arg1:boolean):Promise<void>;
+
+export function SetPlanActive(arg1:string,arg2:boolean):Promise<models.Plan>;
+
 export function ShowWindow():Promise<void>;
 
 export function StartWatcher(arg1:string):Promise<void>;
 
 export function StopWatcher():Promise<void>;
 
+export function SuggestPaths():Promise<models.PathSuggestions>;
+
+export function SwitchProfile(arg1:string):Promise<models.Profile>;
+
+export function SyncRemoteScores():Promise<number>;
+
 export function UpdateSettings(arg1:models.Settings):Promise<void>;
@@ -2,6 +2,14 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddTeammate(arg1) {
+  return window['go']['main']['App']['AddTeammate'](arg1);
+}
+
+export function AskSessionFollowUp(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AskSessionFollowUp'](arg1, arg2, arg3, arg4);
+}
+
 export function CancelSessionInsights(arg1) {
   return window['go']['main']['App']['CancelSessionInsights'](arg1);
 }
@@ -14,14 +22,54 @@ export function ClearCache() {
   return window['go']['main']['App']['ClearCache']();
 }
 
+export function CompareWithTeammate(arg1, arg2) {
+  return window['go']['main']['App']['CompareWithTeammate'](arg1, arg2);
+}
+
+export function DeleteAIConversation(arg1) {
+  return window['go']['main']['App']['DeleteAIConversation'](arg1);
+}
+
+export function DeleteGoal(arg1) {
+  return window['go']['main']['App']['DeleteGoal'](arg1);
+}
+
+export function DeletePlan(arg1) {
+  return window['go']['main']['App']['DeletePlan'](arg1);
+}
+
 export function DownloadAndInstallUpdate(arg1) {
   return window['go']['main']['App']['DownloadAndInstallUpdate'](arg1);
 }
 
+export function EstimateSessionInsights(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['EstimateSessionInsights'](arg1, arg2, arg3, arg4);
+}
+
+export function ExportTraces(arg1) {
+  return window['go']['main']['App']['ExportTraces'](arg1);
+}
+
 export function GenerateSessionInsights(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['GenerateSessionInsights'](arg1, arg2, arg3, arg4);
 }
 
+export function GenerateTrainingPlan(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GenerateTrainingPlan'](arg1, arg2, arg3, arg4);
+}
+
+export function GenerateTrainingReview(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GenerateTrainingReview'](arg1, arg2, arg3, arg4);
+}
+
+export function GetAIConversation(arg1) {
+  return window['go']['main']['App']['GetAIConversation'](arg1);
+}
+
+export function GetActivityStats() {
+  return window['go']['main']['App']['GetActivityStats']();
+}
+
 export function GetAllBenchmarkProgresses() {
   return window['go']['main']['App']['GetAllBenchmarkProgresses']();
 }
@@ -34,6 +82,10 @@ export function GetBenchmarks() {
   return window['go']['main']['App']['GetBenchmarks']();
 }
 
+export function GetConnectivityStatus() {
+  return window['go']['main']['App']['GetConnectivityStatus']();
+}
+
 export function GetDefaultSettings() {
   return window['go']['main']['App']['GetDefaultSettings']();
 }
@@ -42,14 +94,62 @@ export function GetFavoriteBenchmarks() {
   return window['go']['main']['App']['GetFavoriteBenchmarks']();
 }
 
+export function GetGoalStatuses() {
+  return window['go']['main']['App']['GetGoalStatuses']();
+}
+
+export function GetGoals() {
+  return window['go']['main']['App']['GetGoals']();
+}
+
+export function GetKillSegments(arg1) {
+  return window['go']['main']['App']['GetKillSegments'](arg1);
+}
+
 export function GetLastScenarioScores(arg1) {
   return window['go']['main']['App']['GetLastScenarioScores'](arg1);
 }
 
+export function GetMostImproved(arg1, arg2) {
+  return window['go']['main']['App']['GetMostImproved'](arg1, arg2);
+}
+
+export function GetOrphanTraces() {
+  return window['go']['main']['App']['GetOrphanTraces']();
+}
+
+export function GetPlanAdherence(arg1) {
+  return window['go']['main']['App']['GetPlanAdherence'](arg1);
+}
+
+export function GetPlanProgress(arg1) {
+  return window['go']['main']['App']['GetPlanProgress'](arg1);
+}
+
+export function GetPlans() {
+  return window['go']['main']['App']['GetPlans']();
+}
+
+export function GetProfiles() {
+  return window['go']['main']['App']['GetProfiles']();
+}
+
 export function GetRecentScenarios(arg1) {
   return window['go']['main']['App']['GetRecentScenarios'](arg1);
 }
 
+export function GetScenarioLeaderboard(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetScenarioLeaderboard'](arg1, arg2, arg3, arg4);
+}
+
+export function GetScenarioPercentile(arg1, arg2) {
+  return window['go']['main']['App']['GetScenarioPercentile'](arg1, arg2);
+}
+
+export function GetScenarioPercentiles(arg1) {
+  return window['go']['main']['App']['GetScenarioPercentiles'](arg1);
+}
+
 export function GetScenarioTrace(arg1) {
   return window['go']['main']['App']['GetScenarioTrace'](arg1);
 }
@@ -58,6 +158,30 @@ export function GetSettings() {
   return window['go']['main']['App']['GetSettings']();
 }
 
+export function GetSteamAccounts() {
+  return window['go']['main']['App']['GetSteamAccounts']();
+}
+
+export function GetTeamProgress(arg1) {
+  return window['go']['main']['App']['GetTeamProgress'](arg1);
+}
+
+export function GetTeammates() {
+  return window['go']['main']['App']['GetTeammates']();
+}
+
+export function GetTraceMetrics(arg1) {
+  return window['go']['main']['App']['GetTraceMetrics'](arg1);
+}
+
+export function GetTraceMetricsHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetTraceMetricsHistory'](arg1, arg2);
+}
+
+export function GetTracesStorageStats() {
+  return window['go']['main']['App']['GetTracesStorageStats']();
+}
+
 export function GetVersion() {
   return window['go']['main']['App']['GetVersion']();
 }
@@ -70,18 +194,54 @@ export function LaunchKovaaksScenario(arg1, arg2) {
   return window['go']['main']['App']['LaunchKovaaksScenario'](arg1, arg2);
 }
 
+export function LaunchPlan(arg1) {
+  return window['go']['main']['App']['LaunchPlan'](arg1);
+}
+
+export function ListAIConversations() {
+  return window['go']['main']['App']['ListAIConversations']();
+}
+
+export function ListTraces(arg1) {
+  return window['go']['main']['App']['ListTraces'](arg1);
+}
+
+export function MigrateTraces() {
+  return window['go']['main']['App']['MigrateTraces']();
+}
+
+export function PruneTraces() {
+  return window['go']['main']['App']['PruneTraces']();
+}
+
 export function QuitApp() {
   return window['go']['main']['App']['QuitApp']();
 }
 
+export function RebuildTraceIndex() {
+  return window['go']['main']['App']['RebuildTraceIndex']();
+}
+
 export function RefreshAllBenchmarkProgresses() {
   return window['go']['main']['App']['RefreshAllBenchmarkProgresses']();
 }
 
+export function RemoveTeammate(arg1) {
+  return window['go']['main']['App']['RemoveTeammate'](arg1);
+}
+
 export function ResetSettings(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['ResetSettings'](arg1, arg2, arg3, arg4);
 }
 
+export function SaveGoal(arg1) {
+  return window['go']['main']['App']['SaveGoal'](arg1);
+}
+
+export function SavePlan(arg1) {
+  return window['go']['main']['App']['SavePlan'](arg1);
+}
+
 export function SaveScenarioNote(arg1, arg2, arg3) {
   return window['go']['main']['App']['SaveScenarioNote'](arg1, arg2, arg3);
 }
@@ -98,6 +258,14 @@ export function SetFavoriteBenchmarks(arg1) {
   return window['go']['main']['App']['SetFavoriteBenchmarks'](arg1);
 }
 
+export function SetOfflineMode(arg1) {
+  return window['go']['main']['App']['SetOfflineMode'](arg1);
+}
+
+export function SetPlanActive(arg1, arg2) {
+  return window['go']['main']['App']['SetPlanActive'](arg1, arg2);
+}
+
 export function ShowWindow() {
   return window['go']['main']['App']['ShowWindow']();
 }
@@ -110,6 +278,18 @@ export function StopWatcher() {
   return window['go']['main']['App']['StopWatcher']();
 }
 
+export function SuggestPaths() {
+  return window['go']['main']['App']['SuggestPaths']();
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function SyncRemoteScores() {
+  return window['go']['main']['App']['SyncRemoteScores']();
+}
+
 export function UpdateSettings(arg1) {
   return window['go']['main']['App']['UpdateSettings'](arg1);
 }
@@ -3,6 +3,7 @@ export namespace models {
 	export class AIOptions {
 	    maxRunsPerScenario: number;
 	    systemPersona: string;
+	    regenerate?: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new AIOptions(source);
@@ -12,6 +13,7 @@ export namespace models {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.maxRunsPerScenario = source["maxRunsPerScenario"];
 	        this.systemPersona = source["systemPersona"];
+	        this.regenerate = source["regenerate"];
 	    }
 	}
 	export class BenchmarkSubcategory {
@@ -255,6 +257,7 @@ export namespace models {
 	    benchmarkProgress: number;
 	    ranks: RankDef[];
 	    categories: ProgressCategory[];
+	    freshness?: Freshness;
 	
 	    static createFrom(source: any = {}) {
 	        return new BenchmarkProgress(source);
@@ -266,6 +269,7 @@ export namespace models {
 	        this.benchmarkProgress = source["benchmarkProgress"];
 	        this.ranks = this.convertValues(source["ranks"], RankDef);
 	        this.categories = this.convertValues(source["categories"], ProgressCategory);
+	        this.freshness = this.convertValues(source["freshness"], Freshness);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -414,6 +418,7 @@ export namespace models {
 	    mouseTrace?: MousePoint[];
 	    traceData?: string;
 	    hasTrace: boolean;
+	    source?: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new ScenarioRecord(source);
@@ -428,6 +433,7 @@ export namespace models {
 	        this.mouseTrace = this.convertValues(source["mouseTrace"], MousePoint);
 	        this.traceData = source["traceData"];
 	        this.hasTrace = source["hasTrace"];
+	        this.source = source["source"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -468,15 +474,19 @@ export namespace models {
 	    personaNameOverride?: string;
 	    statsDir: string;
 	    tracesDir: string;
+	    traceRetention: TraceRetentionPolicy;
 	    sessionGapMinutes: number;
 	    theme: string;
 	    font?: string;
 	    favoriteBenchmarks?: string[];
+	    teammates?: Teammate[];
 	    mouseTrackingEnabled: boolean;
 	    mouseBufferMinutes: number;
 	    maxExistingOnStart: number;
 	    autostartEnabled: boolean;
+	    offlineMode: boolean;
 	    geminiApiKey?: string;
+	    ai: AISettings;
 	    scenarioNotes?: Record<string, ScenarioNote>;
 	    sessionNotes?: Record<string, SessionNote>;
 	
@@ -491,15 +501,19 @@ export namespace models {
 	        this.personaNameOverride = source["personaNameOverride"];
 	        this.statsDir = source["statsDir"];
 	        this.tracesDir = source["tracesDir"];
+	        this.traceRetention = this.convertValues(source["traceRetention"], TraceRetentionPolicy);
 	        this.sessionGapMinutes = source["sessionGapMinutes"];
 	        this.theme = source["theme"];
 	        this.font = source["font"];
 	        this.favoriteBenchmarks = source["favoriteBenchmarks"];
+	        this.teammates = this.convertValues(source["teammates"], Teammate);
 	        this.mouseTrackingEnabled = source["mouseTrackingEnabled"];
 	        this.mouseBufferMinutes = source["mouseBufferMinutes"];
 	        this.maxExistingOnStart = source["maxExistingOnStart"];
 	        this.autostartEnabled = source["autostartEnabled"];
+	        this.offlineMode = source["offlineMode"];
 	        this.geminiApiKey = source["geminiApiKey"];
+	        this.ai = this.convertValues(source["ai"], AISettings);
 	        this.scenarioNotes = this.convertValues(source["scenarioNotes"], ScenarioNote, true);
 	        this.sessionNotes = this.convertValues(source["sessionNotes"], SessionNote, true);
 	    }
@@ -543,5 +557,1753 @@ export namespace models {
 	    }
 	}
 
+	export class ConnectivityStatus {
+	    online: boolean;
+	    offlineMode: boolean;
+	    lastChange: any;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConnectivityStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.online = source["online"];
+	        this.offlineMode = source["offlineMode"];
+	        this.lastChange = this.convertValues(source["lastChange"], null);
+	        this.lastError = source["lastError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class Freshness {
+	    fetchedAt: any;
+	    source: string;
+	    stale: boolean;
+	    lastError?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Freshness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fetchedAt = this.convertValues(source["fetchedAt"], null);
+	        this.source = source["source"];
+	        this.stale = source["stale"];
+	        this.lastError = source["lastError"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class LastScoresResult {
+	    scores: KovaaksLastScore[];
+	    freshness: Freshness;
+	
+	    static createFrom(source: any = {}) {
+	        return new LastScoresResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scores = this.convertValues(source["scores"], KovaaksLastScore);
+	        this.freshness = this.convertValues(source["freshness"], Freshness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class LeaderboardEntry {
+	    rank: number;
+	    steamId: string;
+	    username: string;
+	    score: number;
+	    country?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LeaderboardEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.rank = source["rank"];
+	        this.steamId = source["steamId"];
+	        this.username = source["username"];
+	        this.score = source["score"];
+	        this.country = source["country"];
+	    }
+	}
+
+	export class LeaderboardPage {
+	    scenario: string;
+	    leaderboardId: number;
+	    page: number;
+	    pageSize: number;
+	    total: number;
+	    friendsOnly: boolean;
+	    entries: LeaderboardEntry[];
+	    freshness: Freshness;
+	
+	    static createFrom(source: any = {}) {
+	        return new LeaderboardPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.leaderboardId = source["leaderboardId"];
+	        this.page = source["page"];
+	        this.pageSize = source["pageSize"];
+	        this.total = source["total"];
+	        this.friendsOnly = source["friendsOnly"];
+	        this.entries = this.convertValues(source["entries"], LeaderboardEntry);
+	        this.freshness = this.convertValues(source["freshness"], Freshness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class ScenarioPercentile {
+	    scenario: string;
+	    score: number;
+	    rank: number;
+	    total: number;
+	    percentile: number;
+	    freshness: Freshness;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioPercentile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.score = source["score"];
+	        this.rank = source["rank"];
+	        this.total = source["total"];
+	        this.percentile = source["percentile"];
+	        this.freshness = this.convertValues(source["freshness"], Freshness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class ScenarioComparison {
+	    name: string;
+	    myScore: number;
+	    theirScore: number;
+	    scoreGap: number;
+	    myRank: number;
+	    theirRank: number;
+	    rankGap: number;
+	    myEnergy?: number;
+	    theirEnergy?: number;
+	    energyGap?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.myScore = source["myScore"];
+	        this.theirScore = source["theirScore"];
+	        this.scoreGap = source["scoreGap"];
+	        this.myRank = source["myRank"];
+	        this.theirRank = source["theirRank"];
+	        this.rankGap = source["rankGap"];
+	        this.myEnergy = source["myEnergy"];
+	        this.theirEnergy = source["theirEnergy"];
+	        this.energyGap = source["energyGap"];
+	    }
+	}
+
+	export class TeamComparison {
+	    benchmarkId: number;
+	    teammate: Teammate;
+	    overallRankGap: number;
+	    progressGap: number;
+	    scenarios: ScenarioComparison[];
+	    freshness: Freshness;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeamComparison(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.benchmarkId = source["benchmarkId"];
+	        this.teammate = this.convertValues(source["teammate"], Teammate);
+	        this.overallRankGap = source["overallRankGap"];
+	        this.progressGap = source["progressGap"];
+	        this.scenarios = this.convertValues(source["scenarios"], ScenarioComparison);
+	        this.freshness = this.convertValues(source["freshness"], Freshness);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class TeamImprovement {
+	    teammate: Teammate;
+	    self: boolean;
+	    since: any;
+	    progressDelta: number;
+	    rankDelta: number;
+	    energyDelta: number;
+	    improvedScenarios: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeamImprovement(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.teammate = this.convertValues(source["teammate"], Teammate);
+	        this.self = source["self"];
+	        this.since = this.convertValues(source["since"], null);
+	        this.progressDelta = source["progressDelta"];
+	        this.rankDelta = source["rankDelta"];
+	        this.energyDelta = source["energyDelta"];
+	        this.improvedScenarios = source["improvedScenarios"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class Teammate {
+	    steamId: string;
+	    name: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Teammate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steamId = source["steamId"];
+	        this.name = source["name"];
+	    }
+	}
+
+	export class TeammateProgress {
+	    teammate: Teammate;
+	    self: boolean;
+	    progress?: BenchmarkProgress;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TeammateProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.teammate = this.convertValues(source["teammate"], Teammate);
+	        this.self = source["self"];
+	        this.progress = this.convertValues(source["progress"], BenchmarkProgress);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class TraceMigrationResult {
+	    scanned: number;
+	    migrated: number;
+	    skipped: number;
+	    failed: number;
+	    bytesBefore: number;
+	    bytesAfter: number;
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceMigrationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scanned = source["scanned"];
+	        this.migrated = source["migrated"];
+	        this.skipped = source["skipped"];
+	        this.failed = source["failed"];
+	        this.bytesBefore = source["bytesBefore"];
+	        this.bytesAfter = source["bytesAfter"];
+	        this.errors = source["errors"];
+	    }
+	}
+
+	export class TracePruneResult {
+	    scanned: number;
+	    deleted: number;
+	    failed: number;
+	    bytesReclaimed: number;
+	    bytesRemaining: number;
+	    prunedAt: string;
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TracePruneResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scanned = source["scanned"];
+	        this.deleted = source["deleted"];
+	        this.failed = source["failed"];
+	        this.bytesReclaimed = source["bytesReclaimed"];
+	        this.bytesRemaining = source["bytesRemaining"];
+	        this.prunedAt = source["prunedAt"];
+	        this.errors = source["errors"];
+	    }
+	}
+
+	export class TraceRetentionPolicy {
+	    maxTotalMB: number;
+	    maxAgeDays: number;
+	    keepOnlyPB: boolean;
+	    keepLastPerScenario: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceRetentionPolicy(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.maxTotalMB = source["maxTotalMB"];
+	        this.maxAgeDays = source["maxAgeDays"];
+	        this.keepOnlyPB = source["keepOnlyPB"];
+	        this.keepLastPerScenario = source["keepLastPerScenario"];
+	    }
+	}
+
+	export class TracesStorageStats {
+	    dir: string;
+	    fileCount: number;
+	    totalBytes: number;
+	    scenarioCount: number;
+	    oldestPlayed?: string;
+	    newestPlayed?: string;
+	    policy: TraceRetentionPolicy;
+	    lastPrune?: TracePruneResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new TracesStorageStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dir = source["dir"];
+	        this.fileCount = source["fileCount"];
+	        this.totalBytes = source["totalBytes"];
+	        this.scenarioCount = source["scenarioCount"];
+	        this.oldestPlayed = source["oldestPlayed"];
+	        this.newestPlayed = source["newestPlayed"];
+	        this.policy = this.convertValues(source["policy"], TraceRetentionPolicy);
+	        this.lastPrune = this.convertValues(source["lastPrune"], TracePruneResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class TraceIndexEntry {
+	    name: string;
+	    statsFile: string;
+	    scenario: string;
+	    datePlayed?: string;
+	    score?: number;
+	    points: number;
+	    durationMs: number;
+	    version: number;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceIndexEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.statsFile = source["statsFile"];
+	        this.scenario = source["scenario"];
+	        this.datePlayed = source["datePlayed"];
+	        this.score = source["score"];
+	        this.points = source["points"];
+	        this.durationMs = source["durationMs"];
+	        this.version = source["version"];
+	        this.size = source["size"];
+	    }
+	}
+
+	export class ClickTiming {
+	    clicks: number;
+	    whileMoving: number;
+	    afterStop: number;
+	    avgDelayAfterStopMs: number;
+	    medianDelayAfterStopMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClickTiming(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.clicks = source["clicks"];
+	        this.whileMoving = source["whileMoving"];
+	        this.afterStop = source["afterStop"];
+	        this.avgDelayAfterStopMs = source["avgDelayAfterStopMs"];
+	        this.medianDelayAfterStopMs = source["medianDelayAfterStopMs"];
+	    }
+	}
+
+	export class DirectionalBias {
+	    right: number;
+	    left: number;
+	    up: number;
+	    down: number;
+	    horizontal: number;
+	    vertical: number;
+	    overshootRight: number;
+	    overshootLeft: number;
+	    overshootUp: number;
+	    overshootDown: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DirectionalBias(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.right = source["right"];
+	        this.left = source["left"];
+	        this.up = source["up"];
+	        this.down = source["down"];
+	        this.horizontal = source["horizontal"];
+	        this.vertical = source["vertical"];
+	        this.overshootRight = source["overshootRight"];
+	        this.overshootLeft = source["overshootLeft"];
+	        this.overshootUp = source["overshootUp"];
+	        this.overshootDown = source["overshootDown"];
+	    }
+	}
+
+	export class Flick {
+	    onsetMs: number;
+	    durationMs: number;
+	    timeToPeakMs: number;
+	    peakVelocity: number;
+	    amplitude: number;
+	    directionDeg: number;
+	    overshoot: number;
+	    corrections: number;
+	    settleTimeMs: number;
+	    smoothness: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Flick(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.onsetMs = source["onsetMs"];
+	        this.durationMs = source["durationMs"];
+	        this.timeToPeakMs = source["timeToPeakMs"];
+	        this.peakVelocity = source["peakVelocity"];
+	        this.amplitude = source["amplitude"];
+	        this.directionDeg = source["directionDeg"];
+	        this.overshoot = source["overshoot"];
+	        this.corrections = source["corrections"];
+	        this.settleTimeMs = source["settleTimeMs"];
+	        this.smoothness = source["smoothness"];
+	    }
+	}
+
+	export class FlickSummary {
+	    count: number;
+	    avgPeakVelocity: number;
+	    avgAmplitude: number;
+	    avgOvershoot: number;
+	    overshootRate: number;
+	    avgCorrections: number;
+	    avgSettleTimeMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FlickSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.avgPeakVelocity = source["avgPeakVelocity"];
+	        this.avgAmplitude = source["avgAmplitude"];
+	        this.avgOvershoot = source["avgOvershoot"];
+	        this.overshootRate = source["overshootRate"];
+	        this.avgCorrections = source["avgCorrections"];
+	        this.avgSettleTimeMs = source["avgSettleTimeMs"];
+	    }
+	}
+
+	export class MotionProfile {
+	    mean: number;
+	    median: number;
+	    p90: number;
+	    peak: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MotionProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mean = source["mean"];
+	        this.median = source["median"];
+	        this.p90 = source["p90"];
+	        this.peak = source["peak"];
+	    }
+	}
+
+	export class TraceMetrics {
+	    algorithmVersion: number;
+	    points: number;
+	    durationMs: number;
+	    pathLength: number;
+	    speed: MotionProfile;
+	    acceleration: MotionProfile;
+	    speedProfile?: number[];
+	    profileBinMs: number;
+	    smoothness: number;
+	    jerkRms: number;
+	    flicks?: Flick[];
+	    flickSummary: FlickSummary;
+	    clicks: ClickTiming;
+	    direction: DirectionalBias;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceMetrics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.algorithmVersion = source["algorithmVersion"];
+	        this.points = source["points"];
+	        this.durationMs = source["durationMs"];
+	        this.pathLength = source["pathLength"];
+	        this.speed = this.convertValues(source["speed"], MotionProfile);
+	        this.acceleration = this.convertValues(source["acceleration"], MotionProfile);
+	        this.speedProfile = source["speedProfile"];
+	        this.profileBinMs = source["profileBinMs"];
+	        this.smoothness = source["smoothness"];
+	        this.jerkRms = source["jerkRms"];
+	        this.flicks = this.convertValues(source["flicks"], Flick);
+	        this.flickSummary = this.convertValues(source["flickSummary"], FlickSummary);
+	        this.clicks = this.convertValues(source["clicks"], ClickTiming);
+	        this.direction = this.convertValues(source["direction"], DirectionalBias);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class TraceMetricsHistoryEntry {
+	    statsFile: string;
+	    scenario: string;
+	    datePlayed?: string;
+	    score?: number;
+	    metrics: TraceMetrics;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceMetricsHistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.statsFile = source["statsFile"];
+	        this.scenario = source["scenario"];
+	        this.datePlayed = source["datePlayed"];
+	        this.score = source["score"];
+	        this.metrics = this.convertValues(source["metrics"], TraceMetrics);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class KillSegment {
+	    kill: number;
+	    startMs: number;
+	    endMs: number;
+	    ttkSec: number;
+	    shots: number;
+	    hits: number;
+	    reactionMs: number;
+	    timeToFirstShotMs: number;
+	    pathLength: number;
+	    distance: number;
+	    pathEfficiency: number;
+	    overshoot: number;
+	    microCorrections: number;
+	    peakVelocity: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KillSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kill = source["kill"];
+	        this.startMs = source["startMs"];
+	        this.endMs = source["endMs"];
+	        this.ttkSec = source["ttkSec"];
+	        this.shots = source["shots"];
+	        this.hits = source["hits"];
+	        this.reactionMs = source["reactionMs"];
+	        this.timeToFirstShotMs = source["timeToFirstShotMs"];
+	        this.pathLength = source["pathLength"];
+	        this.distance = source["distance"];
+	        this.pathEfficiency = source["pathEfficiency"];
+	        this.overshoot = source["overshoot"];
+	        this.microCorrections = source["microCorrections"];
+	        this.peakVelocity = source["peakVelocity"];
+	    }
+	}
+
+	export class KillSegmentPhase {
+	    phase: string;
+	    kills: number;
+	    avgReactionMs: number;
+	    avgTimeToFirstShotMs: number;
+	    avgMicroCorrections: number;
+	    avgPathEfficiency: number;
+	    avgOvershoot: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KillSegmentPhase(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.kills = source["kills"];
+	        this.avgReactionMs = source["avgReactionMs"];
+	        this.avgTimeToFirstShotMs = source["avgTimeToFirstShotMs"];
+	        this.avgMicroCorrections = source["avgMicroCorrections"];
+	        this.avgPathEfficiency = source["avgPathEfficiency"];
+	        this.avgOvershoot = source["avgOvershoot"];
+	    }
+	}
+
+	export class KillSegmentation {
+	    segments: KillSegment[];
+	    phases?: KillSegmentPhase[];
+	    unaligned: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new KillSegmentation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.segments = this.convertValues(source["segments"], KillSegment);
+	        this.phases = this.convertValues(source["phases"], KillSegmentPhase);
+	        this.unaligned = source["unaligned"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class TraceExportOptions {
+	    format: string;
+	    fileNames?: string[];
+	    scenario?: string;
+	    from?: string;
+	    to?: string;
+	    outputDir?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.fileNames = source["fileNames"];
+	        this.scenario = source["scenario"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.outputDir = source["outputDir"];
+	    }
+	}
+
+	export class TraceExportResult {
+	    dir: string;
+	    exported: number;
+	    failed: number;
+	    files: string[];
+	    errors?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TraceExportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.dir = source["dir"];
+	        this.exported = source["exported"];
+	        this.failed = source["failed"];
+	        this.files = source["files"];
+	        this.errors = source["errors"];
+	    }
+	}
+
+	export class PathSuggestions {
+	    steamInstallDirs: string[];
+	    libraryDirs: string[];
+	    kovaaksDirs: string[];
+	    statsDirs: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PathSuggestions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steamInstallDirs = source["steamInstallDirs"];
+	        this.libraryDirs = source["libraryDirs"];
+	        this.kovaaksDirs = source["kovaaksDirs"];
+	        this.statsDirs = source["statsDirs"];
+	    }
+	}
+
+	export class Profile {
+	    id: string;
+	    steamId?: string;
+	    accountName?: string;
+	    personaName?: string;
+	    active: boolean;
+	    exists: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.steamId = source["steamId"];
+	        this.accountName = source["accountName"];
+	        this.personaName = source["personaName"];
+	        this.active = source["active"];
+	        this.exists = source["exists"];
+	    }
+	}
+
+	export class SteamAccount {
+	    steamId: string;
+	    accountName: string;
+	    personaName: string;
+	    mostRecent: boolean;
+	    lastLogin: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SteamAccount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.steamId = source["steamId"];
+	        this.accountName = source["accountName"];
+	        this.personaName = source["personaName"];
+	        this.mostRecent = source["mostRecent"];
+	        this.lastLogin = source["lastLogin"];
+	    }
+	}
+
+	export class AISettings {
+	    provider: string;
+	    model?: string;
+	    baseUrl?: string;
+	    apiKey?: string;
+	    promptTokenBudget?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AISettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.provider = source["provider"];
+	        this.model = source["model"];
+	        this.baseUrl = source["baseUrl"];
+	        this.apiKey = source["apiKey"];
+	        this.promptTokenBudget = source["promptTokenBudget"];
+	    }
+	}
+
+	export class AIConversation {
+	    sessionId: string;
+	    options: AIOptions;
+	    provider: string;
+	    model: string;
+	    createdAt: string;
+	    updatedAt: string;
+	    turns: AITurn[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AIConversation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.options = this.convertValues(source["options"], AIOptions);
+	        this.provider = source["provider"];
+	        this.model = source["model"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.turns = this.convertValues(source["turns"], AITurn);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class AIConversationSummary {
+	    sessionId: string;
+	    provider: string;
+	    model: string;
+	    createdAt: string;
+	    updatedAt: string;
+	    turns: number;
+	    preview: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AIConversationSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sessionId = source["sessionId"];
+	        this.provider = source["provider"];
+	        this.model = source["model"];
+	        this.createdAt = source["createdAt"];
+	        this.updatedAt = source["updatedAt"];
+	        this.turns = source["turns"];
+	        this.preview = source["preview"];
+	    }
+	}
+
+	export class AITurn {
+	    role: string;
+	    content: string;
+	    at: string;
+	    usage?: AIUsage;
+	
+	    static createFrom(source: any = {}) {
+	        return new AITurn(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.role = source["role"];
+	        this.content = source["content"];
+	        this.at = source["at"];
+	        this.usage = this.convertValues(source["usage"], AIUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class AIUsage {
+	    promptTokens: number;
+	    completionTokens: number;
+	    totalTokens: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AIUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.promptTokens = source["promptTokens"];
+	        this.completionTokens = source["completionTokens"];
+	        this.totalTokens = source["totalTokens"];
+	    }
+	}
+
+	export class AIRequest {
+	    requestId: string;
+	    sessionId: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AIRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.requestId = source["requestId"];
+	        this.sessionId = source["sessionId"];
+	    }
+	}
+
+	export class AIPromptEstimate {
+	    tokens: number;
+	    systemTokens: number;
+	    dataTokens: number;
+	    historyTokens?: number;
+	    budget: number;
+	    runsPerScenario: number;
+	    scenarios: number;
+	    scenariosOmitted: number;
+	    libraryEntries: number;
+	    summarised: boolean;
+	    overBudget: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AIPromptEstimate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tokens = source["tokens"];
+	        this.systemTokens = source["systemTokens"];
+	        this.dataTokens = source["dataTokens"];
+	        this.historyTokens = source["historyTokens"];
+	        this.budget = source["budget"];
+	        this.runsPerScenario = source["runsPerScenario"];
+	        this.scenarios = source["scenarios"];
+	        this.scenariosOmitted = source["scenariosOmitted"];
+	        this.libraryEntries = source["libraryEntries"];
+	        this.summarised = source["summarised"];
+	        this.overBudget = source["overBudget"];
+	    }
+	}
+
+	export class Plan {
+	    id: string;
+	    name: string;
+	    description?: string;
+	    source: string;
+	    sensitivityNote?: string;
+	    sharecode?: string;
+	    items: PlanItem[];
+	    schedule: PlanSchedule;
+	    createdAt: any;
+	    updatedAt: any;
+	    active: boolean;
+	    activatedAt?: any;
+	    runs?: PlanRun[];
+	    cursor: number;
+	    cursorAt?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new Plan(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.source = source["source"];
+	        this.sensitivityNote = source["sensitivityNote"];
+	        this.sharecode = source["sharecode"];
+	        this.items = this.convertValues(source["items"], PlanItem);
+	        this.schedule = this.convertValues(source["schedule"], PlanSchedule);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.active = source["active"];
+	        this.activatedAt = this.convertValues(source["activatedAt"], null);
+	        this.runs = this.convertValues(source["runs"], PlanRun);
+	        this.cursor = source["cursor"];
+	        this.cursorAt = this.convertValues(source["cursorAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class PlanItem {
+	    scenario: string;
+	    runs: number;
+	    targetMinutes?: number;
+	    focus?: string;
+	    targetScore?: number;
+	    sensNote?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runs = source["runs"];
+	        this.targetMinutes = source["targetMinutes"];
+	        this.focus = source["focus"];
+	        this.targetScore = source["targetScore"];
+	        this.sensNote = source["sensNote"];
+	    }
+	}
+
+	export class PlanItemProgress {
+	    done: number;
+	    minutes: number;
+	    bestScore: number;
+	    targetHit: boolean;
+	    complete: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanItemProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.done = source["done"];
+	        this.minutes = source["minutes"];
+	        this.bestScore = source["bestScore"];
+	        this.targetHit = source["targetHit"];
+	        this.complete = source["complete"];
+	    }
+	}
+
+	export class PlanLaunch {
+	    playlistPath?: string;
+	    scenario?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanLaunch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.playlistPath = source["playlistPath"];
+	        this.scenario = source["scenario"];
+	    }
+	}
+
+	export class PlanProgress {
+	    planId: string;
+	    periodStart?: any;
+	    periodEnd?: any;
+	    scheduled: boolean;
+	    items: PlanItemProgress[];
+	    completed: number;
+	    percent: number;
+	    next: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanProgress(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.planId = source["planId"];
+	        this.periodStart = this.convertValues(source["periodStart"], null);
+	        this.periodEnd = this.convertValues(source["periodEnd"], null);
+	        this.scheduled = source["scheduled"];
+	        this.items = this.convertValues(source["items"], PlanItemProgress);
+	        this.completed = source["completed"];
+	        this.percent = source["percent"];
+	        this.next = source["next"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class PlanRun {
+	    fileName: string;
+	    item: number;
+	    score: number;
+	    seconds: number;
+	    playedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanRun(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.fileName = source["fileName"];
+	        this.item = source["item"];
+	        this.score = source["score"];
+	        this.seconds = source["seconds"];
+	        this.playedAt = this.convertValues(source["playedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class PlanAdherence {
+	    planId: string;
+	    periods: number;
+	    completed: number;
+	    percent: number;
+	    currentStreak: number;
+	    bestStreak: number;
+	    history: PlanPeriod[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanAdherence(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.planId = source["planId"];
+	        this.periods = source["periods"];
+	        this.completed = source["completed"];
+	        this.percent = source["percent"];
+	        this.currentStreak = source["currentStreak"];
+	        this.bestStreak = source["bestStreak"];
+	        this.history = this.convertValues(source["history"], PlanPeriod);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class PlanPeriod {
+	    start: any;
+	    end: any;
+	    percent: number;
+	    complete: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanPeriod(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = this.convertValues(source["start"], null);
+	        this.end = this.convertValues(source["end"], null);
+	        this.percent = source["percent"];
+	        this.complete = source["complete"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class PlanSchedule {
+	    kind: string;
+	    weekdays?: number[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PlanSchedule(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.weekdays = source["weekdays"];
+	    }
+	}
+
+	export class Goal {
+	    id: string;
+	    name: string;
+	    kind: string;
+	    scenario?: string;
+	    targetScore?: number;
+	    benchmarkId?: number;
+	    rank?: string;
+	    tag?: string;
+	    targetHours?: number;
+	    start: any;
+	    deadline?: any;
+	    createdAt: any;
+	    updatedAt: any;
+	    achievedAt?: any;
+	    offPace: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Goal(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.kind = source["kind"];
+	        this.scenario = source["scenario"];
+	        this.targetScore = source["targetScore"];
+	        this.benchmarkId = source["benchmarkId"];
+	        this.rank = source["rank"];
+	        this.tag = source["tag"];
+	        this.targetHours = source["targetHours"];
+	        this.start = this.convertValues(source["start"], null);
+	        this.deadline = this.convertValues(source["deadline"], null);
+	        this.createdAt = this.convertValues(source["createdAt"], null);
+	        this.updatedAt = this.convertValues(source["updatedAt"], null);
+	        this.achievedAt = this.convertValues(source["achievedAt"], null);
+	        this.offPace = source["offPace"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class GoalStatus {
+	    goalId: string;
+	    current: number;
+	    target: number;
+	    percent: number;
+	    achieved: boolean;
+	    achievedAt?: any;
+	    forecast?: any;
+	    pace: string;
+	    blocking?: string[];
+	    evaluatedAt: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new GoalStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.goalId = source["goalId"];
+	        this.current = source["current"];
+	        this.target = source["target"];
+	        this.percent = source["percent"];
+	        this.achieved = source["achieved"];
+	        this.achievedAt = this.convertValues(source["achievedAt"], null);
+	        this.forecast = this.convertValues(source["forecast"], null);
+	        this.pace = source["pace"];
+	        this.blocking = source["blocking"];
+	        this.evaluatedAt = this.convertValues(source["evaluatedAt"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class ActivityStats {
+	    first?: any;
+	    last?: any;
+	    runs: number;
+	    playtimeSec: number;
+	    activeDays: number;
+	    dailyStreak: ActivityStreak;
+	    weeklyStreak: ActivityStreak;
+	    days: DayActivity[];
+	    scenarios: ScenarioVolume[];
+	    byHour: HeatmapCell[];
+	    byWeekday: HeatmapCell[];
+	    consistency: ScenarioConsistency[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.first = this.convertValues(source["first"], null);
+	        this.last = this.convertValues(source["last"], null);
+	        this.runs = source["runs"];
+	        this.playtimeSec = source["playtimeSec"];
+	        this.activeDays = source["activeDays"];
+	        this.dailyStreak = this.convertValues(source["dailyStreak"], ActivityStreak);
+	        this.weeklyStreak = this.convertValues(source["weeklyStreak"], ActivityStreak);
+	        this.days = this.convertValues(source["days"], DayActivity);
+	        this.scenarios = this.convertValues(source["scenarios"], ScenarioVolume);
+	        this.byHour = this.convertValues(source["byHour"], HeatmapCell);
+	        this.byWeekday = this.convertValues(source["byWeekday"], HeatmapCell);
+	        this.consistency = this.convertValues(source["consistency"], ScenarioConsistency);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class ActivityStreak {
+	    current: number;
+	    best: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ActivityStreak(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.current = source["current"];
+	        this.best = source["best"];
+	    }
+	}
+
+	export class DayActivity {
+	    date: string;
+	    runs: number;
+	    playtimeSec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayActivity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.runs = source["runs"];
+	        this.playtimeSec = source["playtimeSec"];
+	    }
+	}
+
+	export class HeatmapCell {
+	    slot: number;
+	    runs: number;
+	    playtimeSec: number;
+	    performance: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HeatmapCell(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.slot = source["slot"];
+	        this.runs = source["runs"];
+	        this.playtimeSec = source["playtimeSec"];
+	        this.performance = source["performance"];
+	    }
+	}
+
+	export class ScenarioConsistency {
+	    scenario: string;
+	    runs: number;
+	    meanCv: number;
+	    weeks: WeekConsistency[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioConsistency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runs = source["runs"];
+	        this.meanCv = source["meanCv"];
+	        this.weeks = this.convertValues(source["weeks"], WeekConsistency);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class ScenarioVolume {
+	    scenario: string;
+	    runs: number;
+	    playtimeSec: number;
+	    lastPlayed: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScenarioVolume(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scenario = source["scenario"];
+	        this.runs = source["runs"];
+	        this.playtimeSec = source["playtimeSec"];
+	        this.lastPlayed = this.convertValues(source["lastPlayed"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class WeekConsistency {
+	    week: string;
+	    runs: number;
+	    mean: number;
+	    stdDev: number;
+	    cv: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekConsistency(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.week = source["week"];
+	        this.runs = source["runs"];
+	        this.mean = source["mean"];
+	        this.stdDev = source["stdDev"];
+	        this.cv = source["cv"];
+	    }
+	}
+
 }
 
@@ -7,9 +7,11 @@ toolchain go1.24.5
 require (
 	github.com/google/generative-ai-go v0.20.1
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 	github.com/wailsapp/wails/v2 v2.10.2
 	golang.org/x/sys v0.37.0
 	golang.org/x/text v0.30.0
+	golang.org/x/time v0.14.0
 	google.golang.org/api v0.256.0
 )
 
@@ -58,7 +60,6 @@ require (
 	golang.org/x/net v0.46.0 // indirect
 	golang.org/x/oauth2 v0.33.0 // indirect
 	golang.org/x/sync v0.18.0 // indirect
-	golang.org/x/time v0.14.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250804133106-a7a43d27e69b // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251103181224-f26f9409b101 // indirect
 	google.golang.org/grpc v1.76.0 // indirect
@@ -0,0 +1,122 @@
+package ai
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/scenarios"
+)
+
+// EstimateTokens roughly estimates the token count of s. Common tokenizers average about four
+// characters per token on English and JSON, which is close enough to budget a prompt.
+func EstimateTokens(s string) int {
+	return (utf8.RuneCountInString(s) + 3) / 4
+}
+
+// payloadDetails returns the levels of detail tried by fitPayload, most detailed first:
+// per-run rows are halved down to none, then library entries and verbose metadata are dropped.
+func payloadDetails(maxRuns, scenarioCount int) []payloadDetail {
+	var out []payloadDetail
+	for runs := maxRuns; ; runs /= 2 {
+		out = append(out, payloadDetail{runs: runs, library: true, verboseMeta: true, scenarios: scenarioCount})
+		if runs == 0 {
+			break
+		}
+	}
+	out = append(out,
+		payloadDetail{runs: 0, library: false, verboseMeta: true, scenarios: scenarioCount},
+		payloadDetail{runs: 0, library: false, verboseMeta: false, scenarios: scenarioCount},
+	)
+	return out
+}
+
+// fitPayload renders the most detailed payload whose estimate, with the system prompt, fits budget.
+// When even aggregates-only doesn't fit, the least played scenarios are dropped (keeping at least one).
+func fitPayload(d sessionDigest, lead, userPrompt string, budget, systemTokens int) (string, models.AIPromptEstimate) {
+	est := models.AIPromptEstimate{Budget: budget, Scenarios: len(d.scenarios)}
+	var user string
+	var lv payloadDetail
+	var library int
+	fits := func() bool {
+		user, library = renderPayload(d, lead, userPrompt, lv)
+		return systemTokens+EstimateTokens(user) <= budget
+	}
+	levels := payloadDetails(d.maxRuns, len(d.scenarios))
+	ok := false
+	for i := range levels {
+		lv = levels[i]
+		if ok = fits(); ok {
+			break
+		}
+	}
+	for !ok && lv.scenarios > 1 {
+		lv.scenarios = (lv.scenarios + 1) / 2
+		ok = fits()
+	}
+	est.DataTokens = EstimateTokens(user)
+	est.Tokens = systemTokens + est.DataTokens
+	est.RunsPerScenario = lv.runs
+	est.ScenariosOmitted = len(d.scenarios) - minInt(lv.scenarios, len(d.scenarios))
+	est.LibraryEntries = library
+	est.Summarised = lv != levels[0]
+	est.OverBudget = !ok
+	return user, est
+}
+
+// relatedLibrary returns compact metadata of unplayed library scenarios that share tags with the
+// played ones, most shared tags first, capped at AIPromptLibraryMaxEntries.
+func relatedLibrary(d sessionDigest) map[string]scenarios.ScenarioMeta {
+	played := map[string]struct{}{}
+	tags := map[string]struct{}{}
+	for _, sd := range d.scenarios {
+		played[sd.name] = struct{}{}
+		if sd.meta != nil {
+			for _, t := range sd.meta.Tags {
+				tags[t] = struct{}{}
+			}
+		}
+	}
+	type candidate struct {
+		name   string
+		shared int
+		meta   scenarios.ScenarioMeta
+	}
+	var cands []candidate
+	for name, m := range scenarios.All() {
+		if _, ok := played[name]; ok {
+			continue
+		}
+		shared := 0
+		for _, t := range m.Tags {
+			if _, ok := tags[t]; ok {
+				shared++
+			}
+		}
+		if shared > 0 {
+			cands = append(cands, candidate{name: name, shared: shared, meta: m})
+		}
+	}
+	sort.Slice(cands, func(i, j int) bool {
+		if cands[i].shared != cands[j].shared {
+			return cands[i].shared > cands[j].shared
+		}
+		return cands[i].name < cands[j].name
+	})
+	if len(cands) > constants.AIPromptLibraryMaxEntries {
+		cands = cands[:constants.AIPromptLibraryMaxEntries]
+	}
+	out := make(map[string]scenarios.ScenarioMeta, len(cands))
+	for _, c := range cands {
+		out[c.name] = scenarios.ScenarioMeta{Description: c.meta.Description, Tags: c.meta.Tags, Difficulty: c.meta.Difficulty}
+	}
+	return out
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
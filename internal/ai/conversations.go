@@ -0,0 +1,210 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// ErrConversationNotFound is returned when a session has no stored conversation.
+var ErrConversationNotFound = errors.New("conversation not found")
+
+// conversationStore persists conversations as JSON files in the active profile's data directory
+// ($HOME/.refleks/conversations/<hash of session ID>.json).
+type conversationStore struct {
+	mu sync.Mutex
+}
+
+// conversationsDir returns the conversations directory of the active profile.
+func conversationsDir() (string, error) {
+	dir, err := appsettings.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, constants.AIConversationsSubdirName), nil
+}
+
+// conversationFileName maps a session ID (any string) to a safe file name.
+func conversationFileName(sessionID string) string {
+	h := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(h[:8]) + ".json"
+}
+
+// Get loads the conversation of a session.
+func (c *conversationStore) Get(sessionID string) (models.AIConversation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.getLocked(sessionID)
+}
+
+func (c *conversationStore) getLocked(sessionID string) (models.AIConversation, error) {
+	var conv models.AIConversation
+	dir, err := conversationsDir()
+	if err != nil {
+		return conv, err
+	}
+	b, err := os.ReadFile(filepath.Join(dir, conversationFileName(sessionID)))
+	if errors.Is(err, os.ErrNotExist) {
+		return conv, ErrConversationNotFound
+	}
+	if err != nil {
+		return conv, err
+	}
+	if err := json.Unmarshal(b, &conv); err != nil {
+		return conv, err
+	}
+	return conv, nil
+}
+
+// Save writes a conversation atomically.
+func (c *conversationStore) Save(conv models.AIConversation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir, err := conversationsDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(conv, "", "  ")
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(filepath.Join(dir, conversationFileName(conv.SessionID)), b, 0o644)
+}
+
+// Delete removes the conversation of a session. Deleting a missing conversation is not an error.
+func (c *conversationStore) Delete(sessionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir, err := conversationsDir()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, conversationFileName(sessionID))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List returns summaries of all stored conversations, most recently updated first.
+// Unreadable files are skipped.
+func (c *conversationStore) List() ([]models.AIConversationSummary, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir, err := conversationsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []models.AIConversationSummary{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.AIConversationSummary, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var conv models.AIConversation
+		if json.Unmarshal(b, &conv) != nil || conv.SessionID == "" {
+			continue
+		}
+		out = append(out, summarizeConversation(conv))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].UpdatedAt > out[j].UpdatedAt })
+	return out, nil
+}
+
+// summarizeConversation builds the listing entry of a conversation.
+func summarizeConversation(conv models.AIConversation) models.AIConversationSummary {
+	sum := models.AIConversationSummary{
+		SessionID: conv.SessionID,
+		Provider:  conv.Provider,
+		Model:     conv.Model,
+		CreatedAt: conv.CreatedAt,
+		UpdatedAt: conv.UpdatedAt,
+		Turns:     len(conv.Turns),
+	}
+	for i := len(conv.Turns) - 1; i >= 0; i-- {
+		if conv.Turns[i].Role == RoleAssistant {
+			sum.Preview = truncateRunes(strings.TrimSpace(conv.Turns[i].Content), constants.AIConversationPreviewChars)
+			break
+		}
+	}
+	return sum
+}
+
+// truncateRunes shortens s to at most n runes, appending an ellipsis when cut.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return strings.TrimSpace(string(r[:n])) + "…"
+}
+
+// historyReserve is the part of a follow-up's prompt budget kept free of the session payload for
+// the question and earlier turns.
+func historyReserve(budget int, question string) int {
+	return minInt(budget*constants.AIHistoryBudgetPct/100, constants.AIHistoryTokenBudget) + EstimateTokens(question)
+}
+
+// conversationRequest builds the request of a follow-up: the session payload as the first user turn,
+// the stored answers and questions that fit in historyBudget tokens (newest kept, at most
+// AIHistoryTokenBudget), then the question.
+func conversationRequest(system, payload string, turns []models.AITurn, question string, historyBudget int) Request {
+	// Skip the first user turn: its prompt is part of the payload
+	history := turns
+	if len(history) > 0 && history[0].Role == RoleUser {
+		history = history[1:]
+	}
+	start := len(history)
+	budget := minInt(historyBudget, constants.AIHistoryTokenBudget)
+	for start > 0 {
+		cost := EstimateTokens(history[start-1].Content)
+		if cost > budget {
+			break
+		}
+		budget -= cost
+		start--
+	}
+	// Keep user/assistant alternation: the kept history must start with an assistant turn
+	for start < len(history) && history[start].Role != RoleAssistant {
+		start++
+	}
+
+	first := payload
+	if start > 0 {
+		first += "\n\n(Earlier parts of this conversation were omitted.)"
+	}
+	if start == len(history) {
+		// Nothing to replay: ask the question in the same turn as the payload
+		return singleTurn(system, first+"\n\nFollow-up question: "+question)
+	}
+	req := Request{System: system, Messages: []Message{{Role: RoleUser, Content: first}}}
+	for _, t := range history[start:] {
+		req.Messages = append(req.Messages, Message{Role: t.Role, Content: t.Content})
+	}
+	req.Messages = append(req.Messages, Message{Role: RoleUser, Content: question})
+	return req
+}
@@ -10,6 +10,7 @@ import (
 	"google.golang.org/api/option"
 
 	"refleks/internal/constants"
+	"refleks/internal/models"
 )
 
 // GeminiClient wraps the Google Generative AI client and model configuration.
@@ -33,6 +34,12 @@ func NewGeminiClient(ctx context.Context, apiKey string, model string) (*GeminiC
 	return &GeminiClient{client: c, model: model}, nil
 }
 
+// Name returns constants.AIProviderGemini.
+func (g *GeminiClient) Name() string { return constants.AIProviderGemini }
+
+// Model returns the Gemini model name.
+func (g *GeminiClient) Model() string { return g.model }
+
 // Close releases underlying resources.
 func (g *GeminiClient) Close() error {
 	if g == nil || g.client == nil {
@@ -41,34 +48,58 @@ func (g *GeminiClient) Close() error {
 	return g.client.Close()
 }
 
-// StreamSessionInsights runs a streaming generation and calls onDelta for each text chunk.
-func (g *GeminiClient) StreamSessionInsights(ctx context.Context, system string, user string, onDelta func(string)) error {
+// Stream runs a streaming generation and calls onDelta for each text chunk.
+// Earlier turns of req are sent as chat history.
+func (g *GeminiClient) Stream(ctx context.Context, req Request, onDelta func(string)) (models.AIUsage, error) {
+	var usage models.AIUsage
 	if g == nil || g.client == nil {
-		return fmt.Errorf("client not initialized")
+		return usage, fmt.Errorf("client not initialized")
+	}
+	if len(req.Messages) == 0 {
+		return usage, errors.New("empty request")
 	}
 	model := g.client.GenerativeModel(g.model)
 	// Provide system instruction & conservative, analysis‑oriented generation config
-	model.SystemInstruction = &genai.Content{Parts: []genai.Part{genai.Text(system)}}
+	model.SystemInstruction = &genai.Content{Parts: []genai.Part{genai.Text(req.System)}}
 	model.GenerationConfig = genai.GenerationConfig{
-		Temperature:     ptr[float32](0.2),
+		Temperature:     ptr[float32](constants.AITemperature),
 		TopK:            ptr[int32](32),
-		TopP:            ptr[float32](0.95),
-		MaxOutputTokens: ptr[int32](2048), // allow slightly longer structured analyses
+		TopP:            ptr[float32](constants.AITopP),
+		MaxOutputTokens: ptr[int32](constants.AIMaxOutputTokens), // allow slightly longer structured analyses
+	}
+	chat := model.StartChat()
+	for _, m := range req.Messages[:len(req.Messages)-1] {
+		role := "user"
+		if m.Role == RoleAssistant {
+			role = "model"
+		}
+		chat.History = append(chat.History, &genai.Content{Role: role, Parts: []genai.Part{genai.Text(m.Content)}})
 	}
-	// Stream the response given the user payload
-	iter := model.GenerateContentStream(ctx, genai.Text(user))
+	// Stream the response to the last user turn
+	iter := chat.SendMessageStream(ctx, genai.Text(req.Messages[len(req.Messages)-1].Content))
 	for {
 		resp, err := iter.Next()
 		if errors.Is(err, context.Canceled) {
-			return err
+			return usage, err
 		}
 		if err != nil {
 			if err == iterator.Done {
-				return nil
+				return usage, nil
+			}
+			return usage, err
+		}
+		if m := resp.UsageMetadata; m != nil {
+			// Counts are cumulative; the last chunk carries the totals
+			usage = models.AIUsage{
+				PromptTokens:     int(m.PromptTokenCount),
+				CompletionTokens: int(m.CandidatesTokenCount),
+				TotalTokens:      int(m.TotalTokenCount),
 			}
-			return err
 		}
 		for _, c := range resp.Candidates {
+			if c.Content == nil {
+				continue
+			}
 			for _, p := range c.Content.Parts {
 				if t, ok := p.(genai.Text); ok {
 					onDelta(string(t))
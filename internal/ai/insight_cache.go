@@ -0,0 +1,152 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"refleks/internal/cache"
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// insightEntry is one cached answer.
+type insightEntry struct {
+	SessionID   string         `json:"sessionId"`
+	RecordsHash string         `json:"recordsHash"`
+	Text        string         `json:"text"`
+	Usage       models.AIUsage `json:"usage"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+// insightCacheFile is the on-disk shape of the insight cache.
+type insightCacheFile struct {
+	Entries map[string]insightEntry `json:"entries"`
+}
+
+// insightCache stores generated session insights in the cache directory, keyed by
+// FingerprintSession + provider + model + token budget + prompt hash.
+type insightCache struct {
+	cacheSvc *cache.Service
+
+	mu     sync.Mutex
+	data   insightCacheFile
+	loaded bool
+}
+
+func newInsightCache(cacheSvc *cache.Service) *insightCache {
+	c := &insightCache{cacheSvc: cacheSvc, data: insightCacheFile{Entries: map[string]insightEntry{}}}
+	if cacheSvc == nil {
+		c.loaded = true
+		return c
+	}
+	cacheSvc.RegisterOnClear(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.data = insightCacheFile{Entries: map[string]insightEntry{}}
+		c.loaded = true
+	})
+	cacheSvc.RegisterOnReload(func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.data = insightCacheFile{Entries: map[string]insightEntry{}}
+		c.loaded = false
+	})
+	return c
+}
+
+// insightCacheKey identifies an answer: the session fingerprint, the model that produced it, the user
+// prompt and the token budget (which decides how much of the session the payload carries). The rendered
+// payload itself isn't hashed: its live percentiles change between requests and need the network.
+func insightCacheKey(in SessionInsightsInput, provider, model string) string {
+	ph := sha256.Sum256([]byte(in.Prompt))
+	h := sha256.Sum256([]byte(FingerprintSession(in) + "|" + provider + "|" + model + "|" + strconv.Itoa(in.TokenBudget) + "|" + hex.EncodeToString(ph[:])))
+	return hex.EncodeToString(h[:16])
+}
+
+// recordsHash digests the records an answer was generated from, so edits invalidate it.
+func recordsHash(records []models.ScenarioRecord) string {
+	h := sha256.New()
+	for _, r := range records {
+		h.Write([]byte(r.FileName))
+		h.Write([]byte{0})
+		h.Write([]byte(strconv.FormatBool(r.HasTrace)))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// Get returns the cached answer for key if it is fresh and was generated from the same records.
+func (c *insightCache) Get(key string, records []models.ScenarioRecord) (insightEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ensureLoadedLocked()
+	e, ok := c.data.Entries[key]
+	if !ok {
+		return e, false
+	}
+	if e.RecordsHash != recordsHash(records) || time.Since(e.CreatedAt) > constants.AIInsightCacheTTLHours*time.Hour {
+		delete(c.data.Entries, key)
+		c.saveLocked()
+		return insightEntry{}, false
+	}
+	return e, true
+}
+
+// Put stores an answer. Entries of the same session generated from other records, expired entries
+// and the oldest entries beyond the size cap are dropped.
+func (c *insightCache) Put(key, sessionID string, records []models.ScenarioRecord, text string, usage models.AIUsage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ensureLoadedLocked()
+	now := time.Now()
+	rh := recordsHash(records)
+	for k, e := range c.data.Entries {
+		if (e.SessionID == sessionID && e.RecordsHash != rh) || now.Sub(e.CreatedAt) > constants.AIInsightCacheTTLHours*time.Hour {
+			delete(c.data.Entries, k)
+		}
+	}
+	c.data.Entries[key] = insightEntry{SessionID: sessionID, RecordsHash: rh, Text: text, Usage: usage, CreatedAt: now}
+	if n := len(c.data.Entries) - constants.AIInsightCacheMaxEntries; n > 0 {
+		keys := make([]string, 0, len(c.data.Entries))
+		for k := range c.data.Entries {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return c.data.Entries[keys[i]].CreatedAt.Before(c.data.Entries[keys[j]].CreatedAt)
+		})
+		for _, k := range keys[:n] {
+			delete(c.data.Entries, k)
+		}
+	}
+	c.saveLocked()
+}
+
+// ensureLoadedLocked lazily loads the persisted cache. Caller must hold c.mu.
+func (c *insightCache) ensureLoadedLocked() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+	if !c.cacheSvc.Exists(constants.AIInsightsCacheFileName) {
+		return
+	}
+	var data insightCacheFile
+	if err := c.cacheSvc.Load(constants.AIInsightsCacheFileName, &data); err != nil {
+		return
+	}
+	if data.Entries == nil {
+		data.Entries = map[string]insightEntry{}
+	}
+	c.data = data
+}
+
+// saveLocked persists the cache. Caller must hold c.mu.
+func (c *insightCache) saveLocked() {
+	if c.cacheSvc != nil {
+		_ = c.cacheSvc.Save(constants.AIInsightsCacheFileName, c.data)
+	}
+}
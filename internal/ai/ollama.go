@@ -0,0 +1,126 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// OllamaClient streams from a local Ollama server's /api/chat endpoint.
+type OllamaClient struct {
+	http    *http.Client
+	baseURL string
+	model   string
+}
+
+// NewOllamaClient constructs a client for baseURL (default http://localhost:11434) and model.
+func NewOllamaClient(baseURL, model string) *OllamaClient {
+	if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL == "" {
+		baseURL = constants.AIDefaultOllamaBaseURL
+	}
+	if model == "" {
+		model = constants.AIDefaultOllamaModel
+	}
+	return &OllamaClient{http: &http.Client{}, baseURL: baseURL, model: model}
+}
+
+// Name returns constants.AIProviderOllama.
+func (c *OllamaClient) Name() string { return constants.AIProviderOllama }
+
+// Model returns the model name sent with each request.
+func (c *OllamaClient) Model() string { return c.model }
+
+// Close releases idle connections.
+func (c *OllamaClient) Close() error {
+	c.http.CloseIdleConnections()
+	return nil
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []openAIMessage `json:"messages"` // same {role, content} shape
+	Stream   bool            `json:"stream"`
+	Options  struct {
+		Temperature float64 `json:"temperature"`
+		TopP        float64 `json:"top_p"`
+		NumPredict  int     `json:"num_predict"`
+	} `json:"options"`
+}
+
+type ollamaChunk struct {
+	Message struct {
+		Content string `json:"content"`
+	} `json:"message"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int    `json:"prompt_eval_count"`
+	EvalCount       int    `json:"eval_count"`
+	Error           string `json:"error"`
+}
+
+// Stream posts req to /api/chat and reads the newline-delimited JSON response.
+func (c *OllamaClient) Stream(ctx context.Context, req Request, onDelta func(string)) (models.AIUsage, error) {
+	var usage models.AIUsage
+	body := ollamaRequest{
+		Model:    c.model,
+		Messages: []openAIMessage{{Role: "system", Content: req.System}},
+		Stream:   true,
+	}
+	body.Options.Temperature = constants.AITemperature
+	body.Options.TopP = constants.AITopP
+	body.Options.NumPredict = constants.AIMaxOutputTokens
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, openAIMessage{Role: m.Role, Content: m.Content})
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return usage, err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/chat", bytes.NewReader(b))
+	if err != nil {
+		return usage, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(hreq)
+	if err != nil {
+		return usage, ctxErr(ctx, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return usage, httpError(resp)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var chunk ollamaChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			return usage, fmt.Errorf("decode stream chunk: %w", err)
+		}
+		if chunk.Error != "" {
+			return usage, fmt.Errorf("ollama: %s", chunk.Error)
+		}
+		if chunk.Message.Content != "" {
+			onDelta(chunk.Message.Content)
+		}
+		if chunk.Done {
+			usage = models.AIUsage{PromptTokens: chunk.PromptEvalCount, CompletionTokens: chunk.EvalCount, TotalTokens: chunk.PromptEvalCount + chunk.EvalCount}
+			return usage, nil
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return usage, ctxErr(ctx, err)
+	}
+	return usage, ctxErr(ctx, errors.New("ollama: stream ended before completion"))
+}
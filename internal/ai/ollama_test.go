@@ -0,0 +1,108 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ndjsonServer serves the given NDJSON lines from /api/chat and records the decoded request.
+func ndjsonServer(t *testing.T, got *ollamaRequest, lines ...string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			http.NotFound(w, r)
+			return
+		}
+		if got != nil {
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		for _, l := range lines {
+			fmt.Fprintf(w, "%s\n", l)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOllamaStream(t *testing.T) {
+	var got ollamaRequest
+	srv := ndjsonServer(t, &got,
+		`{"message":{"role":"assistant","content":"Hello"},"done":false}`,
+		"",
+		`{"message":{"role":"assistant","content":", world"},"done":false}`,
+		`{"message":{"role":"assistant","content":""},"done":true,"prompt_eval_count":20,"eval_count":4}`,
+		`{"message":{"role":"assistant","content":"after done"},"done":false}`,
+	)
+	c := NewOllamaClient(srv.URL+"/", "test-model")
+	defer c.Close()
+
+	var out strings.Builder
+	usage, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(s string) { out.WriteString(s) })
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if out.String() != "Hello, world" {
+		t.Errorf("text = %q, want %q", out.String(), "Hello, world")
+	}
+	if usage.PromptTokens != 20 || usage.CompletionTokens != 4 || usage.TotalTokens != 24 {
+		t.Errorf("usage = %+v", usage)
+	}
+	if got.Model != "test-model" || !got.Stream {
+		t.Errorf("request = %+v", got)
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Messages[1].Content != "hi" {
+		t.Errorf("messages = %+v", got.Messages)
+	}
+}
+
+func TestOllamaStreamErrorChunk(t *testing.T) {
+	srv := ndjsonServer(t, nil,
+		`{"message":{"content":"partial"},"done":false}`,
+		`{"error":"model not found"}`,
+	)
+	c := NewOllamaClient(srv.URL, "m")
+	defer c.Close()
+
+	_, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Fatalf("err = %v, want model not found", err)
+	}
+}
+
+func TestOllamaStreamEndsBeforeDone(t *testing.T) {
+	srv := ndjsonServer(t, nil, `{"message":{"content":"partial"},"done":false}`)
+	c := NewOllamaClient(srv.URL, "m")
+	defer c.Close()
+
+	_, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "before completion") {
+		t.Fatalf("err = %v, want stream ended before completion", err)
+	}
+}
+
+func TestOllamaStreamCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"message":{"content":"first"},"done":false}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	c := NewOllamaClient(srv.URL, "m")
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := c.Stream(ctx, singleTurn("sys", "hi"), func(string) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
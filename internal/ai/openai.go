@@ -0,0 +1,170 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// OpenAIClient streams from any OpenAI-compatible chat completions endpoint
+// (OpenAI, LM Studio, llama.cpp server, vLLM, OpenRouter, ...).
+type OpenAIClient struct {
+	http    *http.Client
+	baseURL string
+	apiKey  string
+	model   string
+}
+
+// NewOpenAIClient constructs a client for baseURL (e.g. "http://localhost:1234/v1"); the API key is
+// optional for local servers. Empty values use the OpenAI defaults.
+func NewOpenAIClient(baseURL, apiKey, model string) *OpenAIClient {
+	if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL == "" {
+		baseURL = constants.AIDefaultOpenAIBaseURL
+	}
+	if model == "" {
+		model = constants.AIDefaultOpenAIModel
+	}
+	return &OpenAIClient{http: &http.Client{}, baseURL: baseURL, apiKey: apiKey, model: model}
+}
+
+// Name returns constants.AIProviderOpenAI.
+func (c *OpenAIClient) Name() string { return constants.AIProviderOpenAI }
+
+// Model returns the model name sent with each request.
+func (c *OpenAIClient) Model() string { return c.model }
+
+// Close releases idle connections.
+func (c *OpenAIClient) Close() error {
+	c.http.CloseIdleConnections()
+	return nil
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIRequest struct {
+	Model         string          `json:"model"`
+	Messages      []openAIMessage `json:"messages"`
+	Stream        bool            `json:"stream"`
+	StreamOptions struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options"`
+	Temperature float64 `json:"temperature"`
+	TopP        float64 `json:"top_p"`
+	MaxTokens   int     `json:"max_tokens"`
+}
+
+type openAIChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// Stream posts req to /chat/completions with stream enabled and reads the server-sent events.
+func (c *OpenAIClient) Stream(ctx context.Context, req Request, onDelta func(string)) (models.AIUsage, error) {
+	var usage models.AIUsage
+	body := openAIRequest{
+		Model:       c.model,
+		Messages:    []openAIMessage{{Role: "system", Content: req.System}},
+		Stream:      true,
+		Temperature: constants.AITemperature,
+		TopP:        constants.AITopP,
+		MaxTokens:   constants.AIMaxOutputTokens,
+	}
+	body.StreamOptions.IncludeUsage = true
+	for _, m := range req.Messages {
+		body.Messages = append(body.Messages, openAIMessage{Role: m.Role, Content: m.Content})
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return usage, err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(b))
+	if err != nil {
+		return usage, err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	hreq.Header.Set("Accept", "text/event-stream")
+	if c.apiKey != "" {
+		hreq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	resp, err := c.http.Do(hreq)
+	if err != nil {
+		return usage, ctxErr(ctx, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return usage, httpError(resp)
+	}
+
+	sc := bufio.NewScanner(resp.Body)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue // blank separators, comments and event names
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return usage, nil
+		}
+		var chunk openAIChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return usage, fmt.Errorf("decode stream chunk: %w", err)
+		}
+		if chunk.Error != nil {
+			return usage, fmt.Errorf("openai: %s", chunk.Error.Message)
+		}
+		if chunk.Usage != nil {
+			usage = models.AIUsage{PromptTokens: chunk.Usage.PromptTokens, CompletionTokens: chunk.Usage.CompletionTokens, TotalTokens: chunk.Usage.TotalTokens}
+		}
+		for _, ch := range chunk.Choices {
+			if ch.Delta.Content != "" {
+				onDelta(ch.Delta.Content)
+			}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return usage, ctxErr(ctx, err)
+	}
+	return usage, ctxErr(ctx, errors.New("openai: stream ended before completion"))
+}
+
+// ctxErr prefers the context error, so a cancelled stream reports context.Canceled.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// httpError builds an error from a non-200 response, including the start of the body.
+func httpError(resp *http.Response) error {
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	msg := strings.TrimSpace(string(b))
+	if msg == "" {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return fmt.Errorf("HTTP %d: %s", resp.StatusCode, msg)
+}
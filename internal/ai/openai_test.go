@@ -0,0 +1,128 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// sseServer serves the given SSE lines from /chat/completions and records the decoded request.
+func sseServer(t *testing.T, got *openAIRequest, lines ...string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		if got != nil {
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Errorf("decode request: %v", err)
+			}
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, l := range lines {
+			fmt.Fprintf(w, "%s\n", l)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOpenAIStream(t *testing.T) {
+	var got openAIRequest
+	srv := sseServer(t, &got,
+		": keep-alive",
+		"event: message",
+		`data: {"choices":[{"delta":{"content":"Hello"}}]}`,
+		"",
+		`data: {"choices":[{"delta":{"content":", world"}}]}`,
+		"",
+		`data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3,"total_tokens":15}}`,
+		"",
+		"data: [DONE]",
+		"",
+		`data: {"choices":[{"delta":{"content":"after done"}}]}`,
+	)
+	c := NewOpenAIClient(srv.URL+"/", "key", "test-model")
+	defer c.Close()
+
+	var out strings.Builder
+	usage, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(s string) { out.WriteString(s) })
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if out.String() != "Hello, world" {
+		t.Errorf("text = %q, want %q", out.String(), "Hello, world")
+	}
+	if usage.PromptTokens != 12 || usage.CompletionTokens != 3 || usage.TotalTokens != 15 {
+		t.Errorf("usage = %+v", usage)
+	}
+	if got.Model != "test-model" || !got.Stream || !got.StreamOptions.IncludeUsage {
+		t.Errorf("request = %+v", got)
+	}
+	if len(got.Messages) != 2 || got.Messages[0].Role != "system" || got.Messages[1].Content != "hi" {
+		t.Errorf("messages = %+v", got.Messages)
+	}
+}
+
+func TestOpenAIStreamErrorChunk(t *testing.T) {
+	srv := sseServer(t, nil,
+		`data: {"choices":[{"delta":{"content":"partial"}}]}`,
+		`data: {"error":{"message":"rate limited"}}`,
+	)
+	c := NewOpenAIClient(srv.URL, "", "m")
+	defer c.Close()
+
+	_, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "rate limited") {
+		t.Fatalf("err = %v, want rate limited", err)
+	}
+}
+
+func TestOpenAIStreamEndsBeforeDone(t *testing.T) {
+	srv := sseServer(t, nil, `data: {"choices":[{"delta":{"content":"partial"}}]}`)
+	c := NewOpenAIClient(srv.URL, "", "m")
+	defer c.Close()
+
+	_, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "before completion") {
+		t.Fatalf("err = %v, want stream ended before completion", err)
+	}
+}
+
+func TestOpenAIStreamHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad key", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	c := NewOpenAIClient(srv.URL, "", "m")
+	defer c.Close()
+
+	_, err := c.Stream(context.Background(), singleTurn("sys", "hi"), func(string) {})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 401") || !strings.Contains(err.Error(), "bad key") {
+		t.Fatalf("err = %v, want HTTP 401 with body", err)
+	}
+}
+
+func TestOpenAIStreamCancel(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `data: {"choices":[{"delta":{"content":"first"}}]}`)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+	c := NewOpenAIClient(srv.URL, "", "m")
+	defer c.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := c.Stream(ctx, singleTurn("sys", "hi"), func(string) { cancel() })
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,142 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/plans"
+	"refleks/internal/scenarios"
+)
+
+// aiPlan is the JSON schema the training planner must answer with.
+type aiPlan struct {
+	Name            string       `json:"name"`
+	Description     string       `json:"description"`
+	SensitivityNote string       `json:"sensitivityNote"`
+	Items           []aiPlanItem `json:"items"`
+}
+
+type aiPlanItem struct {
+	Scenario    string  `json:"scenario"`
+	Runs        int     `json:"runs"`
+	Focus       string  `json:"focus"`
+	TargetScore float64 `json:"targetScore"`
+	SensNote    string  `json:"sensNote"`
+}
+
+// GenerateTrainingPlan asks the model for a structured training plan based on the given records.
+// The answer is validated against the plan schema and the known scenarios; invalid answers are sent
+// back with the errors for repair, up to AIPlanMaxAttempts attempts. The returned plan is not stored.
+// It blocks until the plan is generated and does not emit stream events; Cancel(reqID) stops it.
+func (s *Service) GenerateTrainingPlan(reqID string, records []models.ScenarioRecord, prompt string, options models.AIOptions) (models.Plan, error) {
+	if len(records) == 0 {
+		return models.Plan{}, errors.New("no runs to plan from")
+	}
+	cfg := s.config()
+	if cfg.Provider == constants.AIProviderGemini && cfg.APIKey == "" {
+		return models.Plan{}, errors.New("missing Gemini API key: set it in Settings or REFLEKS_GEMINI_API_KEY")
+	}
+	client, err := NewProvider(s.ctx, cfg)
+	if err != nil {
+		return models.Plan{}, err
+	}
+	defer client.Close()
+	ctx, release := s.cancellable(reqID)
+	defer release()
+
+	options.SystemPersona = constants.AITrainingPlannerPersona
+	input := SessionInsightsInput{Records: records, Options: options, Prompt: prompt, TokenBudget: cfg.TokenBudget()}
+	system, user, _ := s.sessionPayload(ctx, input)
+	known := knownScenarios(records)
+
+	req := singleTurn(system, user)
+	var lastErr error
+	for attempt := 0; attempt < constants.AIPlanMaxAttempts; attempt++ {
+		var answer strings.Builder
+		if _, err := client.Stream(ctx, req, func(text string) { answer.WriteString(text) }); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return models.Plan{}, errors.New("training plan generation cancelled")
+			}
+			return models.Plan{}, errors.New(providerErrorMessage(client, err))
+		}
+		plan, err := parsePlan(answer.String(), known)
+		if err == nil {
+			return plan, nil
+		}
+		lastErr = err
+		req.Messages = append(req.Messages,
+			Message{Role: RoleAssistant, Content: answer.String()},
+			Message{Role: RoleUser, Content: planRepairMessage(err)},
+		)
+	}
+	return models.Plan{}, fmt.Errorf("the model did not return a valid plan after %d attempts: %w", constants.AIPlanMaxAttempts, lastErr)
+}
+
+// parsePlan decodes and validates a planner answer. Scenario names are matched case-insensitively
+// against known and replaced by their canonical spelling.
+func parsePlan(answer string, known map[string]string) (models.Plan, error) {
+	raw, ok := extractJSON(answer)
+	if !ok {
+		return models.Plan{}, errors.New("no JSON object found in the answer")
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	dec.DisallowUnknownFields()
+	var ap aiPlan
+	if err := dec.Decode(&ap); err != nil {
+		return models.Plan{}, fmt.Errorf("invalid JSON: %v", err)
+	}
+	p := models.Plan{Name: ap.Name, Description: ap.Description, Source: plans.SourceAI, SensitivityNote: ap.SensitivityNote}
+	var unknown []string
+	for _, it := range ap.Items {
+		name, ok := known[strings.ToLower(strings.TrimSpace(it.Scenario))]
+		if !ok {
+			unknown = append(unknown, fmt.Sprintf("%q", it.Scenario))
+			name = it.Scenario
+		}
+		p.Items = append(p.Items, models.PlanItem{Scenario: name, Runs: it.Runs, Focus: it.Focus, TargetScore: it.TargetScore, SensNote: it.SensNote})
+	}
+	err := plans.Validate(p)
+	if len(unknown) > 0 {
+		uerr := fmt.Errorf("unknown scenarios %s: use exact names from the payload", strings.Join(unknown, ", "))
+		err = errors.Join(err, uerr)
+	}
+	if err != nil {
+		return models.Plan{}, err
+	}
+	return p, nil
+}
+
+// extractJSON returns the outermost JSON object of an answer, ignoring code fences and surrounding prose.
+func extractJSON(answer string) (string, bool) {
+	i := strings.Index(answer, "{")
+	j := strings.LastIndex(answer, "}")
+	if i < 0 || j < i {
+		return "", false
+	}
+	return answer[i : j+1], true
+}
+
+// planRepairMessage asks the model to fix an invalid plan.
+func planRepairMessage(err error) string {
+	return fmt.Sprintf("The plan is invalid: %v.\nReply with the corrected plan only, as a single JSON object matching the schema.", err)
+}
+
+// knownScenarios maps the lower-cased names of the played and curated scenarios to their spelling.
+func knownScenarios(records []models.ScenarioRecord) map[string]string {
+	out := map[string]string{}
+	for name := range scenarios.All() {
+		out[strings.ToLower(name)] = name
+	}
+	for _, r := range records {
+		if name := strings.TrimSpace(safeScenarioName(r)); name != "" {
+			out[strings.ToLower(name)] = name
+		}
+	}
+	return out
+}
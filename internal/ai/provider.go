@@ -0,0 +1,116 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+)
+
+// Roles of the turns in a Request.
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is one turn of a conversation.
+type Message struct {
+	Role    string `json:"role"` // RoleUser or RoleAssistant
+	Content string `json:"content"`
+}
+
+// Request is a single generation: a system instruction plus the conversation so far,
+// ending with the user turn to answer.
+type Request struct {
+	System   string
+	Messages []Message
+}
+
+// LLMProvider is a chat model backend. Implementations stream the response text through onDelta
+// as it arrives, stop as soon as ctx is cancelled (returning ctx.Err()) and report token usage
+// when the backend provides it.
+type LLMProvider interface {
+	// Name returns the provider ID (constants.AIProvider*).
+	Name() string
+	// Model returns the model the provider generates with.
+	Model() string
+	// Stream runs a generation and calls onDelta for each text chunk.
+	Stream(ctx context.Context, req Request, onDelta func(string)) (models.AIUsage, error)
+	// Close releases underlying resources.
+	Close() error
+}
+
+// Config selects and configures a provider.
+type Config struct {
+	Provider string
+	Model    string
+	BaseURL  string
+	APIKey   string
+	// PromptTokenBudget caps the estimated prompt size (0 = AIDefaultPromptTokenBudget)
+	PromptTokenBudget int
+}
+
+// ConfigFromSettings resolves the provider configuration from settings, applying the environment
+// variable overrides of the API keys.
+func ConfigFromSettings(s models.Settings) Config {
+	cfg := Config{Provider: s.AI.Provider, Model: s.AI.Model, BaseURL: s.AI.BaseURL, APIKey: s.AI.APIKey, PromptTokenBudget: s.AI.PromptTokenBudget}
+	switch cfg.Provider {
+	case constants.AIProviderOpenAI:
+		if v := appsettings.GetEnv(constants.EnvOpenAIAPIKeyVar); v != "" {
+			cfg.APIKey = v
+		}
+	case constants.AIProviderOllama:
+	default:
+		cfg.Provider = constants.AIProviderGemini
+		cfg.APIKey = appsettings.GetEnv(constants.EnvGeminiAPIKeyVar)
+		if cfg.APIKey == "" {
+			cfg.APIKey = s.GeminiAPIKey
+		}
+	}
+	return cfg
+}
+
+// ModelName returns the model the configured provider will use.
+func (c Config) ModelName() string {
+	if c.Model != "" {
+		return c.Model
+	}
+	switch c.Provider {
+	case constants.AIProviderOpenAI:
+		return constants.AIDefaultOpenAIModel
+	case constants.AIProviderOllama:
+		return constants.AIDefaultOllamaModel
+	default:
+		return constants.AIDefaultModel
+	}
+}
+
+// TokenBudget returns the prompt token budget, applying the default.
+func (c Config) TokenBudget() int {
+	if c.PromptTokenBudget > 0 {
+		return c.PromptTokenBudget
+	}
+	return constants.AIDefaultPromptTokenBudget
+}
+
+// NewProvider constructs the provider selected by cfg.
+func NewProvider(ctx context.Context, cfg Config) (LLMProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", constants.AIProviderGemini:
+		return NewGeminiClient(ctx, cfg.APIKey, cfg.Model)
+	case constants.AIProviderOpenAI:
+		return NewOpenAIClient(cfg.BaseURL, cfg.APIKey, cfg.Model), nil
+	case constants.AIProviderOllama:
+		return NewOllamaClient(cfg.BaseURL, cfg.Model), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider: %q", cfg.Provider)
+	}
+}
+
+// singleTurn wraps one user message in a Request.
+func singleTurn(system, user string) Request {
+	return Request{System: system, Messages: []Message{{Role: RoleUser, Content: user}}}
+}
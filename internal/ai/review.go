@@ -0,0 +1,425 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/scenarios"
+	"refleks/internal/util"
+)
+
+// reviewIDPrefix marks the conversation/session IDs of training block reviews ("review:2026-09-01:2026-09-28").
+const reviewIDPrefix = "review:"
+
+// reviewDateLayout is the layout of review range bounds (local dates).
+const reviewDateLayout = "2006-01-02"
+
+// ReviewInput is the data of a training block review.
+type ReviewInput struct {
+	// From and To bound the reviewed period (inclusive, local midnight of each day).
+	From time.Time
+	To   time.Time
+	// History holds every known run, including runs before From (used for personal bests).
+	History     []models.ScenarioRecord
+	RankChanges []models.BenchmarkRankChange
+	Prompt      string
+}
+
+// ReviewSessionID returns the session ID a review of [from, to] is stored under.
+func ReviewSessionID(from, to time.Time) string {
+	return reviewIDPrefix + from.Format(reviewDateLayout) + ":" + to.Format(reviewDateLayout)
+}
+
+// parseReviewSessionID returns the period of a review session ID.
+func parseReviewSessionID(id string) (from, to time.Time, ok bool) {
+	rest, found := strings.CutPrefix(id, reviewIDPrefix)
+	if !found {
+		return
+	}
+	a, b, found := strings.Cut(rest, ":")
+	if !found {
+		return
+	}
+	from, err1 := time.ParseInLocation(reviewDateLayout, a, time.Local)
+	to, err2 := time.ParseInLocation(reviewDateLayout, b, time.Local)
+	return from, to, err1 == nil && err2 == nil && !to.Before(from)
+}
+
+// ReviewRange resolves the bounds of a review from "YYYY-MM-DD" dates. An empty to means today and
+// an empty from AIReviewDefaultDays days before to.
+func ReviewRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if strings.TrimSpace(to) != "" {
+		t, err := time.ParseInLocation(reviewDateLayout, strings.TrimSpace(to), time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date %q (want YYYY-MM-DD)", to)
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -(constants.AIReviewDefaultDays - 1))
+	if strings.TrimSpace(from) != "" {
+		t, err := time.ParseInLocation(reviewDateLayout, strings.TrimSpace(from), time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid start date %q (want YYYY-MM-DD)", from)
+		}
+		start = t
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end date %s is before start date %s", end.Format(reviewDateLayout), start.Format(reviewDateLayout))
+	}
+	return start, end, nil
+}
+
+// reviewRecords returns the runs of history played within [from, to].
+func reviewRecords(history []models.ScenarioRecord, from, to time.Time) []models.ScenarioRecord {
+	end := to.AddDate(0, 0, 1)
+	var out []models.ScenarioRecord
+	for _, r := range history {
+		t := util.DatePlayed(r)
+		if t.IsZero() || t.Before(from) || !t.Before(end) || safeScenarioName(r) == "" {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// BuildReviewPrompt constructs the system instruction and user message of a training block review.
+// Unlike BuildSessionPrompt it sends no individual runs: only per-scenario trends, skill-bucket
+// aggregates, sensitivity changes, benchmark rank changes and training volume.
+func BuildReviewPrompt(in ReviewInput) (system string, user string) {
+	return SystemPrompt(constants.AITrainingReviewPersona), buildReviewPayload(in)
+}
+
+// reviewEstimate reports the estimated size of a review prompt. Review payloads are already
+// aggregated and capped at AIReviewMaxScenarios, so they are not summarised further.
+func reviewEstimate(system, user string, budget int) models.AIPromptEstimate {
+	est := models.AIPromptEstimate{SystemTokens: EstimateTokens(system), DataTokens: EstimateTokens(user), Budget: budget}
+	est.Tokens = est.SystemTokens + est.DataTokens
+	est.OverBudget = est.Tokens > budget
+	return est
+}
+
+type reviewRun struct {
+	t     time.Time
+	score float64
+	acc   float64
+	cm360 float64
+}
+
+type reviewScenario struct {
+	Name            string   `json:"name"`
+	Tags            []string `json:"tags,omitempty"`
+	Runs            int      `json:"runs"`
+	ActiveDays      int      `json:"activeDays"`
+	First           string   `json:"first"`
+	Last            string   `json:"last"`
+	AvgScore        float64  `json:"avgScore"`
+	BestScore       float64  `json:"bestScore"`
+	BestDate        string   `json:"bestDate"`
+	PrevBest        float64  `json:"prevBest,omitempty"`
+	PBDates         []string `json:"pbDates,omitempty"`
+	SlopePerWeek    float64  `json:"slopePerWeek"`
+	SlopePctPerWeek float64  `json:"slopePctPerWeek"`
+	EarlyAvg        float64  `json:"earlyAvg"`
+	LateAvg         float64  `json:"lateAvg"`
+	EarlyAcc        float64  `json:"earlyAcc"`
+	LateAcc         float64  `json:"lateAcc"`
+	DaysSincePB     int      `json:"daysSincePb"`
+	Plateau         bool     `json:"plateau"`
+}
+
+type reviewVolume struct {
+	Period    string `json:"period"` // date, or week start for long reviews
+	Runs      int    `json:"runs"`
+	Scenarios int    `json:"scenarios"`
+}
+
+type reviewSensChange struct {
+	Date string  `json:"date"`
+	From float64 `json:"fromCm360"`
+	To   float64 `json:"toCm360"`
+}
+
+func buildReviewPayload(in ReviewInput) string {
+	from, to := in.From, in.To
+	end := to.AddDate(0, 0, 1)
+	days := int(math.Round(end.Sub(from).Hours() / 24))
+
+	// All runs per scenario in chronological order (before and during the period)
+	byName := map[string][]reviewRun{}
+	var earliest time.Time
+	for _, r := range in.History {
+		name := safeScenarioName(r)
+		t := util.DatePlayed(r)
+		if name == "" || t.IsZero() || !t.Before(end) {
+			continue
+		}
+		if earliest.IsZero() || t.Before(earliest) {
+			earliest = t
+		}
+		byName[name] = append(byName[name], reviewRun{
+			t:     t,
+			score: asFloat(r.Stats["Score"]),
+			acc:   asFloat(r.Stats["Accuracy"]),
+			cm360: asFloat(r.Stats["cm/360"]),
+		})
+	}
+
+	type dayAgg struct {
+		runs      int
+		scenarios map[string]struct{}
+		cm360     []float64
+	}
+	perDay := map[string]*dayAgg{}
+	var list []reviewScenario
+	totalRuns := 0
+	for name, runs := range byName {
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].t.Before(runs[j].t) })
+		sc, inPeriod := reviewScenarioOf(name, runs, from, to)
+		if len(inPeriod) == 0 {
+			continue
+		}
+		totalRuns += len(inPeriod)
+		for _, r := range inPeriod {
+			d := r.t.Format(reviewDateLayout)
+			a := perDay[d]
+			if a == nil {
+				a = &dayAgg{scenarios: map[string]struct{}{}}
+				perDay[d] = a
+			}
+			a.runs++
+			a.scenarios[name] = struct{}{}
+			if r.cm360 > 0 {
+				a.cm360 = append(a.cm360, r.cm360)
+			}
+		}
+		list = append(list, sc)
+	}
+	// Most played first; keep the payload small
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Runs != list[j].Runs {
+			return list[i].Runs > list[j].Runs
+		}
+		return list[i].Name < list[j].Name
+	})
+	omitted := 0
+	if len(list) > constants.AIReviewMaxScenarios {
+		omitted = len(list) - constants.AIReviewMaxScenarios
+		list = list[:constants.AIReviewMaxScenarios]
+	}
+
+	// Skill buckets from scenario tags
+	type bucketAgg struct {
+		runs, scenarios, improving, plateaued int
+		slope                                 float64
+	}
+	sums := map[string]*bucketAgg{}
+	for _, sc := range list {
+		for _, tag := range sc.Tags {
+			if strings.TrimSpace(tag) == "" {
+				continue
+			}
+			b := sums[tag]
+			if b == nil {
+				b = &bucketAgg{}
+				sums[tag] = b
+			}
+			b.runs += sc.Runs
+			b.scenarios++
+			b.slope += sc.SlopePctPerWeek
+			if sc.SlopePctPerWeek > 0 && !sc.Plateau {
+				b.improving++
+			}
+			if sc.Plateau {
+				b.plateaued++
+			}
+		}
+	}
+	buckets := map[string]map[string]float64{}
+	for tag, b := range sums {
+		buckets[tag] = map[string]float64{
+			"runs":               float64(b.runs),
+			"scenarios":          float64(b.scenarios),
+			"avgSlopePctPerWeek": util.Round(b.slope/float64(b.scenarios), 2),
+			"improving":          float64(b.improving),
+			"plateaued":          float64(b.plateaued),
+		}
+	}
+
+	// Volume per day (or per week for long periods) and sensitivity changes between active days
+	dates := make([]string, 0, len(perDay))
+	for d := range perDay {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+	var volume []reviewVolume
+	var sens []reviewSensChange
+	weekly := days > constants.AIReviewDailyVolumeMaxDays
+	prevCm := 0.0
+	for _, d := range dates {
+		a := perDay[d]
+		period := d
+		if weekly {
+			t, _ := time.ParseInLocation(reviewDateLayout, d, time.Local)
+			period = t.AddDate(0, 0, -int((t.Weekday()+6)%7)).Format(reviewDateLayout) // Monday
+		}
+		if n := len(volume); n > 0 && volume[n-1].Period == period {
+			volume[n-1].Runs += a.runs
+			volume[n-1].Scenarios += len(a.scenarios)
+		} else {
+			volume = append(volume, reviewVolume{Period: period, Runs: a.runs, Scenarios: len(a.scenarios)})
+		}
+		if cm := median(a.cm360); cm > 0 {
+			if prevCm > 0 && math.Abs(cm-prevCm)/prevCm*100 >= constants.AIReviewSensChangePct {
+				sens = append(sens, reviewSensChange{Date: d, From: util.Round(prevCm, 2), To: util.Round(cm, 2)})
+			}
+			prevCm = cm
+		}
+	}
+	runsPerActiveDay := 0.0
+	if len(dates) > 0 {
+		runsPerActiveDay = util.Round(float64(totalRuns)/float64(len(dates)), 2)
+	}
+
+	payload := struct {
+		Range       map[string]any                `json:"range"`
+		Info        map[string]string             `json:"info"`
+		Volume      map[string]any                `json:"volume"`
+		Scenarios   []reviewScenario              `json:"scenarios"`
+		Omitted     int                           `json:"omittedScenarios,omitempty"`
+		Buckets     map[string]map[string]float64 `json:"buckets,omitempty"`
+		Sensitivity []reviewSensChange            `json:"sensitivityChanges,omitempty"`
+		Benchmarks  []models.BenchmarkRankChange  `json:"benchmarks,omitempty"`
+		Prompt      string                        `json:"prompt"`
+	}{
+		Range: map[string]any{"from": from.Format(reviewDateLayout), "to": to.Format(reviewDateLayout), "days": days},
+		Info: map[string]string{
+			"units.acc":          "fraction 0..1 (e.g., 0.78 = 78%)",
+			"units.cm360":        "centimeters per 360° (higher = lower sensitivity)",
+			"slopePerWeek":       "linear trend of the score in score units per week over the period; slopePctPerWeek relative to avgScore",
+			"earlyAvg":           "average score of the first half of the period's runs; lateAvg of the second half",
+			"prevBest":           "best score before the period (absent if first played in it); pbDates lists the days a new best was set",
+			"plateau":            fmt.Sprintf("true when played in the last %d days without a new best and the trend is under %.0f%%/week", constants.AIReviewPlateauDays, constants.AIReviewPlateauSlopePct),
+			"volume":             "runs and distinct scenarios per day (per week, keyed by Monday, for long periods)",
+			"benchmarks":         "rank/progress change of the player's benchmarks between the daily snapshots nearest the period bounds",
+			"sensitivityChanges": "compares the median cm/360 of consecutive active days",
+		},
+		Volume: map[string]any{
+			"runs":             totalRuns,
+			"activeDays":       len(dates),
+			"runsPerActiveDay": runsPerActiveDay,
+			"byPeriod":         volume,
+		},
+		Scenarios:   list,
+		Omitted:     omitted,
+		Buckets:     buckets,
+		Sensitivity: sens,
+		Benchmarks:  in.RankChanges,
+		Prompt:      strings.TrimSpace(in.Prompt),
+	}
+	if payload.Scenarios == nil {
+		payload.Scenarios = []reviewScenario{}
+	}
+	if earliest.IsZero() || !earliest.Before(from) {
+		// Nothing before the period: the personal-best context is partial, not absent by choice
+		payload.Info["history"] = "no runs before the period start are available, so prevBest is absent and pbDates/daysSincePb only reflect runs within the period; do not treat every best in the period as a new all-time PB"
+	}
+	b, _ := json.Marshal(payload)
+	return "Training block data (JSON below). Use ONLY these stats. If the prompt is empty or asks for an overall review, produce a concise structured Markdown review of the whole period with relevant headings (##) you choose (e.g. Summary, Progress, Plateaus, Skill Balance, Volume & Consistency, Sensitivity, Next Block). Avoid filler; do not fabricate metrics.\n" + string(b)
+}
+
+// reviewScenarioOf summarises a scenario's runs (chronological, all history up to the end of the period).
+// It returns the summary and the runs played within the period.
+func reviewScenarioOf(name string, runs []reviewRun, from, to time.Time) (reviewScenario, []reviewRun) {
+	sc := reviewScenario{Name: name}
+	if m, ok := scenarios.Get(name); ok {
+		sc.Tags = m.Tags
+	}
+	best, bestAt := 0.0, time.Time{}
+	var inPeriod []reviewRun
+	days := map[string]struct{}{}
+	pbDays := map[string]struct{}{}
+	for _, r := range runs {
+		if r.t.Before(from) {
+			if r.score > best {
+				best, bestAt = r.score, r.t
+			}
+			sc.PrevBest = best
+			continue
+		}
+		inPeriod = append(inPeriod, r)
+		d := r.t.Format(reviewDateLayout)
+		days[d] = struct{}{}
+		if r.score > best {
+			best, bestAt = r.score, r.t
+			if _, seen := pbDays[d]; !seen {
+				pbDays[d] = struct{}{}
+				sc.PBDates = append(sc.PBDates, d)
+			}
+		}
+	}
+	if len(inPeriod) == 0 {
+		return sc, nil
+	}
+	sc.Runs = len(inPeriod)
+	sc.ActiveDays = len(days)
+	sc.First = inPeriod[0].t.Format(reviewDateLayout)
+	sc.Last = inPeriod[len(inPeriod)-1].t.Format(reviewDateLayout)
+	sc.BestScore = util.Round(best, 2)
+	sc.BestDate = bestAt.Format(reviewDateLayout)
+	sc.PrevBest = util.Round(sc.PrevBest, 2)
+
+	scores := make([]float64, len(inPeriod))
+	accs := make([]float64, len(inPeriod))
+	xs := make([]float64, len(inPeriod))
+	for i, r := range inPeriod {
+		scores[i] = r.score
+		accs[i] = r.acc
+		xs[i] = r.t.Sub(from).Hours() / 24
+	}
+	avg := mean(scores)
+	sc.AvgScore = util.Round(avg, 2)
+	half := len(inPeriod) / 2
+	if half == 0 {
+		half = 1
+	}
+	sc.EarlyAvg = util.Round(mean(scores[:half]), 2)
+	sc.LateAvg = util.Round(mean(scores[len(scores)-half:]), 2)
+	sc.EarlyAcc = util.Round(mean(accs[:half]), 2)
+	sc.LateAcc = util.Round(mean(accs[len(accs)-half:]), 2)
+	_, perDay := util.LinearFit(xs, scores)
+	slope := perDay * 7
+	sc.SlopePerWeek = util.Round(slope, 2)
+	if avg != 0 {
+		sc.SlopePctPerWeek = util.Round(slope/avg*100, 2)
+	}
+
+	end := to.AddDate(0, 0, 1)
+	sc.DaysSincePB = int(end.Sub(bestAt).Hours() / 24)
+	recent := end.AddDate(0, 0, -constants.AIReviewPlateauDays)
+	playedRecently := !inPeriod[len(inPeriod)-1].t.Before(recent)
+	sc.Plateau = sc.Runs >= constants.AIReviewPlateauMinRuns && playedRecently &&
+		bestAt.Before(recent) && sc.SlopePctPerWeek < constants.AIReviewPlateauSlopePct
+	return sc, inPeriod
+}
+
+// median returns the median of vs (0 when empty).
+func median(vs []float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	s := append([]float64(nil), vs...)
+	sort.Float64s(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
@@ -2,77 +2,310 @@ package ai
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
+	"refleks/internal/cache"
 	"refleks/internal/constants"
+	"refleks/internal/leaderboard"
 	"refleks/internal/models"
 	appsettings "refleks/internal/settings"
+	"refleks/internal/traces"
 )
 
 // Service coordinates AI streaming requests and emits Wails events for the frontend.
 type Service struct {
-	ctx         context.Context
-	settingsSvc *appsettings.Service
-	mu          sync.Mutex
-	cancels     map[string]context.CancelFunc
+	ctx            context.Context
+	settingsSvc    *appsettings.Service
+	leaderboardSvc *leaderboard.Service
+	tracesSvc      *traces.Service
+	mu             sync.Mutex
+	cancels        map[string]context.CancelFunc
+	conversations  conversationStore
+	insights       *insightCache
+	history        func() []models.ScenarioRecord
+	rankChanges    func(from, to time.Time) []models.BenchmarkRankChange
 }
 
-func NewService(ctx context.Context, settingsSvc *appsettings.Service) *Service {
-	return &Service{ctx: ctx, settingsSvc: settingsSvc, cancels: make(map[string]context.CancelFunc)}
+// payloadBuilder builds the system instruction and the data message of a request and estimates their size.
+type payloadBuilder func(ctx context.Context, input SessionInsightsInput) (system, user string, est models.AIPromptEstimate)
+
+func NewService(ctx context.Context, settingsSvc *appsettings.Service, cacheSvc *cache.Service, leaderboardSvc *leaderboard.Service, tracesSvc *traces.Service) *Service {
+	return &Service{ctx: ctx, settingsSvc: settingsSvc, leaderboardSvc: leaderboardSvc, tracesSvc: tracesSvc, cancels: make(map[string]context.CancelFunc), insights: newInsightCache(cacheSvc)}
+}
+
+// SetHistorySource sets the provider of the full run history used by training block reviews.
+func (s *Service) SetHistorySource(fn func() []models.ScenarioRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = fn
+}
+
+// SetRankChangesSource sets the provider of the user's benchmark rank changes used by training block reviews.
+func (s *Service) SetRankChangesSource(fn func(from, to time.Time) []models.BenchmarkRankChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rankChanges = fn
 }
 
 // NewRequestID returns a unique ID for correlating streams on the frontend.
 func (s *Service) NewRequestID() string { return uuid.NewString() }
 
 // GenerateSessionInsights starts a streaming generation for the given records and options.
+// It starts a new conversation for the session, replacing any stored one. Unless options.Regenerate is set,
+// a cached answer for the same records, model and prompt is replayed instead of generating a new one.
 // It emits events: AI:Session:Start, AI:Session:Delta, AI:Session:Done, AI:Session:Error
 func (s *Service) GenerateSessionInsights(reqID string, sessionID string, records []models.ScenarioRecord, prompt string, options models.AIOptions) {
-	// Resolve API key: env override wins
-	key := appsettings.GetEnv(constants.EnvGeminiAPIKeyVar)
-	if key == "" && s.settingsSvc != nil {
-		key = s.settingsSvc.Get().GeminiAPIKey
+	s.run(reqID, sessionID, records, options, nil, prompt, s.sessionPayload)
+}
+
+// GenerateTrainingReview starts a streaming review of the training between from and to (inclusive dates),
+// built from the run history rather than one session. It is stored and cached like a session under the
+// returned session ID, so follow-ups work the same way, and emits the same events.
+func (s *Service) GenerateTrainingReview(reqID string, from, to time.Time, prompt string, options models.AIOptions) string {
+	sessionID := ReviewSessionID(from, to)
+	history := s.historyRecords()
+	s.run(reqID, sessionID, reviewRecords(history, from, to), options, nil, prompt, s.reviewPayload(from, to, history))
+	return sessionID
+}
+
+// AskFollowUp continues the stored conversation of a session with a question. The session payload is
+// rebuilt from records and sent with as many earlier turns as fit in the history budget.
+// It emits the same events as GenerateSessionInsights.
+func (s *Service) AskFollowUp(reqID string, sessionID string, records []models.ScenarioRecord, question string, options models.AIOptions) error {
+	if strings.TrimSpace(question) == "" {
+		return errors.New("empty question")
+	}
+	conv, err := s.conversations.Get(sessionID)
+	if err != nil {
+		return err
+	}
+	build := s.sessionPayload
+	if from, to, ok := parseReviewSessionID(sessionID); ok {
+		history := s.historyRecords()
+		records = reviewRecords(history, from, to)
+		build = s.reviewPayload(from, to, history)
+	}
+	s.run(reqID, sessionID, records, options, &conv, question, build)
+	return nil
+}
+
+// EstimateSessionInsights builds the prompt GenerateSessionInsights would send and reports its estimated size.
+func (s *Service) EstimateSessionInsights(sessionID string, records []models.ScenarioRecord, prompt string, options models.AIOptions) models.AIPromptEstimate {
+	input := SessionInsightsInput{SessionID: sessionID, Records: records, Options: options, Prompt: prompt, TokenBudget: s.config().TokenBudget()}
+	_, _, est := s.sessionPayload(s.ctx, input)
+	return est
+}
+
+// config returns the provider configuration from the current settings.
+func (s *Service) config() Config {
+	if s.settingsSvc != nil {
+		return ConfigFromSettings(s.settingsSvc.Get())
+	}
+	return ConfigFromSettings(appsettings.Default())
+}
+
+// sessionPayload builds the prompt of a session analysis, with live percentiles and trace metrics.
+func (s *Service) sessionPayload(ctx context.Context, input SessionInsightsInput) (string, string, models.AIPromptEstimate) {
+	input.Percentiles = s.livePercentiles(ctx, input.Records)
+	input.Mouse = s.traceMetrics(input.Records)
+	return BuildSessionPromptEstimate(input)
+}
+
+// reviewPayload returns the builder of a training block review of [from, to].
+func (s *Service) reviewPayload(from, to time.Time, history []models.ScenarioRecord) payloadBuilder {
+	return func(ctx context.Context, input SessionInsightsInput) (string, string, models.AIPromptEstimate) {
+		s.mu.Lock()
+		rankChanges := s.rankChanges
+		s.mu.Unlock()
+		in := ReviewInput{From: from, To: to, History: history, Prompt: input.Prompt}
+		if rankChanges != nil {
+			in.RankChanges = rankChanges(from, to.AddDate(0, 0, 1))
+		}
+		system, user := BuildReviewPrompt(in)
+		return system, user, reviewEstimate(system, user, input.TokenBudget)
+	}
+}
+
+// historyRecords returns the full run history, or nil when no source is set.
+func (s *Service) historyRecords() []models.ScenarioRecord {
+	s.mu.Lock()
+	history := s.history
+	s.mu.Unlock()
+	if history == nil {
+		return nil
 	}
-	if key == "" {
+	return history()
+}
+
+// Conversations lists the stored conversations, most recent first.
+func (s *Service) Conversations() ([]models.AIConversationSummary, error) {
+	return s.conversations.List()
+}
+
+// Conversation returns the stored conversation of a session.
+func (s *Service) Conversation(sessionID string) (models.AIConversation, error) {
+	return s.conversations.Get(sessionID)
+}
+
+// DeleteConversation removes the stored conversation of a session.
+func (s *Service) DeleteConversation(sessionID string) error {
+	return s.conversations.Delete(sessionID)
+}
+
+// run streams one answer and appends the question/answer pair to the session's conversation.
+// conv is nil for an initial analysis, where prompt is the optional user prompt; otherwise prompt is
+// the follow-up question.
+func (s *Service) run(reqID string, sessionID string, records []models.ScenarioRecord, options models.AIOptions, conv *models.AIConversation, prompt string, build payloadBuilder) {
+	cfg := s.config()
+	input := SessionInsightsInput{SessionID: sessionID, Records: records, Options: options, Prompt: prompt, TokenBudget: cfg.TokenBudget()}
+	cacheKey := ""
+	if conv == nil {
+		cacheKey = insightCacheKey(input, cfg.Provider, cfg.ModelName())
+		if e, ok := s.insights.Get(cacheKey, records); ok && !options.Regenerate {
+			s.replayCached(reqID, sessionID, options, cfg, prompt, e)
+			return
+		}
+	}
+	if cfg.Provider == constants.AIProviderGemini && cfg.APIKey == "" {
 		runtime.EventsEmit(s.ctx, constants.EventAISessionError, map[string]any{"requestId": reqID, "error": "Missing Gemini API key. Set it in Settings or REFLEKS_GEMINI_API_KEY."})
 		return
 	}
-	input := SessionInsightsInput{SessionID: sessionID, Records: records, Options: options, Prompt: prompt}
-	system, user := BuildSessionPrompt(input)
-	client, err := NewGeminiClient(s.ctx, key, "")
+	if conv != nil && len(conv.Turns) > 0 && conv.Turns[0].Role == RoleUser {
+		// The payload carries the prompt of the initial analysis
+		input.Prompt = conv.Turns[0].Content
+	}
+	client, err := NewProvider(s.ctx, cfg)
 	if err != nil {
 		runtime.EventsEmit(s.ctx, constants.EventAISessionError, map[string]any{"requestId": reqID, "error": err.Error()})
 		return
 	}
-	runtime.EventsEmit(s.ctx, constants.EventAISessionStart, map[string]any{"requestId": reqID, "sessionId": sessionID})
-	ctx, cancel := context.WithCancel(s.ctx)
-	s.mu.Lock()
-	s.cancels[reqID] = cancel
-	s.mu.Unlock()
+	runtime.EventsEmit(s.ctx, constants.EventAISessionStart, map[string]any{"requestId": reqID, "sessionId": sessionID, "provider": client.Name(), "model": client.Model(), "cached": false})
+	ctx, release := s.cancellable(reqID)
 	go func() {
+		var usage models.AIUsage
 		defer func() {
 			_ = client.Close()
-			s.mu.Lock()
-			delete(s.cancels, reqID)
-			s.mu.Unlock()
-			runtime.EventsEmit(s.ctx, constants.EventAISessionDone, map[string]any{"requestId": reqID, "cached": false})
+			release()
+			runtime.EventsEmit(s.ctx, constants.EventAISessionDone, map[string]any{"requestId": reqID, "cached": false, "usage": usage})
 		}()
-		err := client.StreamSessionInsights(ctx, system, user, func(text string) {
+		budget := input.TokenBudget
+		if conv != nil {
+			// Fit the payload to what the question and earlier turns leave of the budget
+			input.TokenBudget = budget - historyReserve(budget, prompt)
+		}
+		system, user, est := build(ctx, input)
+		req := singleTurn(system, user)
+		if conv != nil {
+			req = conversationRequest(system, user, conv.Turns, prompt, budget-est.Tokens-EstimateTokens(prompt))
+			total := est.SystemTokens
+			for _, m := range req.Messages {
+				total += EstimateTokens(m.Content)
+			}
+			est.HistoryTokens = total - est.SystemTokens - est.DataTokens
+			est.Tokens = total
+			est.Budget = budget
+			est.OverBudget = total > budget
+		}
+		runtime.EventsEmit(s.ctx, constants.EventAISessionPrompt, map[string]any{"requestId": reqID, "estimate": est})
+		var answer strings.Builder
+		var err error
+		usage, err = client.Stream(ctx, req, func(text string) {
+			answer.WriteString(text)
 			runtime.EventsEmit(s.ctx, constants.EventAISessionDelta, map[string]any{"requestId": reqID, "text": text})
 		})
-		if err != nil && err != context.Canceled {
-			msg := err.Error()
-			if strings.Contains(msg, "API key") || strings.Contains(msg, "400") || strings.Contains(msg, "403") {
-				msg = "Failed to connect to Gemini. Please check your API key."
-			}
-			runtime.EventsEmit(s.ctx, constants.EventAISessionError, map[string]any{"requestId": reqID, "error": msg})
+		if err != nil && !errors.Is(err, context.Canceled) {
+			runtime.EventsEmit(s.ctx, constants.EventAISessionError, map[string]any{"requestId": reqID, "error": providerErrorMessage(client, err)})
+			return
+		}
+		// Keep cancelled answers too: the user saw them
+		if answer.Len() > 0 {
+			s.appendTurns(sessionID, options, client.Name(), client.Model(), conv, prompt, answer.String(), usage)
 		}
+		if err == nil && cacheKey != "" && answer.Len() > 0 {
+			s.insights.Put(cacheKey, sessionID, records, answer.String(), usage)
+		}
+	}()
+}
+
+// replayCached emits a cached answer through the usual stream events and restarts the session's conversation with it.
+func (s *Service) replayCached(reqID, sessionID string, options models.AIOptions, cfg Config, prompt string, e insightEntry) {
+	runtime.EventsEmit(s.ctx, constants.EventAISessionStart, map[string]any{"requestId": reqID, "sessionId": sessionID, "provider": cfg.Provider, "model": cfg.ModelName(), "cached": true})
+	go func() {
+		runtime.EventsEmit(s.ctx, constants.EventAISessionDelta, map[string]any{"requestId": reqID, "text": e.Text})
+		s.appendTurns(sessionID, options, cfg.Provider, cfg.ModelName(), nil, prompt, e.Text, e.Usage)
+		runtime.EventsEmit(s.ctx, constants.EventAISessionDone, map[string]any{"requestId": reqID, "cached": true, "usage": e.Usage})
 	}()
 }
 
+// appendTurns stores a question and its answer, starting a new conversation when conv is nil.
+func (s *Service) appendTurns(sessionID string, options models.AIOptions, provider, model string, conv *models.AIConversation, question, answer string, usage models.AIUsage) {
+	now := time.Now().UTC().Format(time.RFC3339)
+	c := models.AIConversation{SessionID: sessionID, CreatedAt: now}
+	if conv != nil {
+		c = *conv
+		// Pick up turns stored since the request started
+		if latest, err := s.conversations.Get(sessionID); err == nil {
+			c = latest
+		}
+	}
+	c.Options = options
+	c.Provider, c.Model = provider, model
+	c.UpdatedAt = now
+	u := usage
+	c.Turns = append(c.Turns,
+		models.AITurn{Role: RoleUser, Content: question, At: now},
+		models.AITurn{Role: RoleAssistant, Content: answer, At: now, Usage: &u},
+	)
+	if err := s.conversations.Save(c); err != nil {
+		runtime.LogWarningf(s.ctx, "ai: save conversation %s: %v", sessionID, err)
+	}
+}
+
+// providerErrorMessage turns a provider error into a message for the UI.
+func providerErrorMessage(p LLMProvider, err error) string {
+	msg := err.Error()
+	switch p.Name() {
+	case constants.AIProviderGemini:
+		if strings.Contains(msg, "API key") || strings.Contains(msg, "400") || strings.Contains(msg, "403") {
+			return "Failed to connect to Gemini. Please check your API key."
+		}
+	case constants.AIProviderOpenAI:
+		if strings.Contains(msg, "HTTP 401") || strings.Contains(msg, "HTTP 403") {
+			return "The AI endpoint rejected the request. Please check your API key."
+		}
+	case constants.AIProviderOllama:
+		if strings.Contains(msg, "connection refused") {
+			return "Failed to connect to Ollama. Is it running?"
+		}
+	}
+	if strings.Contains(msg, "HTTP 404") {
+		return fmt.Sprintf("Model %q or endpoint not found: %s", p.Model(), msg)
+	}
+	return msg
+}
+
+// cancellable returns a context cancelled by Cancel(reqID) and the func that unregisters it (and
+// releases the context) once the request is over.
+func (s *Service) cancellable(reqID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.mu.Lock()
+	s.cancels[reqID] = cancel
+	s.mu.Unlock()
+	return ctx, func() {
+		s.mu.Lock()
+		delete(s.cancels, reqID)
+		s.mu.Unlock()
+		cancel()
+	}
+}
+
 // Cancel cancels an in-flight request by ID.
 func (s *Service) Cancel(reqID string) {
 	s.mu.Lock()
@@ -82,3 +315,53 @@ func (s *Service) Cancel(reqID string) {
 		delete(s.cancels, reqID)
 	}
 }
+
+// livePercentiles resolves leaderboard percentiles for the played scenarios within a short deadline.
+// Scenarios that can't be resolved in time (or offline) fall back to the static library values.
+func (s *Service) livePercentiles(ctx context.Context, records []models.ScenarioRecord) map[string]map[string]float64 {
+	if s.leaderboardSvc == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(constants.AIPercentileLookupTimeoutSeconds)*time.Second)
+	defer cancel()
+	out := map[string]map[string]float64{}
+	seen := map[string]struct{}{}
+	for _, r := range records {
+		name := safeScenarioName(r)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if ctx.Err() != nil {
+			// Out of time: use whatever is already cached
+			if p, ok := s.leaderboardSvc.CachedPercentiles(name); ok && len(p) > 0 {
+				out[name] = p
+			}
+			continue
+		}
+		if p, err := s.leaderboardSvc.GetPercentilesContext(ctx, name); err == nil && len(p) > 0 {
+			out[name] = p
+		}
+	}
+	return out
+}
+
+// traceMetrics loads the stored mouse metrics of the records that have a trace.
+func (s *Service) traceMetrics(records []models.ScenarioRecord) map[string]models.TraceMetrics {
+	if s.tracesSvc == nil {
+		return nil
+	}
+	out := map[string]models.TraceMetrics{}
+	for _, r := range records {
+		if !r.HasTrace {
+			continue
+		}
+		if m, err := s.tracesSvc.Metrics(r.FileName); err == nil {
+			out[r.FileName] = m
+		}
+	}
+	return out
+}
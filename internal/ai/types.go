@@ -19,6 +19,13 @@ type SessionInsightsInput struct {
 	Records   []models.ScenarioRecord `json:"records"`
 	Options   models.AIOptions        `json:"options"`
 	Prompt    string                  `json:"prompt"`
+	// Percentiles holds live leaderboard score thresholds per scenario name.
+	// They take precedence over the static ScenarioMeta.Percentiles.
+	Percentiles map[string]map[string]float64 `json:"percentiles,omitempty"`
+	// Mouse holds stored trace metrics keyed by record FileName (runs without a trace are absent).
+	Mouse map[string]models.TraceMetrics `json:"mouse,omitempty"`
+	// TokenBudget caps the estimated prompt size (0 = AIDefaultPromptTokenBudget).
+	TokenBudget int `json:"tokenBudget,omitempty"`
 }
 
 // Delta is a partial text chunk streamed from the model.
@@ -29,8 +36,9 @@ type Delta struct {
 
 // Done indicates stream completion and optional usage metadata.
 type Done struct {
-	RequestID string `json:"requestId"`
-	Cached    bool   `json:"cached"`
+	RequestID string         `json:"requestId"`
+	Cached    bool           `json:"cached"`
+	Usage     models.AIUsage `json:"usage"`
 }
 
 // FingerprintSession makes a short hash for caching based on records and options length caps.
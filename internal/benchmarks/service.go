@@ -1,13 +1,12 @@
 package benchmarks
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -15,6 +14,8 @@ import (
 	"refleks/internal/benchmarks/rankcalc"
 	"refleks/internal/cache"
 	"refleks/internal/constants"
+	"refleks/internal/freshness"
+	"refleks/internal/kovaaks"
 	"refleks/internal/models"
 	"refleks/internal/settings"
 	"refleks/internal/steam"
@@ -24,8 +25,12 @@ import (
 //go:embed benchmarks_data.json
 var embeddedBenchmarks []byte
 
+// progressTTL is how long fetched progress is considered current.
+const progressTTL = time.Duration(constants.BenchmarkProgressTTLMinutes) * time.Minute
+
 // Service manages benchmark data and progress tracking.
 type Service struct {
+	ctx               context.Context
 	mu                sync.Mutex
 	progressCache     map[int]models.BenchmarkProgress
 	scenarioIndex     map[string][]int
@@ -35,15 +40,18 @@ type Service struct {
 	onProgressUpdated func(int, models.BenchmarkProgress)
 	settingsSvc       *settings.Service
 	cacheSvc          *cache.Service
+	api               kovaaks.API
 }
 
 // NewService creates a new benchmark service.
-func NewService(settingsSvc *settings.Service, cacheSvc *cache.Service) *Service {
+func NewService(ctx context.Context, settingsSvc *settings.Service, cacheSvc *cache.Service, api kovaaks.API) *Service {
 	s := &Service{
+		ctx:           ctx,
 		progressCache: make(map[int]models.BenchmarkProgress),
 		scenarioIndex: make(map[string][]int),
 		settingsSvc:   settingsSvc,
 		cacheSvc:      cacheSvc,
+		api:           api,
 	}
 	// Register cache clear callback
 	cacheSvc.RegisterOnClear(func() {
@@ -52,6 +60,13 @@ func NewService(settingsSvc *settings.Service, cacheSvc *cache.Service) *Service
 		s.progressCache = make(map[int]models.BenchmarkProgress)
 		s.scenarioIndex = make(map[string][]int)
 	})
+	// Profile switch: the progress cache is reloaded lazily from the new cache directory
+	cacheSvc.RegisterOnReload(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.progressCache = make(map[int]models.BenchmarkProgress)
+		s.scenarioIndex = make(map[string][]int)
+	})
 	return s
 }
 
@@ -78,6 +93,10 @@ func (s *Service) GetBenchmarks() ([]models.Benchmark, error) {
 }
 
 // GetBenchmarkProgress returns progress for a specific benchmark.
+// With useCache, cached data is returned as-is (annotated with its freshness). Without it,
+// the API is queried; if that fails and cached data exists, the cached data is returned
+// marked stale together with the refresh error, so the caller can still render the last
+// known ranks. The returned bool reports whether the result came from cache.
 func (s *Service) GetBenchmarkProgress(benchmarkId int, useCache bool) (models.BenchmarkProgress, bool, error) {
 	if useCache {
 		if p, ok := s.GetCachedBenchmarkProgress(benchmarkId); ok {
@@ -87,12 +106,16 @@ func (s *Service) GetBenchmarkProgress(benchmarkId int, useCache bool) (models.B
 
 	raw, err := s.GetPlayerProgressRaw(benchmarkId)
 	if err != nil {
+		if p, ok := s.recordRefreshError(benchmarkId, err); ok {
+			return p, true, err
+		}
 		return models.BenchmarkProgress{}, false, err
 	}
 	prog, err := s.buildStructuredProgress(raw, benchmarkId)
 	if err != nil {
 		return models.BenchmarkProgress{}, false, err
 	}
+	prog.Freshness = freshness.Live()
 
 	// Update cache asynchronously
 	go func(bid int, p models.BenchmarkProgress) {
@@ -118,6 +141,56 @@ func (s *Service) GetBenchmarkProgress(benchmarkId int, useCache bool) (models.B
 	return prog, false, nil
 }
 
+// recordRefreshError stores the failed refresh on the cached entry (if any) and
+// returns the cached progress annotated as stale. The cache file is only rewritten
+// when the error changed, so repeated failures while offline don't touch the disk.
+func (s *Service) recordRefreshError(benchmarkId int, err error) (models.BenchmarkProgress, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.progressCache) == 0 {
+		_, _ = s.loadCacheLocked()
+	}
+	p, ok := s.progressCache[benchmarkId]
+	if !ok {
+		return models.BenchmarkProgress{}, false
+	}
+	if freshness.ErrorChanged(p.Freshness, err) {
+		p.Freshness = freshness.WithError(p.Freshness, err)
+		s.progressCache[benchmarkId] = p
+		_ = s.saveCacheLocked()
+	}
+
+	p.Freshness = freshness.FromCache(p.Freshness, progressTTL)
+	return p, true
+}
+
+// RevalidateStale refreshes every cached benchmark whose data is stale and returns
+// how many were refreshed live. Intended to be registered with a freshness.Scheduler.
+func (s *Service) RevalidateStale(ctx context.Context) int {
+	s.mu.Lock()
+	if len(s.progressCache) == 0 {
+		_, _ = s.loadCacheLocked()
+	}
+	var stale []int
+	for id, p := range s.progressCache {
+		if freshness.IsStale(p.Freshness, progressTTL) {
+			stale = append(stale, id)
+		}
+	}
+	s.mu.Unlock()
+
+	refreshed := 0
+	for _, id := range stale {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, cached, err := s.GetBenchmarkProgress(id, false); err == nil && !cached {
+			refreshed++
+		}
+	}
+	return refreshed
+}
+
 // GetAllBenchmarkProgresses returns progress for all benchmarks.
 func (s *Service) GetAllBenchmarkProgresses() (map[int]models.BenchmarkProgress, error) {
 	list, err := s.GetBenchmarks()
@@ -157,6 +230,7 @@ func (s *Service) GetAllBenchmarkProgresses() (map[int]models.BenchmarkProgress,
 
 	for id := range validIDs {
 		if p, ok := s.progressCache[id]; ok {
+			p.Freshness = freshness.FromCache(p.Freshness, progressTTL)
 			result[id] = p
 		} else {
 			missingIDs = append(missingIDs, id)
@@ -165,16 +239,14 @@ func (s *Service) GetAllBenchmarkProgresses() (map[int]models.BenchmarkProgress,
 	s.mu.Unlock()
 
 	if len(missingIDs) > 0 {
+		// Fetch the missing progresses concurrently
 		var mu sync.Mutex
 		var wg sync.WaitGroup
-		sem := make(chan struct{}, 3)
 
 		for _, id := range missingIDs {
 			wg.Add(1)
 			go func(bid int) {
 				defer wg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
 
 				p, _, err := s.GetBenchmarkProgress(bid, false)
 				if err == nil {
@@ -207,17 +279,15 @@ func (s *Service) RefreshAllBenchmarkProgresses() (map[int]models.BenchmarkProgr
 	results := make(map[int]models.BenchmarkProgress)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, 3)
 
 	for id := range uniqueIDs {
 		wg.Add(1)
 		go func(bid int) {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
 
-			prog, _, err := s.GetBenchmarkProgress(bid, false)
-			if err == nil {
+			// A failed refresh still returns the stale cached progress
+			prog, cached, err := s.GetBenchmarkProgress(bid, false)
+			if err == nil || cached {
 				mu.Lock()
 				results[bid] = prog
 				mu.Unlock()
@@ -300,34 +370,40 @@ func (s *Service) GetCachedBenchmarkProgress(benchmarkId int) (models.BenchmarkP
 		_, _ = s.loadCacheLocked()
 	}
 	p, ok := s.progressCache[benchmarkId]
+	if ok {
+		p.Freshness = freshness.FromCache(p.Freshness, progressTTL)
+	}
 	return p, ok
 }
 
 // Internal helpers
 
+// GetPlayerProgressRaw fetches the raw progress JSON for the current Steam user.
 func (s *Service) GetPlayerProgressRaw(benchmarkId int) (string, error) {
 	steamID := steam.GetSteamID(s.settingsSvc.Get())
 	if steamID == "" {
 		return "", errors.New("steam ID not found")
 	}
-	url := fmt.Sprintf(constants.KovaaksPlayerProgressURL, benchmarkId, steamID)
-
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(url)
+	b, err := s.api.PlayerProgress(s.ctx, benchmarkId, steamID)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch player progress: %w", err)
 	}
-	defer resp.Body.Close()
+	return string(b), nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status %d from progress endpoint", resp.StatusCode)
+// GetProgressForSteamID fetches benchmark progress for any player (e.g. a teammate) using the
+// same endpoint and parsing as the local user's progress. The result is not cached here.
+func (s *Service) GetProgressForSteamID(ctx context.Context, benchmarkId int, steamID string) (models.BenchmarkProgress, error) {
+	b, err := s.api.PlayerProgress(ctx, benchmarkId, steamID)
+	if err != nil {
+		return models.BenchmarkProgress{}, fmt.Errorf("failed to fetch player progress: %w", err)
 	}
-
-	b, err := io.ReadAll(resp.Body)
+	prog, err := s.buildStructuredProgress(string(b), benchmarkId)
 	if err != nil {
-		return "", fmt.Errorf("failed to read progress response: %w", err)
+		return models.BenchmarkProgress{}, err
 	}
-	return string(b), nil
+	prog.Freshness = freshness.Live()
+	return prog, nil
 }
 
 func (s *Service) rebuildScenarioIndexLocked() {
@@ -395,6 +471,15 @@ func (s *Service) buildStructuredProgress(raw string, benchmarkId int) (models.B
 	return out, nil
 }
 
+// BenchmarkName returns the display name of a benchmark difficulty ("Voltaic S5 Novice"), or "" if unknown.
+func (s *Service) BenchmarkName(benchmarkId int) string {
+	b, d := s.findDifficultyByBenchmarkID(benchmarkId)
+	if b == nil || d == nil {
+		return ""
+	}
+	return strings.TrimSpace(b.BenchmarkName + " " + d.DifficultyName)
+}
+
 func (s *Service) findDifficultyByBenchmarkID(benchmarkId int) (*models.Benchmark, *models.BenchmarkDifficulty) {
 	list, err := s.GetBenchmarks()
 	if err != nil {
@@ -9,10 +9,11 @@ import (
 	"refleks/internal/settings"
 )
 
-// Service manages application cache.
+// Service manages application cache. Cache files live in the active profile's data directory.
 type Service struct {
-	mu      sync.Mutex
-	onClear []func()
+	mu       sync.Mutex
+	onClear  []func()
+	onReload []func()
 }
 
 // NewService creates a new cache service.
@@ -27,12 +28,29 @@ func (s *Service) RegisterOnClear(fn func()) {
 	s.onClear = append(s.onClear, fn)
 }
 
+// RegisterOnReload registers a callback to be run when the cache directory changes (profile switch).
+// Callbacks should drop in-memory state so it is lazily reloaded from the new directory.
+func (s *Service) RegisterOnReload(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onReload = append(s.onReload, fn)
+}
+
+// Reload triggers the registered reload callbacks without touching any file.
+func (s *Service) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, fn := range s.onReload {
+		fn()
+	}
+}
+
 // ClearAll clears the cache directory and triggers registered callbacks.
 func (s *Service) ClearAll() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	dir, err := settings.GetConfigDir()
+	dir, err := settings.GetDataDir()
 	if err != nil {
 		return err
 	}
@@ -56,7 +74,7 @@ func (s *Service) ClearAll() error {
 
 // Save writes the given data to a JSON file in the cache directory.
 func (s *Service) Save(filename string, data any) error {
-	dir, err := settings.GetConfigDir()
+	dir, err := settings.GetDataDir()
 	if err != nil {
 		return err
 	}
@@ -76,7 +94,7 @@ func (s *Service) Save(filename string, data any) error {
 
 // Load reads data from a JSON file in the cache directory.
 func (s *Service) Load(filename string, dest any) error {
-	dir, err := settings.GetConfigDir()
+	dir, err := settings.GetDataDir()
 	if err != nil {
 		return err
 	}
@@ -93,7 +111,7 @@ func (s *Service) Load(filename string, dest any) error {
 
 // Exists checks if a cache file exists.
 func (s *Service) Exists(filename string) bool {
-	dir, err := settings.GetConfigDir()
+	dir, err := settings.GetDataDir()
 	if err != nil {
 		return false
 	}
@@ -4,5 +4,65 @@ package constants
 const (
 	AIDefaultModel              = "gemini-2.5-flash-lite"
 	AISessionAnalystPersona     = "session-analyst"
+	AITrainingReviewPersona     = "training-reviewer"
+	AITrainingPlannerPersona    = "training-planner"
 	AIDefaultMaxRunsPerScenario = 12
+	// Upper bound on live leaderboard percentile lookups before a prompt is sent
+	AIPercentileLookupTimeoutSeconds = 5
+
+	// Supported AI providers (Settings.AI.Provider)
+	AIProviderGemini  = "gemini"
+	AIProviderOpenAI  = "openai" // any OpenAI-compatible chat completions endpoint
+	AIProviderOllama  = "ollama"
+	AIDefaultProvider = AIProviderGemini
+
+	// Defaults for local backends when no base URL / model is configured
+	AIDefaultOpenAIBaseURL = "https://api.openai.com/v1"
+	AIDefaultOpenAIModel   = "gpt-4o-mini"
+	AIDefaultOllamaBaseURL = "http://localhost:11434"
+	AIDefaultOllamaModel   = "llama3.1"
+
+	// Generation config shared by all providers (conservative, analysis-oriented)
+	AITemperature     = 0.2
+	AITopP            = 0.95
+	AIMaxOutputTokens = 2048
+
+	// Estimated tokens of the system prompt + session data when Settings.AI.PromptTokenBudget is unset
+	AIDefaultPromptTokenBudget = 12000
+	// Smallest accepted prompt budget (the system prompt alone is ~1k tokens)
+	AIMinPromptTokenBudget = 3000
+	// Unplayed library scenarios offered as drill candidates in a session payload
+	AIPromptLibraryMaxEntries = 30
+
+	// Estimated tokens of earlier conversation turns sent with a follow-up; older turns are dropped first
+	AIHistoryTokenBudget = 6000
+	// Share of the prompt budget (percent) a follow-up reserves for earlier turns, at most AIHistoryTokenBudget
+	AIHistoryBudgetPct = 30
+	// Length of AIConversationSummary.Preview in characters
+	AIConversationPreviewChars = 160
+
+	// Cached insights are replayed for this long unless the session's records change
+	AIInsightCacheTTLHours = 72
+	// Upper bound on cached insights; the oldest are evicted first
+	AIInsightCacheMaxEntries = 200
+
+	// Structured training plans
+	// Attempts at a valid plan (the first answer plus repairs)
+	AIPlanMaxAttempts = 3
+
+	// Training block reviews
+	// Length of the reviewed period when no start date is given
+	AIReviewDefaultDays = 28
+	// Most played scenarios included in a review payload
+	AIReviewMaxScenarios = 25
+	// Periods longer than this report volume per week instead of per day
+	AIReviewDailyVolumeMaxDays = 42
+	// A scenario has plateaued when it has at least this many runs in the period, was played in the
+	// last AIReviewPlateauDays days without a PB in them, and its score trend is flatter than
+	// AIReviewPlateauSlopePct percent per week
+	AIReviewPlateauMinRuns  = 8
+	AIReviewPlateauDays     = 14
+	AIReviewPlateauSlopePct = 1.0
+	// Day-to-day change of the median cm/360 reported as a sensitivity change, in percent
+	AIReviewSensChangePct = 2.0
 )
@@ -22,12 +22,119 @@ const (
 	KovaaksProcessName = "FPSAimTrainer.exe"
 	KovaaksSteamAppID  = 824270
 
+	// Kovaak's API client defaults
+	// KovaaksHTTPTimeoutSeconds bounds a single request attempt (retries get their own timeout).
+	KovaaksHTTPTimeoutSeconds = 10
+	// KovaaksMaxRetries is how many times a request is retried on 429/5xx or transient network errors.
+	KovaaksMaxRetries = 3
+	// KovaaksBackoffBaseMillis is the initial retry delay; it doubles on every attempt.
+	KovaaksBackoffBaseMillis = 500
+	// KovaaksBackoffMaxMillis caps a single retry delay.
+	KovaaksBackoffMaxMillis = 8000
+	// KovaaksRequestsPerSecond and KovaaksBurst configure the global token-bucket limiter
+	// shared by every Kovaak's API call in the process.
+	KovaaksRequestsPerSecond = 4
+	KovaaksBurst             = 4
+
+	// Leaderboard paging defaults (the API caps page size at 100)
+	DefaultLeaderboardPageSize = 50
+	MaxLeaderboardPageSize     = 100
+	// LeaderboardMaxCachedPages caps the in-memory leaderboard page cache
+	LeaderboardMaxCachedPages = 500
+
+	// Team comparison
+	// DefaultImprovementWindowDays is the look-back window of the "most improved" summary.
+	DefaultImprovementWindowDays = 7
+	// TeamSnapshotRetentionDays bounds how long daily progress snapshots are kept.
+	TeamSnapshotRetentionDays = 90
+
+	// Remote run sync
+	// RemoteSyncMaxScenarios bounds how many recently played scenarios are checked per sync.
+	RemoteSyncMaxScenarios = 30
+	// RemoteMatchToleranceSeconds is how far apart a local and a remote run may end and still match.
+	RemoteMatchToleranceSeconds = 180
+	// DefaultScenarioLengthSeconds is assumed when a remote run's scenario length is unknown.
+	DefaultScenarioLengthSeconds = 60
+	// RecordSourceRemote marks ScenarioRecords synced from Kovaak's (ScenarioRecord.Source).
+	RecordSourceRemote = "remote"
+
+	// Trace retention
+	// TracePruneIntervalHours is how often the background pruner applies the retention policy.
+	TracePruneIntervalHours = 6
+
+	// Remote data freshness
+	// Cached data older than its TTL is reported as stale and refreshed by the revalidation scheduler.
+	BenchmarkProgressTTLMinutes = 30
+	LastScoresTTLMinutes        = 5
+	LeaderboardTTLMinutes       = 60
+	// RevalidateIntervalMinutes is the base period of the background revalidation scheduler;
+	// each tick is offset by up to RevalidateJitterSeconds so clients don't sync up.
+	RevalidateIntervalMinutes = 10
+	RevalidateJitterSeconds   = 90
+
+	// Data source labels used in Freshness.Source
+	DataSourceLive  = "live"
+	DataSourceCache = "cache"
+
 	// Updater default timeouts (in seconds)
 	// UpdaterHTTPTimeoutSeconds is used for quick API calls (e.g., GitHub latest release). Keep small.
 	UpdaterHTTPTimeoutSeconds = 10
 	// UpdaterDownloadTimeoutSeconds is used for downloading installer assets. Larger to accommodate slow links.
 	UpdaterDownloadTimeoutSeconds = 600
 
+	// --- Mouse trace analysis ---
+	// TraceAnalysisVersion is bumped whenever the analysis changes so stored metrics are recomputed.
+	TraceAnalysisVersion = 1
+	// TraceMaxGapMs splits the trace where samples are further apart (tracker paused or dropped).
+	TraceMaxGapMs = 250
+	// TraceProfileBinMs is the bucket size of the stored speed profile.
+	TraceProfileBinMs = 100
+	// TraceMinMoveSpeed is the lowest speed (counts/s) considered movement rather than jitter;
+	// the effective threshold also scales with the run's speed (TraceMoveSpeedRatio of its p90).
+	TraceMinMoveSpeed   = 50.0
+	TraceMoveSpeedRatio = 0.1
+	// TraceSettleMs is how long the cursor must stay below the movement threshold to count as at rest.
+	TraceSettleMs = 150
+	// TraceFlickMinAmplitude and TraceFlickMinPeakRatio (of the run's p90 speed) filter small
+	// adjustments out of the flick list.
+	TraceFlickMinAmplitude = 20.0
+	TraceFlickMinPeakRatio = 0.5
+	// TracePrimaryEndRatio ends the primary submovement at the first slowdown below this share of peak speed.
+	TracePrimaryEndRatio = 0.3
+	// TraceCorrectionRatio is the share of peak speed a re-acceleration must reach to count as a correction.
+	TraceCorrectionRatio = 0.15
+	// TraceOvershootMinRatio is the share of the amplitude an overshoot must exceed to count in OvershootRate.
+	TraceOvershootMinRatio = 0.05
+
+	// --- Training plans ---
+	PlanMaxItems         = 12
+	PlanMaxRunsPerItem   = 20
+	PlanMaxTargetMinutes = 600
+	// PlanMaxStoredRuns caps the matched runs kept per plan; the oldest are dropped first.
+	PlanMaxStoredRuns = 5000
+	// PlanAdherenceMaxPeriods caps the period history returned with adherence stats.
+	PlanAdherenceMaxPeriods = 60
+
+	// --- Goals ---
+	// GoalForecastDays is the window of recent runs the forecast regression is fitted on.
+	GoalForecastDays = 42
+	// GoalForecastMinDays is the number of days with runs the window needs for a forecast.
+	GoalForecastMinDays = 3
+	// GoalForecastMaxDays drops forecasts further out than this (treated as not improving).
+	GoalForecastMaxDays = 730
+	// GoalEvaluateDebounceMs delays re-evaluation after parsed runs so a scan is evaluated once.
+	GoalEvaluateDebounceMs = 2000
+	// GoalEvaluateIntervalMinutes is how often goals are re-evaluated without new runs, so deadlines
+	// passing and playtime falling behind are noticed while the user isn't playing.
+	GoalEvaluateIntervalMinutes = 60
+
+	// --- Activity stats ---
+	// StatsConsistencyMinRuns is the number of runs a week needs for its coefficient of variation.
+	StatsConsistencyMinRuns = 3
+	// StatsConsistencyMaxScenarios and StatsConsistencyMaxWeeks cap the consistency table.
+	StatsConsistencyMaxScenarios = 30
+	StatsConsistencyMaxWeeks     = 26
+
 	// --- Sensitivity conversion defaults ---
 	// Default yaw (deg/count) constants for supported game scales. These are used
 	// by the sensitivity converter to derive cm/360 for linear engines where
@@ -9,14 +9,32 @@ const (
 	EventBenchmarkProgressUpdated = "benchmark:progress:updated"
 	EventBenchmarkProgressPrefix  = "benchmark:progress:" // + benchmarkId
 
+	// Connectivity/cache events
+	EventConnectivityChanged = "connectivity:changed"
+	EventCacheRevalidated    = "cache:revalidated"
+
 	// Watcher/Scenario events
 	EventWatcherStarted  = "watcher:started"
 	EventScenarioAdded   = "scenario:added"
 	EventScenarioUpdated = "scenario:updated"
+	EventTracesPruned    = "traces:pruned"
+
+	// Profile events
+	EventProfileChanged = "profile:changed"
+
+	// Plan events
+	EventPlanUpdated = "plan:updated"
+
+	// Goal events
+	EventGoalsUpdated = "goals:updated"
+	EventGoalAchieved = "goal:achieved"
+	EventGoalOffPace  = "goal:off-pace"
 
 	// AI events
 	EventAISessionStart = "ai:session:start"
 	EventAISessionDelta = "ai:session:delta"
-	EventAISessionDone  = "ai:session:done"
-	EventAISessionError = "ai:session:error"
+	// Emitted with the estimated prompt size right before a request is sent
+	EventAISessionPrompt = "ai:session:prompt"
+	EventAISessionDone   = "ai:session:done"
+	EventAISessionError  = "ai:session:error"
 )
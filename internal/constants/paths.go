@@ -5,6 +5,19 @@ const (
 	// Name of the app config folder in the user's home directory
 	ConfigDirName    = ".refleks"
 	TracesSubdirName = "traces"
+	// Per-profile data folders ($HOME/.refleks/profiles/<steamID>); the default profile uses the config folder itself
+	ProfilesSubdirName = "profiles"
+	DefaultProfileID   = "default"
+	// Default destination of trace exports (a timestamped folder is created per export)
+	ExportsSubdirName = "exports"
+	// Training plans of the profile, in the profile data directory
+	PlansFileName = "plans.json"
+	// Goals of the profile, in the profile data directory (next to the settings file)
+	GoalsFileName = "goals.json"
+	// Kovaak's playlists folder, relative to the parent of the stats directory
+	KovaaksPlaylistsRelDir = "Saved/SaveGames/Playlists"
+	// Persisted AI conversations, one JSON file per session, in the profile data directory
+	AIConversationsSubdirName = "conversations"
 
 	// Default Kovaak's stats directory on Windows
 	DefaultWindowsKovaaksStatsDir = `C:\\Program Files (x86)\\Steam\\steamapps\\common\\FPSAimTrainer\\FPSAimTrainer\\stats`
@@ -12,6 +25,18 @@ const (
 	// Default Steam install directory (used to locate config/loginusers.vdf)
 	DefaultWindowsSteamInstallDir = `C:\\Program Files (x86)\\Steam`
 
+	// Kovaak's stats directory relative to a Steam library root
+	KovaaksStatsRelDir = "steamapps/common/FPSAimTrainer/FPSAimTrainer/stats"
+	// Kovaak's stats directory relative to its install directory
+	KovaaksStatsSubdir = "FPSAimTrainer/stats"
+	// Steam library list, relative to the Steam install directory
+	SteamLibraryFoldersFile = "steamapps/libraryfolders.vdf"
+
+	// Steam install locations on Linux, relative to the home directory, in lookup order
+	LinuxSteamRootDir    = ".steam/steam"
+	LinuxSteamDataDir    = ".local/share/Steam"
+	LinuxFlatpakSteamDir = ".var/app/com.valvesoftware.Steam/.local/share/Steam"
+
 	// Environment variable names
 	// If set, this overrides SteamID detection from loginusers.vdf
 	EnvSteamIDVar = "REFLEKS_STEAM_ID"
@@ -21,12 +46,32 @@ const (
 	EnvStatsDirVar = "REFLEKS_STATS_DIR"
 	// If set, this overrides the stored Gemini API key for AI insights
 	EnvGeminiAPIKeyVar = "REFLEKS_GEMINI_API_KEY"
+	// If set, this overrides the stored API key of the OpenAI-compatible AI provider
+	EnvOpenAIAPIKeyVar = "REFLEKS_OPENAI_API_KEY"
+	// If set, the Linux mouse tracker reads these comma-separated /dev/input/event* devices instead of auto-detecting
+	EnvMouseDeviceVar = "REFLEKS_MOUSE_DEVICE"
+	// If set, mouse traces are replayed from this file (.trace or .csv) instead of captured from the device
+	EnvMouseReplayVar = "REFLEKS_MOUSE_REPLAY"
+	// Playback speed of EnvMouseReplayVar (1 = real time, 0 = whole trace available at once)
+	EnvMouseReplaySpeedVar = "REFLEKS_MOUSE_REPLAY_SPEED"
 
 	// Conventional, explicit filename for release assets. Keep in sync with build/windows/installer/project.nsi
 	// Result example: "refleks-0.3.0-windows-amd64-installer.exe"
 	WindowsInstallerNameFmt = "refleks-%s-windows-amd64-installer.exe"
 
 	// Cache file names
-	BenchmarksCacheFileName = "benchmarks.json"
-	SettingsFileName        = "settings.json"
+	BenchmarksCacheFileName  = "benchmarks.json"
+	LastScoresCacheFileName  = "last_scores.json"
+	LeaderboardCacheFileName = "leaderboards.json"
+	TeamCacheFileName        = "team.json"
+	RemoteRunsCacheFileName  = "remote_runs.json"
+	AIInsightsCacheFileName  = "ai_insights.json"
+	SettingsFileName         = "settings.json"
+
+	// Machine-wide profile state, kept in the config folder: the active profile and which profile played each run
+	ProfilesFileName  = "profiles.json"
+	RunOwnersFileName = "run_owners.json"
+
+	// TraceIndexFileName is the index of stored traces, kept inside the traces directory.
+	TraceIndexFileName = "index.json"
 )
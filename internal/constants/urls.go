@@ -1,11 +1,23 @@
 package constants
 
 const (
+	// Kovaaks webapp backend base URL. Endpoint paths below are relative to it.
+	KovaaksAPIBaseURL = "https://kovaaks.com/webapp-backend"
+
 	// Kovaaks player progress endpoint. Use fmt.Sprintf with benchmarkId and steamId.
-	KovaaksPlayerProgressURL = "https://kovaaks.com/webapp-backend/benchmarks/player-progress-rank-benchmark?benchmarkId=%d&steamId=%s"
+	KovaaksPlayerProgressPath = "/benchmarks/player-progress-rank-benchmark?benchmarkId=%d&steamId=%s"
 
 	// Kovaaks last scores endpoint. Use fmt.Sprintf with username and scenarioName.
-	KovaaksLastScoresURL = "https://kovaaks.com/webapp-backend/user/scenario/last-scores/by-name?username=%s&scenarioName=%s"
+	KovaaksLastScoresPath = "/user/scenario/last-scores/by-name?username=%s&scenarioName=%s"
+
+	// Kovaaks scenario search endpoint (resolves a scenario name to its leaderboardId). Use fmt.Sprintf with scenarioName.
+	KovaaksScenarioSearchPath = "/scenario/popular?page=0&max=20&scenarioNameSearch=%s"
+
+	// Kovaaks global leaderboard endpoint. Use fmt.Sprintf with leaderboardId, page, max and usernameSearch (may be empty).
+	KovaaksLeaderboardPath = "/leaderboard/scores/global?leaderboardId=%d&page=%d&max=%d&usernameSearch=%s"
+
+	// Kovaaks user search endpoint (resolves a webapp username/persona name to a Steam ID). Use fmt.Sprintf with username.
+	KovaaksUserSearchPath = "/user/search?username=%s"
 
 	// --- Updater/GitHub release info ---
 	// GitHub repository owner/name used for update checks and downloads
@@ -0,0 +1,53 @@
+package freshness
+
+import (
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// Live returns freshness metadata for data fetched from the API just now.
+func Live() *models.Freshness {
+	return &models.Freshness{FetchedAt: time.Now(), Source: constants.DataSourceLive}
+}
+
+// FromCache returns a copy of f describing the same data served from cache.
+// Data without a fetch time (legacy cache entries) or older than ttl is stale,
+// as is data whose last refresh failed.
+func FromCache(f *models.Freshness, ttl time.Duration) *models.Freshness {
+	out := models.Freshness{Source: constants.DataSourceCache}
+	if f != nil {
+		out.FetchedAt = f.FetchedAt
+		out.LastError = f.LastError
+	}
+	out.Stale = IsStale(&out, ttl)
+	return &out
+}
+
+// WithError returns a copy of f recording a failed refresh. The data is marked stale.
+func WithError(f *models.Freshness, err error) *models.Freshness {
+	out := models.Freshness{Source: constants.DataSourceCache}
+	if f != nil {
+		out = *f
+	}
+	if err != nil {
+		out.LastError = err.Error()
+	}
+	out.Stale = true
+	return &out
+}
+
+// ErrorChanged reports whether recording err on f would change its LastError. Caches use it to
+// skip persisting repeated failures (e.g. every revalidation tick while offline).
+func ErrorChanged(f *models.Freshness, err error) bool {
+	return f == nil || err == nil || f.LastError != err.Error()
+}
+
+// IsStale reports whether data described by f should be refreshed.
+func IsStale(f *models.Freshness, ttl time.Duration) bool {
+	if f == nil || f.FetchedAt.IsZero() || f.LastError != "" {
+		return true
+	}
+	return time.Since(f.FetchedAt) > ttl
+}
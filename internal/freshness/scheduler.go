@@ -0,0 +1,109 @@
+package freshness
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Task revalidates one kind of cached remote data and returns how many entries it refreshed.
+type Task func(ctx context.Context) int
+
+// Scheduler periodically runs registered revalidation tasks with a jittered interval.
+// It can also be triggered on demand (e.g. when connectivity returns).
+type Scheduler struct {
+	interval time.Duration
+	jitter   time.Duration
+
+	mu      sync.Mutex
+	tasks   []Task
+	trigger chan struct{}
+	onRun   func(refreshed int, triggered bool)
+}
+
+// NewScheduler creates a scheduler running every interval ± up to jitter.
+func NewScheduler(interval, jitter time.Duration) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		jitter:   jitter,
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+// Register adds a revalidation task. Tasks run sequentially in registration order.
+func (s *Scheduler) Register(t Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks = append(s.tasks, t)
+}
+
+// SetOnRun sets a callback invoked after each run with the total number of refreshed
+// entries and whether the run was triggered explicitly (vs. a timer tick).
+func (s *Scheduler) SetOnRun(fn func(refreshed int, triggered bool)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRun = fn
+}
+
+// Trigger requests an immediate run. Multiple triggers before the run coalesce.
+func (s *Scheduler) Trigger() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the scheduling loop until ctx is cancelled. Call it in its own goroutine.
+func (s *Scheduler) Start(ctx context.Context) {
+	timer := time.NewTimer(s.nextDelay())
+	defer timer.Stop()
+	for {
+		triggered := false
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-s.trigger:
+			triggered = true
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+		s.runOnce(ctx, triggered)
+		timer.Reset(s.nextDelay())
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, triggered bool) {
+	s.mu.Lock()
+	tasks := append([]Task(nil), s.tasks...)
+	onRun := s.onRun
+	s.mu.Unlock()
+
+	total := 0
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			return
+		}
+		total += t(ctx)
+	}
+	if onRun != nil {
+		onRun(total, triggered)
+	}
+}
+
+// nextDelay returns interval offset by a random amount in [-jitter, +jitter].
+func (s *Scheduler) nextDelay() time.Duration {
+	d := s.interval
+	if s.jitter > 0 {
+		d += time.Duration(rand.Int64N(int64(2*s.jitter))) - s.jitter
+	}
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
@@ -0,0 +1,239 @@
+package goals
+
+import (
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/scenarios"
+	"refleks/internal/util"
+)
+
+// Goal kinds
+const (
+	KindScore     = "score"
+	KindBenchmark = "benchmark"
+	KindPlaytime  = "playtime"
+)
+
+// Paces
+const (
+	PaceAchieved = "achieved"
+	PaceOnPace   = "on-pace"
+	PaceOffPace  = "off-pace"
+	PaceExpired  = "expired"
+	PaceUnknown  = "unknown"
+)
+
+// evaluate computes the status of a goal. bench returns the cached benchmark progress of an ID.
+func evaluate(g models.Goal, runs []util.Run, bench func(int) (models.BenchmarkProgress, bool), now time.Time) models.GoalStatus {
+	st := models.GoalStatus{GoalID: g.ID, EvaluatedAt: now}
+	var forecast *time.Time
+	known := false
+	switch g.Kind {
+	case KindScore:
+		st.Target = g.TargetScore
+		for _, r := range runs {
+			if !strings.EqualFold(r.Scenario, g.Scenario) {
+				continue
+			}
+			st.Current = max(st.Current, r.Score)
+			if r.Score >= g.TargetScore && st.AchievedAt == nil {
+				at := r.At
+				st.AchievedAt = &at
+			}
+		}
+		forecast, known = forecastScore(runs, g.Scenario, g.TargetScore, now)
+	case KindPlaytime:
+		st.Target = g.TargetHours
+		end := now
+		if g.Deadline != nil && g.Deadline.Before(end) {
+			end = *g.Deadline
+		}
+		var secs float64
+		for _, r := range runs {
+			if r.At.Before(g.Start) || r.At.After(end) || !matchesPlaytime(g, r.Scenario) {
+				continue
+			}
+			secs += r.Seconds
+			if secs/3600 >= g.TargetHours && st.AchievedAt == nil {
+				at := r.At
+				st.AchievedAt = &at
+			}
+		}
+		st.Current = secs / 3600
+		// Average rate since the start; a day at least, so a first session doesn't extrapolate wildly
+		if days := now.Sub(g.Start).Hours() / 24; days >= 1 {
+			known = true
+			if rate := st.Current / days; rate > 0 {
+				t := now.Add(time.Duration((g.TargetHours - st.Current) / rate * 24 * float64(time.Hour)))
+				if t.Sub(now) <= constants.GoalForecastMaxDays*24*time.Hour {
+					forecast = &t
+				}
+			}
+		}
+	case KindBenchmark:
+		p, ok := bench(g.BenchmarkID)
+		target := rankIndex(p.Ranks, g.Rank)
+		if !ok || target == 0 {
+			st.Pace = PaceUnknown
+			return st
+		}
+		st.Target, st.Current = float64(target), float64(p.OverallRank)
+		if p.OverallRank >= target {
+			at := now
+			st.AchievedAt = &at
+		}
+		forecast, known, st.Blocking, st.Percent = forecastBenchmark(runs, p, target, now)
+	}
+
+	if g.AchievedAt != nil && (st.AchievedAt == nil || g.AchievedAt.Before(*st.AchievedAt)) {
+		st.AchievedAt = g.AchievedAt
+	}
+	st.Achieved = st.AchievedAt != nil
+	if g.Kind != KindBenchmark && st.Target > 0 {
+		st.Percent = min(st.Current/st.Target, 1) * 100
+	}
+	if st.Achieved {
+		st.Percent = 100
+	}
+	st.Forecast = forecast
+	st.Pace = pace(g, st, known, now)
+	return st
+}
+
+// pace classifies a goal from its forecast.
+func pace(g models.Goal, st models.GoalStatus, known bool, now time.Time) string {
+	switch {
+	case st.Achieved:
+		return PaceAchieved
+	case g.Deadline != nil && now.After(*g.Deadline):
+		return PaceExpired
+	case st.Forecast != nil && (g.Deadline == nil || !st.Forecast.After(*g.Deadline)):
+		return PaceOnPace
+	case known && g.Deadline != nil:
+		return PaceOffPace
+	default:
+		return PaceUnknown
+	}
+}
+
+// matchesPlaytime reports whether a scenario counts towards a playtime goal.
+func matchesPlaytime(g models.Goal, scenario string) bool {
+	if g.Scenario != "" {
+		return strings.EqualFold(g.Scenario, scenario)
+	}
+	if g.Tag == "" {
+		return true
+	}
+	meta, ok := scenarios.Get(scenario)
+	if !ok {
+		return false
+	}
+	for _, t := range meta.Tags {
+		if strings.EqualFold(t, g.Tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankIndex returns the 1-based index of a rank name, 0 when unknown.
+func rankIndex(ranks []models.RankDef, name string) int {
+	for i, r := range ranks {
+		if strings.EqualFold(r.Name, strings.TrimSpace(name)) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// forecastBenchmark forecasts a benchmark rank as the date the last scenario below the rank
+// threshold reaches it. This is conservative: Kovaak's overall ranks don't always need every scenario.
+// It also returns those scenarios and the mean share of the thresholds reached.
+func forecastBenchmark(runs []util.Run, p models.BenchmarkProgress, target int, now time.Time) (forecast *time.Time, known bool, blocking []string, percent float64) {
+	var sum float64
+	n := 0
+	known = true
+	stalled := false
+	for _, c := range p.Categories {
+		for _, gr := range c.Groups {
+			for _, sc := range gr.Scenarios {
+				if target >= len(sc.Thresholds) || sc.Thresholds[target] <= 0 {
+					continue
+				}
+				threshold := sc.Thresholds[target]
+				n++
+				sum += min(sc.Score/threshold, 1)
+				if sc.Score >= threshold {
+					continue
+				}
+				blocking = append(blocking, sc.Name)
+				f, k := forecastScore(runs, sc.Name, threshold, now)
+				switch {
+				case !k:
+					known = false
+				case f == nil:
+					stalled = true
+				case forecast == nil || f.After(*forecast):
+					forecast = f
+				}
+			}
+		}
+	}
+	percent = percentOf(sum, n)
+	if stalled {
+		// A scenario that isn't improving holds the rank back indefinitely
+		return nil, true, blocking, percent
+	}
+	if !known {
+		return nil, false, blocking, percent
+	}
+	return forecast, true, blocking, percent
+}
+
+func percentOf(sum float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n) * 100
+}
+
+// forecastScore fits a line through the daily best scores of a scenario over the recent window
+// and returns when it crosses target. known is false when there are too few days to fit; a nil
+// forecast with known set means the scores aren't improving (or only far beyond the horizon).
+func forecastScore(runs []util.Run, scenario string, target float64, now time.Time) (forecast *time.Time, known bool) {
+	from := now.AddDate(0, 0, -constants.GoalForecastDays)
+	best := map[time.Time]float64{}
+	for _, r := range runs {
+		if r.At.Before(from) || r.At.After(now) || !strings.EqualFold(r.Scenario, scenario) {
+			continue
+		}
+		l := r.At.Local()
+		day := time.Date(l.Year(), l.Month(), l.Day(), 0, 0, 0, 0, time.Local)
+		best[day] = max(best[day], r.Score)
+	}
+	if len(best) < constants.GoalForecastMinDays {
+		return nil, false
+	}
+	xs := make([]float64, 0, len(best))
+	ys := make([]float64, 0, len(best))
+	for day, v := range best {
+		xs = append(xs, day.Sub(from).Hours()/24)
+		ys = append(ys, v)
+	}
+	a, b := util.LinearFit(xs, ys)
+	if b <= 0 {
+		return nil, true
+	}
+	t := from.Add(time.Duration((target - a) / b * 24 * float64(time.Hour)))
+	if t.Before(now) {
+		// The trend line is already past the target even if no run is yet
+		t = now
+	}
+	if t.Sub(now) > constants.GoalForecastMaxDays*24*time.Hour {
+		return nil, true
+	}
+	return &t, true
+}
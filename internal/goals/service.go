@@ -0,0 +1,320 @@
+// Package goals stores the user's goals and evaluates them from the run history and benchmark progress.
+package goals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"refleks/internal/benchmarks"
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// ErrNotFound is returned for an unknown goal ID.
+var ErrNotFound = errors.New("goal not found")
+
+// Service manages the goals of the active profile, stored next to its settings ($HOME/.refleks/goals.json).
+type Service struct {
+	ctx          context.Context
+	benchmarkSvc *benchmarks.Service
+
+	mu      sync.Mutex
+	goals   []models.Goal
+	loaded  bool
+	history func() []models.ScenarioRecord
+	timer   *time.Timer
+}
+
+// NewService creates a new goals service.
+func NewService(ctx context.Context, benchmarkSvc *benchmarks.Service) *Service {
+	return &Service{ctx: ctx, benchmarkSvc: benchmarkSvc}
+}
+
+// SetHistorySource sets the provider of the full run history goals are evaluated on.
+func (s *Service) SetHistorySource(fn func() []models.ScenarioRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = fn
+}
+
+// Reload drops the loaded goals so they are read again from the active profile.
+func (s *Service) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.goals, s.loaded = nil, false
+}
+
+// List returns all goals, oldest first.
+func (s *Service) List() []models.Goal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	return append([]models.Goal{}, s.goals...)
+}
+
+// Save validates and stores a goal. A goal without ID is created; changing the target of a goal
+// clears its achievement so it is evaluated afresh.
+func (s *Service) Save(g models.Goal) (models.Goal, error) {
+	g = normalize(g)
+	if err := Validate(g); err != nil {
+		return models.Goal{}, err
+	}
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	now := time.Now()
+	g.UpdatedAt = now
+	g.AchievedAt, g.OffPace = nil, false
+	if g.ID == "" {
+		g.ID = uuid.NewString()
+		g.CreatedAt = now
+		if g.Start.IsZero() {
+			g.Start = now
+		}
+		s.goals = append(s.goals, g)
+	} else {
+		i := s.indexLocked(g.ID)
+		if i < 0 {
+			s.mu.Unlock()
+			return models.Goal{}, ErrNotFound
+		}
+		old := s.goals[i]
+		g.CreatedAt = old.CreatedAt
+		if g.Start.IsZero() {
+			g.Start = old.Start
+		}
+		if sameTarget(old, g) {
+			g.AchievedAt, g.OffPace = old.AchievedAt, old.OffPace
+		}
+		s.goals[i] = g
+	}
+	err := s.saveLocked()
+	s.mu.Unlock()
+	if err != nil {
+		return models.Goal{}, err
+	}
+	s.Schedule()
+	return g, nil
+}
+
+// Delete removes a goal.
+func (s *Service) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.goals = append(s.goals[:i], s.goals[i+1:]...)
+	return s.saveLocked()
+}
+
+// OnRun re-evaluates the goals shortly after a run is parsed (see Schedule).
+func (s *Service) OnRun(models.ScenarioRecord) { s.Schedule() }
+
+// OnBenchmarkProgress re-evaluates the goals shortly after benchmark progress is refreshed.
+func (s *Service) OnBenchmarkProgress(int, models.BenchmarkProgress) { s.Schedule() }
+
+// Schedule evaluates the goals once no further trigger arrived for GoalEvaluateDebounceMs,
+// so the runs of a scan or a burst of progress refreshes are evaluated once.
+func (s *Service) Schedule() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := time.Duration(constants.GoalEvaluateDebounceMs) * time.Millisecond
+	if s.timer != nil {
+		s.timer.Reset(d)
+		return
+	}
+	s.timer = time.AfterFunc(d, func() { s.Evaluate() })
+}
+
+// RunEvaluator evaluates the goals every interval until ctx is cancelled. Runs and benchmark progress
+// trigger evaluations on their own; this catches what changes with time alone (deadlines, paces).
+func (s *Service) RunEvaluator(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Evaluate()
+		}
+	}
+}
+
+// Evaluate computes the status of every goal. Goals that were just achieved emit EventGoalAchieved
+// and goals whose forecast just slipped past their deadline emit EventGoalOffPace; all statuses are
+// announced with EventGoalsUpdated.
+func (s *Service) Evaluate() []models.GoalStatus {
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	history := s.history
+	goals := append([]models.Goal{}, s.goals...)
+	s.mu.Unlock()
+
+	var runs []util.Run
+	if history != nil {
+		runs = util.RunsOf(history())
+	}
+	now := time.Now()
+	statuses := make([]models.GoalStatus, len(goals))
+	for i, g := range goals {
+		statuses[i] = evaluate(g, runs, s.benchmarkSvc.GetCachedBenchmarkProgress, now)
+	}
+
+	// Record transitions on the stored goals (they may have changed meanwhile). Events are only
+	// emitted for transitions written here, so overlapping evaluations announce each one once.
+	type event struct {
+		name string
+		goal models.Goal
+		st   models.GoalStatus
+	}
+	var events []event
+	s.mu.Lock()
+	changed := false
+	for i, g := range goals {
+		j := s.indexLocked(g.ID)
+		if j < 0 || !s.goals[j].UpdatedAt.Equal(g.UpdatedAt) {
+			continue
+		}
+		st := statuses[i]
+		if st.Achieved && s.goals[j].AchievedAt == nil {
+			s.goals[j].AchievedAt = st.AchievedAt
+			changed = true
+			events = append(events, event{constants.EventGoalAchieved, s.goals[j], st})
+		}
+		if off := st.Pace == PaceOffPace; off != s.goals[j].OffPace {
+			s.goals[j].OffPace = off
+			changed = true
+			if off {
+				events = append(events, event{constants.EventGoalOffPace, s.goals[j], st})
+			}
+		}
+	}
+	if changed {
+		if err := s.saveLocked(); err != nil {
+			runtime.LogWarningf(s.ctx, "goals: save: %v", err)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, e := range events {
+		runtime.EventsEmit(s.ctx, e.name, map[string]any{"goal": e.goal, "status": e.st})
+	}
+	runtime.EventsEmit(s.ctx, constants.EventGoalsUpdated, statuses)
+	return statuses
+}
+
+// Validate checks that a goal is well-formed.
+func Validate(g models.Goal) error {
+	var errs []string
+	if g.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	switch g.Kind {
+	case KindScore:
+		if g.Scenario == "" {
+			errs = append(errs, "scenario is required")
+		}
+		if g.TargetScore <= 0 {
+			errs = append(errs, "targetScore must be positive")
+		}
+	case KindBenchmark:
+		if g.BenchmarkID <= 0 {
+			errs = append(errs, "benchmarkId is required")
+		}
+		if g.Rank == "" {
+			errs = append(errs, "rank is required")
+		}
+	case KindPlaytime:
+		if g.TargetHours <= 0 {
+			errs = append(errs, "targetHours must be positive")
+		}
+	default:
+		errs = append(errs, fmt.Sprintf("unknown goal kind %q", g.Kind))
+	}
+	if g.Deadline != nil && !g.Start.IsZero() && !g.Deadline.After(g.Start) {
+		errs = append(errs, "deadline must be after the start")
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// normalize trims the text fields of a goal and clears the fields of other kinds.
+func normalize(g models.Goal) models.Goal {
+	g.Name = strings.TrimSpace(g.Name)
+	g.Kind = strings.ToLower(strings.TrimSpace(g.Kind))
+	g.Scenario = strings.TrimSpace(g.Scenario)
+	g.Rank = strings.TrimSpace(g.Rank)
+	g.Tag = strings.TrimSpace(g.Tag)
+	switch g.Kind {
+	case KindScore:
+		g.BenchmarkID, g.Rank, g.Tag, g.TargetHours = 0, "", "", 0
+	case KindBenchmark:
+		g.Scenario, g.TargetScore, g.Tag, g.TargetHours = "", 0, "", 0
+	case KindPlaytime:
+		g.TargetScore, g.BenchmarkID, g.Rank = 0, 0, ""
+	}
+	return g
+}
+
+// sameTarget reports whether two versions of a goal aim at the same thing.
+func sameTarget(a, b models.Goal) bool {
+	a.ID, a.Name, a.CreatedAt, a.UpdatedAt, a.AchievedAt, a.OffPace = b.ID, b.Name, b.CreatedAt, b.UpdatedAt, b.AchievedAt, b.OffPace
+	if (a.Deadline == nil) != (b.Deadline == nil) || (a.Deadline != nil && !a.Deadline.Equal(*b.Deadline)) {
+		return false
+	}
+	sameStart := a.Start.Equal(b.Start)
+	a.Deadline, a.Start = b.Deadline, b.Start
+	return sameStart && a == b
+}
+
+func (s *Service) indexLocked(id string) int {
+	for i := range s.goals {
+		if s.goals[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ensureLoadedLocked lazily loads the goals file. Caller must hold s.mu.
+func (s *Service) ensureLoadedLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.goals = nil
+	var goals []models.Goal
+	if err := settings.ReadDataJSON(constants.GoalsFileName, &goals); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			runtime.LogWarningf(s.ctx, "goals: read %s: %v", constants.GoalsFileName, err)
+		}
+		return
+	}
+	sort.SliceStable(goals, func(i, j int) bool { return goals[i].CreatedAt.Before(goals[j].CreatedAt) })
+	s.goals = goals
+}
+
+// saveLocked writes the goals file atomically. Caller must hold s.mu.
+func (s *Service) saveLocked() error {
+	goals := s.goals
+	if goals == nil {
+		goals = []models.Goal{}
+	}
+	return settings.WriteDataJSON(constants.GoalsFileName, goals)
+}
@@ -0,0 +1,284 @@
+package kovaaks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// API is the subset of the Kovaak's webapp backend used by RefleK's.
+// Services depend on this interface so tests can point a Client at an httptest server
+// (see WithBaseURL) or swap in a fake implementation entirely.
+type API interface {
+	// PlayerProgress returns the raw JSON of a player's progress in a benchmark.
+	PlayerProgress(ctx context.Context, benchmarkID int, steamID string) ([]byte, error)
+	// LastScores returns the last scores a player posted on a scenario (newest first).
+	LastScores(ctx context.Context, username, scenarioName string) ([]models.KovaaksLastScore, error)
+	// SearchScenarios searches scenarios by name (used to resolve leaderboard IDs).
+	SearchScenarios(ctx context.Context, scenarioName string) ([]ScenarioSearchResult, error)
+	// Leaderboard returns one page of a scenario leaderboard.
+	Leaderboard(ctx context.Context, leaderboardID, page, max int, usernameSearch string) (LeaderboardScores, error)
+	// SearchUsers searches Kovaak's players by webapp username or Steam persona name.
+	SearchUsers(ctx context.Context, username string) ([]UserSearchResult, error)
+}
+
+// Client is a context-aware Kovaak's API client with retries, a shared rate limiter
+// and conditional (ETag/Last-Modified) caching. It is safe for concurrent use and
+// is meant to be shared by every service that talks to Kovaak's. Every request waits on
+// the shared limiter, so callers may fan out concurrently without throttling themselves.
+type Client struct {
+	baseURL     string
+	http        *http.Client
+	limiter     *rate.Limiter
+	userAgent   string
+	maxRetries  int
+	backoffBase time.Duration
+	backoffMax  time.Duration
+
+	mu         sync.Mutex
+	validators map[string]cachedResponse
+
+	// connectivity state (see connectivity.go)
+	offlineMode    bool
+	online         bool
+	lastChange     time.Time
+	lastErr        error
+	onConnectivity []func(models.ConnectivityStatus)
+}
+
+// cachedResponse keeps the last successful body of a URL with its validators,
+// so a 304 Not Modified can be answered locally.
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL overrides the API base URL (e.g. an httptest server in tests).
+func WithBaseURL(u string) Option {
+	return func(c *Client) { c.baseURL = strings.TrimRight(u, "/") }
+}
+
+// WithHTTPClient overrides the underlying HTTP client.
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) { c.http = h }
+}
+
+// WithRateLimit overrides the token-bucket limiter (requests per second and burst).
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) { c.limiter = rate.NewLimiter(rate.Limit(perSecond), burst) }
+}
+
+// WithRetries overrides the retry count and backoff bounds.
+func WithRetries(max int, base, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = max
+		c.backoffBase = base
+		c.backoffMax = maxDelay
+	}
+}
+
+// NewClient creates a client with the application defaults from constants.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:     constants.KovaaksAPIBaseURL,
+		http:        &http.Client{Timeout: time.Duration(constants.KovaaksHTTPTimeoutSeconds) * time.Second},
+		limiter:     rate.NewLimiter(rate.Limit(constants.KovaaksRequestsPerSecond), constants.KovaaksBurst),
+		userAgent:   "refleks/" + constants.AppVersion,
+		maxRetries:  constants.KovaaksMaxRetries,
+		backoffBase: time.Duration(constants.KovaaksBackoffBaseMillis) * time.Millisecond,
+		backoffMax:  time.Duration(constants.KovaaksBackoffMaxMillis) * time.Millisecond,
+		validators:  make(map[string]cachedResponse),
+		online:      true,
+		lastChange:  time.Now(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PlayerProgress implements API.
+func (c *Client) PlayerProgress(ctx context.Context, benchmarkID int, steamID string) ([]byte, error) {
+	if strings.TrimSpace(steamID) == "" {
+		return nil, errors.New("kovaaks: missing steam ID")
+	}
+	return c.Get(ctx, fmt.Sprintf(constants.KovaaksPlayerProgressPath, benchmarkID, url.QueryEscape(steamID)))
+}
+
+// LastScores implements API.
+func (c *Client) LastScores(ctx context.Context, username, scenarioName string) ([]models.KovaaksLastScore, error) {
+	if strings.TrimSpace(username) == "" {
+		return nil, errors.New("kovaaks: missing username")
+	}
+	b, err := c.Get(ctx, fmt.Sprintf(constants.KovaaksLastScoresPath, url.QueryEscape(username), url.QueryEscape(scenarioName)))
+	if err != nil {
+		return nil, err
+	}
+	var scores []models.KovaaksLastScore
+	if err := json.Unmarshal(b, &scores); err != nil {
+		return nil, fmt.Errorf("kovaaks: decode last scores: %w", err)
+	}
+	return scores, nil
+}
+
+// Get performs a GET on a path relative to the base URL and returns the response body.
+// Requests wait on the shared limiter, retry 429/5xx and transient network errors with
+// exponential backoff, and revalidate previously seen bodies with If-None-Match/If-Modified-Since.
+func (c *Client) Get(ctx context.Context, path string) ([]byte, error) {
+	if c.OfflineMode() {
+		return nil, &offlineError{err: errOfflineMode}
+	}
+	endpoint := c.baseURL + path
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepCtx(ctx, c.backoff(attempt, lastErr)); err != nil {
+				return nil, err
+			}
+		}
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, retry, err := c.do(ctx, endpoint)
+		if err == nil {
+			c.recordResult(nil)
+			return body, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	c.recordResult(lastErr)
+	return nil, lastErr
+}
+
+// do runs a single attempt. retry reports whether the failure is worth retrying.
+func (c *Client) do(ctx context.Context, endpoint string) (body []byte, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	c.mu.Lock()
+	cached, haveCached := c.validators[endpoint]
+	c.mu.Unlock()
+	if haveCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, true, &offlineError{err: err}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && haveCached:
+		return cached.body, false, nil
+	case resp.StatusCode == http.StatusOK:
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, true, &offlineError{err: err}
+		}
+		etag := resp.Header.Get("ETag")
+		lastMod := resp.Header.Get("Last-Modified")
+		if etag != "" || lastMod != "" {
+			c.mu.Lock()
+			c.validators[endpoint] = cachedResponse{etag: etag, lastModified: lastMod, body: b}
+			c.mu.Unlock()
+		}
+		return b, false, nil
+	}
+
+	// Drain so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+	serr := &StatusError{StatusCode: resp.StatusCode, URL: endpoint, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return nil, retry, serr
+}
+
+// backoff returns the delay before the given retry attempt (1-based), honouring Retry-After.
+func (c *Client) backoff(attempt int, lastErr error) time.Duration {
+	var serr *StatusError
+	if errors.As(lastErr, &serr) && serr.RetryAfter > 0 {
+		if serr.RetryAfter > c.backoffMax {
+			return c.backoffMax
+		}
+		return serr.RetryAfter
+	}
+	d := c.backoffBase << (attempt - 1)
+	if d <= 0 || d > c.backoffMax {
+		d = c.backoffMax
+	}
+	// Jitter in [d/2, d) so parallel callers don't retry in lockstep
+	half := int64(d / 2)
+	if half <= 0 {
+		return d
+	}
+	return time.Duration(half + rand.Int64N(half))
+}
+
+// ClearCache drops all conditional-request validators and cached bodies.
+func (c *Client) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validators = make(map[string]cachedResponse)
+}
+
+func parseRetryAfter(v string) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
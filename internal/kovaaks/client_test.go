@@ -0,0 +1,250 @@
+package kovaaks
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// newTestClient returns a client for srv with a fast limiter and millisecond backoff.
+func newTestClient(url string) *Client {
+	return NewClient(
+		WithBaseURL(url),
+		WithRateLimit(1000, 100),
+		WithRetries(3, time.Millisecond, 5*time.Millisecond),
+	)
+}
+
+func TestGetRetriesTransientStatus(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= 2 {
+					w.WriteHeader(status)
+					return
+				}
+				w.Write([]byte(`{"ok":true}`))
+			}))
+			defer srv.Close()
+
+			b, err := newTestClient(srv.URL).Get(context.Background(), "/x")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if string(b) != `{"ok":true}` {
+				t.Errorf("body = %s", b)
+			}
+			if n := calls.Load(); n != 3 {
+				t.Errorf("requests = %d, want 3", n)
+			}
+		})
+	}
+}
+
+func TestGetGivesUpWhenRateLimited(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	start := time.Now()
+	_, err := c.Get(context.Background(), "/x")
+	if !IsRateLimited(err) || IsNotFound(err) || IsOffline(err) {
+		t.Fatalf("err = %v, want rate limited", err)
+	}
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusTooManyRequests || serr.RetryAfter != time.Second {
+		t.Errorf("StatusError = %+v", serr)
+	}
+	if n := calls.Load(); n != 4 {
+		t.Errorf("requests = %d, want 4 (1 + 3 retries)", n)
+	}
+	// Retry-After is capped at the maximum backoff
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("took %v, Retry-After should be capped at backoffMax", d)
+	}
+	if !c.Connectivity().Online {
+		t.Error("an HTTP response should leave the API online")
+	}
+}
+
+func TestGetNotFoundIsNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv.URL).Get(context.Background(), "/missing")
+	if !IsNotFound(err) || !errors.Is(err, ErrNotFound) || IsRateLimited(err) || IsOffline(err) {
+		t.Fatalf("err = %v, want not found", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("requests = %d, want 1", n)
+	}
+}
+
+func TestGetRevalidatesWithETag(t *testing.T) {
+	var calls, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` && r.Header.Get("If-Modified-Since") == "Mon, 02 Jan 2006 15:04:05 GMT" {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Write([]byte(`{"v":1}`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	for i := 0; i < 3; i++ {
+		b, err := c.Get(context.Background(), "/x")
+		if err != nil || string(b) != `{"v":1}` {
+			t.Fatalf("Get #%d = %s, %v", i, b, err)
+		}
+	}
+	if n := notModified.Load(); n != 2 {
+		t.Errorf("304 responses = %d, want 2", n)
+	}
+
+	// Without validators the server must send the body again
+	c.ClearCache()
+	if _, err := c.Get(context.Background(), "/x"); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != 4 || notModified.Load() != 2 {
+		t.Errorf("after ClearCache: requests = %d, 304s = %d; want 4, 2", calls.Load(), notModified.Load())
+	}
+}
+
+func TestGetUnexpectedNotModified(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	// A 304 without a cached body is an error, not an empty success
+	_, err := newTestClient(srv.URL).Get(context.Background(), "/x")
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusNotModified {
+		t.Fatalf("err = %v, want status 304", err)
+	}
+}
+
+func TestGetOffline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close()
+
+	c := newTestClient(url)
+	var changes []bool
+	c.OnConnectivityChange(func(st models.ConnectivityStatus) { changes = append(changes, st.Online) })
+	_, err := c.Get(context.Background(), "/x")
+	if !IsOffline(err) || !errors.Is(err, ErrOffline) || IsNotFound(err) || IsRateLimited(err) {
+		t.Fatalf("err = %v, want offline", err)
+	}
+	st := c.Connectivity()
+	if st.Online || st.LastError == "" {
+		t.Errorf("connectivity = %+v, want offline with an error", st)
+	}
+	if len(changes) != 1 || changes[0] {
+		t.Errorf("connectivity changes = %v, want [false]", changes)
+	}
+}
+
+func TestGetOfflineMode(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(srv.URL)
+	c.SetOfflineMode(true)
+	_, err := c.Get(context.Background(), "/x")
+	if !IsOffline(err) {
+		t.Fatalf("err = %v, want offline", err)
+	}
+	if calls.Load() != 0 {
+		t.Errorf("offline mode made %d requests", calls.Load())
+	}
+	if st := c.Connectivity(); st.Online || !st.OfflineMode {
+		t.Errorf("connectivity = %+v", st)
+	}
+
+	c.SetOfflineMode(false)
+	if _, err := c.Get(context.Background(), "/x"); err != nil {
+		t.Fatalf("Get after offline mode: %v", err)
+	}
+}
+
+func TestGetCancelledDuringBackoff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithBaseURL(srv.URL), WithRateLimit(1000, 100), WithRetries(3, time.Hour, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.Get(ctx, "/x"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := NewClient(WithRetries(5, 100*time.Millisecond, time.Second))
+	for attempt, max := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 5: time.Second} {
+		for i := 0; i < 20; i++ {
+			if d := c.backoff(attempt, nil); d < max/2 || d >= max {
+				t.Errorf("backoff(%d) = %v, want in [%v, %v)", attempt, d, max/2, max)
+			}
+		}
+	}
+	if d := c.backoff(1, &StatusError{StatusCode: 503, RetryAfter: 300 * time.Millisecond}); d != 300*time.Millisecond {
+		t.Errorf("Retry-After backoff = %v, want 300ms", d)
+	}
+	if d := c.backoff(1, &StatusError{StatusCode: 429, RetryAfter: time.Minute}); d != time.Second {
+		t.Errorf("capped Retry-After backoff = %v, want 1s", d)
+	}
+}
+
+func TestStatusErrorIs(t *testing.T) {
+	tests := []struct {
+		status              int
+		notFound, rateLimit bool
+	}{
+		{http.StatusNotFound, true, false},
+		{http.StatusTooManyRequests, false, true},
+		{http.StatusInternalServerError, false, false},
+		{http.StatusForbidden, false, false},
+	}
+	for _, tt := range tests {
+		err := error(&StatusError{StatusCode: tt.status, URL: "u"})
+		wrapped := errors.Join(errors.New("context"), err)
+		for _, e := range []error{err, wrapped} {
+			if IsNotFound(e) != tt.notFound || IsRateLimited(e) != tt.rateLimit || IsOffline(e) {
+				t.Errorf("status %d: notFound=%v rateLimited=%v offline=%v", tt.status, IsNotFound(e), IsRateLimited(e), IsOffline(e))
+			}
+		}
+	}
+	off := &offlineError{err: errors.New("dial tcp: connection refused")}
+	if !IsOffline(off) || IsNotFound(off) || errors.Unwrap(off) == nil {
+		t.Errorf("offlineError does not match ErrOffline or lost its cause")
+	}
+}
@@ -0,0 +1,89 @@
+package kovaaks
+
+import (
+	"errors"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// errOfflineMode is the cause reported while the user has forced offline mode.
+var errOfflineMode = errors.New("offline mode enabled")
+
+// SetOfflineMode forces the client offline: every call fails fast with ErrOffline
+// without touching the network. Disabling it does not mark the API online until
+// the next successful request.
+func (c *Client) SetOfflineMode(enabled bool) {
+	c.mu.Lock()
+	changed := c.offlineMode != enabled
+	c.offlineMode = enabled
+	c.mu.Unlock()
+	if changed {
+		c.notifyConnectivity()
+	}
+}
+
+// OfflineMode reports whether offline mode is forced.
+func (c *Client) OfflineMode() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offlineMode
+}
+
+// Connectivity returns the current reachability status of the API.
+func (c *Client) Connectivity() models.ConnectivityStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.connectivityLocked()
+}
+
+// OnConnectivityChange registers a callback fired whenever the API goes online/offline
+// or offline mode is toggled. Callbacks run on the goroutine that observed the change.
+func (c *Client) OnConnectivityChange(fn func(models.ConnectivityStatus)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onConnectivity = append(c.onConnectivity, fn)
+}
+
+func (c *Client) connectivityLocked() models.ConnectivityStatus {
+	st := models.ConnectivityStatus{
+		Online:      c.online && !c.offlineMode,
+		OfflineMode: c.offlineMode,
+		LastChange:  c.lastChange,
+	}
+	if c.lastErr != nil {
+		st.LastError = c.lastErr.Error()
+	}
+	return st
+}
+
+// recordResult updates reachability from the outcome of a request. Only transport
+// failures count as offline; any HTTP response proves the API is reachable.
+func (c *Client) recordResult(err error) {
+	online := err == nil || !IsOffline(err)
+	c.mu.Lock()
+	changed := c.online != online
+	c.online = online
+	if online {
+		c.lastErr = nil
+	} else {
+		c.lastErr = err
+	}
+	if changed {
+		c.lastChange = time.Now()
+	}
+	c.mu.Unlock()
+	if changed {
+		c.notifyConnectivity()
+	}
+}
+
+func (c *Client) notifyConnectivity() {
+	c.mu.Lock()
+	st := c.connectivityLocked()
+	fns := append([]func(models.ConnectivityStatus){}, c.onConnectivity...)
+	c.mu.Unlock()
+	for _, fn := range fns {
+		fn(st)
+	}
+}
@@ -0,0 +1,61 @@
+package kovaaks
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the API reports the resource (player, scenario, benchmark) does not exist.
+	ErrNotFound = errors.New("kovaaks: not found")
+	// ErrRateLimited is returned when the API keeps answering 429 after all retries.
+	ErrRateLimited = errors.New("kovaaks: rate limited")
+	// ErrOffline is returned when the API cannot be reached at all (DNS, connection refused, timeouts).
+	ErrOffline = errors.New("kovaaks: offline")
+)
+
+// StatusError describes a non-2xx response from the Kovaak's API.
+// It matches ErrNotFound / ErrRateLimited via errors.Is where applicable.
+type StatusError struct {
+	StatusCode int
+	URL        string
+	// RetryAfter is the server-provided delay for 429/503 responses (zero if absent).
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("kovaaks: unexpected status %d from %s", e.StatusCode, e.URL)
+}
+
+// Is lets errors.Is(err, ErrNotFound) and errors.Is(err, ErrRateLimited) work on status errors.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// offlineError wraps a transport error so it matches ErrOffline while keeping the cause.
+type offlineError struct {
+	err error
+}
+
+func (e *offlineError) Error() string { return "kovaaks: offline: " + e.err.Error() }
+func (e *offlineError) Unwrap() error { return e.err }
+func (e *offlineError) Is(target error) bool {
+	return target == ErrOffline
+}
+
+// IsOffline reports whether err indicates the API could not be reached.
+func IsOffline(err error) bool { return errors.Is(err, ErrOffline) }
+
+// IsNotFound reports whether err is a 404 from the API.
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsRateLimited reports whether err is a 429 from the API.
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
@@ -0,0 +1,68 @@
+package kovaaks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"refleks/internal/constants"
+)
+
+// ScenarioSearchResult is one match from the scenario search endpoint.
+type ScenarioSearchResult struct {
+	ScenarioName  string `json:"scenarioName"`
+	LeaderboardID int    `json:"leaderboardId"`
+	Counts        struct {
+		Plays   int `json:"plays"`
+		Entries int `json:"entries"`
+	} `json:"counts"`
+}
+
+// LeaderboardScore is one row of a scenario leaderboard.
+type LeaderboardScore struct {
+	SteamID          string  `json:"steamId"`
+	Score            float64 `json:"score"`
+	Rank             int     `json:"rank"`
+	SteamAccountName string  `json:"steamAccountName"`
+	WebappUsername   string  `json:"webappUsername"`
+	Country          string  `json:"country"`
+}
+
+// LeaderboardScores is a page of a scenario leaderboard.
+type LeaderboardScores struct {
+	Total int                `json:"total"`
+	Page  int                `json:"page"`
+	Max   int                `json:"max"`
+	Data  []LeaderboardScore `json:"data"`
+}
+
+// SearchScenarios searches scenarios by name.
+func (c *Client) SearchScenarios(ctx context.Context, scenarioName string) ([]ScenarioSearchResult, error) {
+	b, err := c.Get(ctx, fmt.Sprintf(constants.KovaaksScenarioSearchPath, url.QueryEscape(scenarioName)))
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data []ScenarioSearchResult `json:"data"`
+	}
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return nil, fmt.Errorf("kovaaks: decode scenario search: %w", err)
+	}
+	return resp.Data, nil
+}
+
+// Leaderboard returns one page of a scenario leaderboard, sorted by rank.
+// usernameSearch filters by player name when non-empty.
+func (c *Client) Leaderboard(ctx context.Context, leaderboardID, page, max int, usernameSearch string) (LeaderboardScores, error) {
+	b, err := c.Get(ctx, fmt.Sprintf(constants.KovaaksLeaderboardPath, leaderboardID, page, max, url.QueryEscape(strings.TrimSpace(usernameSearch))))
+	if err != nil {
+		return LeaderboardScores{}, err
+	}
+	var resp LeaderboardScores
+	if err := json.Unmarshal(b, &resp); err != nil {
+		return LeaderboardScores{}, fmt.Errorf("kovaaks: decode leaderboard: %w", err)
+	}
+	return resp, nil
+}
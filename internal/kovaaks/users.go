@@ -0,0 +1,37 @@
+package kovaaks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"refleks/internal/constants"
+)
+
+// UserSearchResult is one match from the user search endpoint.
+type UserSearchResult struct {
+	SteamID          string `json:"steamId"`
+	Username         string `json:"username"`
+	SteamAccountName string `json:"steamAccountName"`
+	Country          string `json:"country"`
+}
+
+// SearchUsers searches players by webapp username or Steam persona name.
+func (c *Client) SearchUsers(ctx context.Context, username string) ([]UserSearchResult, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, errors.New("kovaaks: missing username")
+	}
+	b, err := c.Get(ctx, fmt.Sprintf(constants.KovaaksUserSearchPath, url.QueryEscape(username)))
+	if err != nil {
+		return nil, err
+	}
+	var users []UserSearchResult
+	if err := json.Unmarshal(b, &users); err != nil {
+		return nil, fmt.Errorf("kovaaks: decode user search: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,498 @@
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/cache"
+	"refleks/internal/constants"
+	"refleks/internal/freshness"
+	"refleks/internal/kovaaks"
+	"refleks/internal/models"
+)
+
+// ttl is how long leaderboard pages and derived percentiles are served without refetching.
+const ttl = time.Duration(constants.LeaderboardTTLMinutes) * time.Minute
+
+// percentileRanks are the thresholds computed by GetPercentiles, keyed like ScenarioMeta.Percentiles.
+var percentileRanks = []struct {
+	key string
+	p   float64
+}{
+	{"p50", 50},
+	{"p75", 75},
+	{"p90", 90},
+	{"p95", 95},
+	{"p99", 99},
+}
+
+type pageKey struct {
+	id     int
+	page   int
+	size   int
+	search string
+}
+
+type pageEntry struct {
+	scores    kovaaks.LeaderboardScores
+	freshness *models.Freshness
+}
+
+type percentilesEntry struct {
+	Values    map[string]float64 `json:"values"`
+	Freshness *models.Freshness  `json:"freshness,omitempty"`
+}
+
+// diskCache is the persisted part of the leaderboard cache. Pages stay in memory only.
+type diskCache struct {
+	IDs         map[string]int              `json:"ids"`
+	Percentiles map[string]percentilesEntry `json:"percentiles"`
+}
+
+// Service fetches scenario leaderboards from Kovaak's and derives percentiles from them.
+type Service struct {
+	ctx      context.Context
+	cacheSvc *cache.Service
+	api      kovaaks.API
+
+	mu     sync.Mutex
+	disk   diskCache
+	pages  map[pageKey]pageEntry
+	loaded bool
+	names  func(steamID string) string
+}
+
+// NewService creates a new leaderboard service.
+func NewService(ctx context.Context, cacheSvc *cache.Service, api kovaaks.API) *Service {
+	s := &Service{
+		ctx:      ctx,
+		cacheSvc: cacheSvc,
+		api:      api,
+		disk:     newDiskCache(),
+		pages:    make(map[pageKey]pageEntry),
+	}
+	cacheSvc.RegisterOnClear(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.disk = newDiskCache()
+		s.pages = make(map[pageKey]pageEntry)
+		s.loaded = true
+	})
+	cacheSvc.RegisterOnReload(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.disk = newDiskCache()
+		s.pages = make(map[pageKey]pageEntry)
+		s.loaded = false
+	})
+	return s
+}
+
+// SetFriendNameSource sets the lookup used to turn friends given by Steam ID into persona
+// names, since the leaderboard search only matches names.
+func (s *Service) SetFriendNameSource(fn func(steamID string) string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.names = fn
+}
+
+func newDiskCache() diskCache {
+	return diskCache{IDs: make(map[string]int), Percentiles: make(map[string]percentilesEntry)}
+}
+
+// GetLeaderboard returns one page of a scenario's global leaderboard. With friends set,
+// only those players are returned with their global ranks; paging then applies to the
+// filtered list. Friends are searched by persona/webapp name: Steam IDs are first resolved
+// through the friend name source and otherwise searched as given.
+func (s *Service) GetLeaderboard(scenario string, page, pageSize int, friends []string) (models.LeaderboardPage, error) {
+	ctx := s.ctx
+	if pageSize <= 0 {
+		pageSize = constants.DefaultLeaderboardPageSize
+	}
+	if pageSize > constants.MaxLeaderboardPageSize {
+		pageSize = constants.MaxLeaderboardPageSize
+	}
+	if page < 0 {
+		page = 0
+	}
+	id, err := s.leaderboardID(ctx, scenario)
+	if err != nil {
+		return models.LeaderboardPage{}, err
+	}
+
+	out := models.LeaderboardPage{Scenario: scenario, LeaderboardID: id, Page: page, PageSize: pageSize}
+	if len(friends) == 0 {
+		res, fr, err := s.fetchPage(ctx, pageKey{id: id, page: page, size: pageSize})
+		if err != nil {
+			return models.LeaderboardPage{}, err
+		}
+		out.Total = res.Total
+		out.Entries = toEntries(res.Data)
+		out.Freshness = *fr
+		return out, nil
+	}
+
+	out.FriendsOnly = true
+	first, fr, err := s.fetchPage(ctx, pageKey{id: id, page: 0, size: 1})
+	if err != nil {
+		return models.LeaderboardPage{}, err
+	}
+	out.Total = first.Total
+	out.Freshness = *fr
+
+	seen := map[string]struct{}{}
+	var all []models.LeaderboardEntry
+	for _, f := range friends {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		name := s.friendName(f)
+		res, ffr, err := s.fetchPage(ctx, pageKey{id: id, page: 0, size: constants.MaxLeaderboardPageSize, search: name})
+		if err != nil {
+			// Keep partial results; surface the failure through freshness
+			out.Freshness = *freshness.WithError(&out.Freshness, err)
+			continue
+		}
+		if ffr.Stale {
+			out.Freshness.Stale = true
+		}
+		for _, e := range toEntries(res.Data) {
+			if !matchesFriend(e, f, name) {
+				continue
+			}
+			if _, ok := seen[e.SteamID]; ok {
+				continue
+			}
+			seen[e.SteamID] = struct{}{}
+			all = append(all, e)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Rank < all[j].Rank })
+	start := page * pageSize
+	if start < len(all) {
+		end := start + pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		out.Entries = all[start:end]
+	}
+	return out, nil
+}
+
+// GetPercentile places score on the scenario's global leaderboard. It binary-searches
+// leaderboard pages (sorted by score, descending), so it costs O(log(total/pageSize)) requests.
+func (s *Service) GetPercentile(scenario string, score float64) (models.ScenarioPercentile, error) {
+	ctx := s.ctx
+	id, err := s.leaderboardID(ctx, scenario)
+	if err != nil {
+		return models.ScenarioPercentile{}, err
+	}
+	size := constants.MaxLeaderboardPageSize
+	first, fr, err := s.fetchPage(ctx, pageKey{id: id, page: 0, size: size})
+	if err != nil {
+		return models.ScenarioPercentile{}, err
+	}
+	out := models.ScenarioPercentile{Scenario: scenario, Score: score, Total: first.Total, Freshness: *fr}
+	if first.Total <= 0 {
+		out.Rank = 1
+		out.Percentile = 100
+		return out, nil
+	}
+
+	lo, hi := 0, (first.Total-1)/size
+	for lo < hi {
+		mid := (lo + hi) / 2
+		res, _, err := s.fetchPage(ctx, pageKey{id: id, page: mid, size: size})
+		if err != nil {
+			return models.ScenarioPercentile{}, err
+		}
+		if len(res.Data) > 0 && res.Data[len(res.Data)-1].Score > score {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	res, _, err := s.fetchPage(ctx, pageKey{id: id, page: lo, size: size})
+	if err != nil {
+		return models.ScenarioPercentile{}, err
+	}
+	above := 0
+	for _, e := range res.Data {
+		if e.Score > score {
+			above++
+		}
+	}
+	out.Rank = lo*size + above + 1
+	if out.Rank > first.Total+1 {
+		out.Rank = first.Total + 1
+	}
+	out.Percentile = 100 * float64(first.Total-(out.Rank-1)) / float64(first.Total)
+	return out, nil
+}
+
+// GetPercentiles returns the score thresholds of the scenario's global leaderboard
+// (p50, p75, p90, p95, p99 and globalHighscore), cached for the leaderboard TTL.
+func (s *Service) GetPercentiles(scenario string) (map[string]float64, error) {
+	return s.GetPercentilesContext(s.ctx, scenario)
+}
+
+// GetPercentilesContext is GetPercentiles bounded by ctx (used by callers with their own deadline).
+func (s *Service) GetPercentilesContext(ctx context.Context, scenario string) (map[string]float64, error) {
+	key := normalize(scenario)
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	cached, ok := s.disk.Percentiles[key]
+	s.mu.Unlock()
+	if ok && !freshness.IsStale(cached.Freshness, ttl) {
+		return copyValues(cached.Values), nil
+	}
+
+	values, err := s.computePercentiles(ctx, scenario)
+	if err != nil {
+		if ok {
+			return copyValues(cached.Values), nil
+		}
+		return nil, err
+	}
+	s.mu.Lock()
+	s.disk.Percentiles[key] = percentilesEntry{Values: values, Freshness: freshness.Live()}
+	s.saveLocked()
+	s.mu.Unlock()
+	return copyValues(values), nil
+}
+
+// CachedPercentiles returns previously computed percentiles without touching the network.
+func (s *Service) CachedPercentiles(scenario string) (map[string]float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	e, ok := s.disk.Percentiles[normalize(scenario)]
+	if !ok {
+		return nil, false
+	}
+	return copyValues(e.Values), true
+}
+
+// RevalidateStale recomputes persisted percentiles whose TTL expired or whose last refresh failed.
+// It returns the number of scenarios refreshed.
+func (s *Service) RevalidateStale(ctx context.Context) int {
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	var stale []string
+	for key, e := range s.disk.Percentiles {
+		if freshness.IsStale(e.Freshness, ttl) {
+			stale = append(stale, key)
+		}
+	}
+	s.mu.Unlock()
+
+	refreshed := 0
+	for _, key := range stale {
+		if ctx.Err() != nil {
+			break
+		}
+		values, err := s.computePercentiles(ctx, key)
+		s.mu.Lock()
+		if err != nil {
+			e, ok := s.disk.Percentiles[key]
+			if ok && freshness.ErrorChanged(e.Freshness, err) {
+				e.Freshness = freshness.WithError(e.Freshness, err)
+				s.disk.Percentiles[key] = e
+				s.saveLocked()
+			}
+		} else {
+			s.disk.Percentiles[key] = percentilesEntry{Values: values, Freshness: freshness.Live()}
+			refreshed++
+			s.saveLocked()
+		}
+		s.mu.Unlock()
+	}
+	return refreshed
+}
+
+func (s *Service) computePercentiles(ctx context.Context, scenario string) (map[string]float64, error) {
+	id, err := s.leaderboardID(ctx, scenario)
+	if err != nil {
+		return nil, err
+	}
+	size := constants.MaxLeaderboardPageSize
+	first, _, err := s.fetchPage(ctx, pageKey{id: id, page: 0, size: size})
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]float64{}
+	if first.Total <= 0 || len(first.Data) == 0 {
+		return values, nil
+	}
+	values["globalHighscore"] = first.Data[0].Score
+	for _, pr := range percentileRanks {
+		rank := int(math.Ceil(float64(first.Total) * (1 - pr.p/100)))
+		if rank < 1 {
+			rank = 1
+		}
+		res, _, err := s.fetchPage(ctx, pageKey{id: id, page: (rank - 1) / size, size: size})
+		if err != nil {
+			return nil, err
+		}
+		idx := (rank - 1) % size
+		if idx < len(res.Data) {
+			values[pr.key] = res.Data[idx].Score
+		}
+	}
+	return values, nil
+}
+
+// leaderboardID resolves a scenario name to its Kovaak's leaderboard ID (cached on disk).
+func (s *Service) leaderboardID(ctx context.Context, scenario string) (int, error) {
+	key := normalize(scenario)
+	if key == "" {
+		return 0, fmt.Errorf("missing scenario name")
+	}
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	id, ok := s.disk.IDs[key]
+	s.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	results, err := s.api.SearchScenarios(ctx, scenario)
+	if err != nil {
+		return 0, err
+	}
+	for _, r := range results {
+		if normalize(r.ScenarioName) == key {
+			s.mu.Lock()
+			s.disk.IDs[key] = r.LeaderboardID
+			s.saveLocked()
+			s.mu.Unlock()
+			return r.LeaderboardID, nil
+		}
+	}
+	return 0, fmt.Errorf("leaderboard for %q: %w", scenario, kovaaks.ErrNotFound)
+}
+
+// fetchPage returns a leaderboard page from memory when fresh, otherwise from the API,
+// falling back to the stale page when the API fails.
+func (s *Service) fetchPage(ctx context.Context, k pageKey) (kovaaks.LeaderboardScores, *models.Freshness, error) {
+	s.mu.Lock()
+	cached, ok := s.pages[k]
+	s.mu.Unlock()
+	if ok && !freshness.IsStale(cached.freshness, ttl) {
+		return cached.scores, freshness.FromCache(cached.freshness, ttl), nil
+	}
+
+	res, err := s.api.Leaderboard(ctx, k.id, k.page, k.size, k.search)
+	if err != nil {
+		if ok {
+			return cached.scores, freshness.FromCache(freshness.WithError(cached.freshness, err), ttl), nil
+		}
+		return kovaaks.LeaderboardScores{}, nil, err
+	}
+	fr := freshness.Live()
+	s.mu.Lock()
+	s.storePageLocked(k, pageEntry{scores: res, freshness: fr})
+	s.mu.Unlock()
+	return res, fr, nil
+}
+
+// storePageLocked caches a page after dropping expired pages and, when the cache is
+// still full, the oldest one. Caller must hold s.mu.
+func (s *Service) storePageLocked(k pageKey, e pageEntry) {
+	var oldest pageKey
+	var oldestAt time.Time
+	for pk, pe := range s.pages {
+		if freshness.IsStale(pe.freshness, ttl) {
+			delete(s.pages, pk)
+			continue
+		}
+		if oldestAt.IsZero() || pe.freshness.FetchedAt.Before(oldestAt) {
+			oldest, oldestAt = pk, pe.freshness.FetchedAt
+		}
+	}
+	if _, ok := s.pages[k]; !ok && len(s.pages) >= constants.LeaderboardMaxCachedPages {
+		delete(s.pages, oldest)
+	}
+	s.pages[k] = e
+}
+
+// friendName returns the name to search the leaderboard for friend, resolving Steam IDs
+// through the friend name source when it knows them.
+func (s *Service) friendName(friend string) string {
+	s.mu.Lock()
+	names := s.names
+	s.mu.Unlock()
+	if names != nil {
+		if n := strings.TrimSpace(names(friend)); n != "" {
+			return n
+		}
+	}
+	return friend
+}
+
+// ensureLoadedLocked lazily loads the persisted cache. Caller must hold s.mu.
+func (s *Service) ensureLoadedLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	if !s.cacheSvc.Exists(constants.LeaderboardCacheFileName) {
+		return
+	}
+	var data diskCache
+	if err := s.cacheSvc.Load(constants.LeaderboardCacheFileName, &data); err != nil {
+		return
+	}
+	if data.IDs == nil {
+		data.IDs = make(map[string]int)
+	}
+	if data.Percentiles == nil {
+		data.Percentiles = make(map[string]percentilesEntry)
+	}
+	s.disk = data
+}
+
+// saveLocked persists the disk cache. Caller must hold s.mu.
+func (s *Service) saveLocked() {
+	_ = s.cacheSvc.Save(constants.LeaderboardCacheFileName, s.disk)
+}
+
+func toEntries(rows []kovaaks.LeaderboardScore) []models.LeaderboardEntry {
+	out := make([]models.LeaderboardEntry, 0, len(rows))
+	for _, r := range rows {
+		name := strings.TrimSpace(r.WebappUsername)
+		if name == "" {
+			name = strings.TrimSpace(r.SteamAccountName)
+		}
+		out = append(out, models.LeaderboardEntry{
+			Rank:     r.Rank,
+			SteamID:  r.SteamID,
+			Username: name,
+			Score:    r.Score,
+			Country:  r.Country,
+		})
+	}
+	return out
+}
+
+func matchesFriend(e models.LeaderboardEntry, friend, name string) bool {
+	return e.SteamID == friend || strings.EqualFold(e.Username, name)
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func copyValues(m map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
@@ -3,4 +3,77 @@ package models
 type AIOptions struct {
 	MaxRunsPerScenario int    `json:"maxRunsPerScenario"`
 	SystemPersona      string `json:"systemPersona"`
+	// Regenerate skips the insight cache and replaces the cached answer
+	Regenerate bool `json:"regenerate,omitempty"`
+}
+
+// AISettings selects the model backend used for AI insights.
+type AISettings struct {
+	Provider string `json:"provider"`          // gemini | openai | ollama
+	Model    string `json:"model,omitempty"`   // empty uses the provider's default model
+	BaseURL  string `json:"baseUrl,omitempty"` // OpenAI-compatible and Ollama endpoints
+	APIKey   string `json:"apiKey,omitempty"`  // OpenAI-compatible endpoints (Gemini uses GeminiAPIKey)
+	// PromptTokenBudget caps the estimated tokens of the system prompt + session data (0 = default)
+	PromptTokenBudget int `json:"promptTokenBudget,omitempty"`
+}
+
+// AIUsage reports the token usage of one generation (zero when the backend doesn't report it).
+type AIUsage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// AITurn is one message of an AI conversation.
+type AITurn struct {
+	Role    string   `json:"role"` // "user" or "assistant"
+	Content string   `json:"content"`
+	At      string   `json:"at"` // RFC3339
+	Usage   *AIUsage `json:"usage,omitempty"`
+}
+
+// AIConversation is the persisted chat thread of a session. The first user turn holds the prompt of
+// the initial analysis; the session data itself is rebuilt from the records on every request.
+type AIConversation struct {
+	SessionID string    `json:"sessionId"`
+	Options   AIOptions `json:"options"`
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	CreatedAt string    `json:"createdAt"`
+	UpdatedAt string    `json:"updatedAt"`
+	Turns     []AITurn  `json:"turns"`
+}
+
+// AIConversationSummary is a conversation without its turns, for listings.
+type AIConversationSummary struct {
+	SessionID string `json:"sessionId"`
+	Provider  string `json:"provider"`
+	Model     string `json:"model"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+	Turns     int    `json:"turns"`
+	// Preview is the start of the last assistant turn
+	Preview string `json:"preview"`
+}
+
+// AIRequest identifies a started AI stream and the conversation it belongs to.
+type AIRequest struct {
+	RequestID string `json:"requestId"`
+	SessionID string `json:"sessionId"`
+}
+
+// AIPromptEstimate reports the estimated size of a prompt and how it was summarised to fit the token budget.
+type AIPromptEstimate struct {
+	Tokens        int `json:"tokens"` // system + data + history
+	SystemTokens  int `json:"systemTokens"`
+	DataTokens    int `json:"dataTokens"`
+	HistoryTokens int `json:"historyTokens,omitempty"` // earlier turns and the question of a follow-up
+	Budget        int `json:"budget"`
+	// RunsPerScenario is the number of per-run rows kept per scenario (0 = aggregates only)
+	RunsPerScenario  int  `json:"runsPerScenario"`
+	Scenarios        int  `json:"scenarios"`
+	ScenariosOmitted int  `json:"scenariosOmitted"`
+	LibraryEntries   int  `json:"libraryEntries"`
+	Summarised       bool `json:"summarised"` // reduced from full detail to fit the budget
+	OverBudget       bool `json:"overBudget"` // still over the budget after every reduction
 }
@@ -61,4 +61,5 @@ type BenchmarkProgress struct {
 	BenchmarkProgress float64            `json:"benchmarkProgress"`
 	Ranks             []RankDef          `json:"ranks"`
 	Categories        []ProgressCategory `json:"categories"`
+	Freshness         *Freshness         `json:"freshness,omitempty"`
 }
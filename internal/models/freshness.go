@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Freshness describes where remote-backed data came from and how current it is.
+// It is attached to every result that originates from the Kovaak's API.
+type Freshness struct {
+	// FetchedAt is when the data was last fetched live from the API (zero if never).
+	FetchedAt time.Time `json:"fetchedAt"`
+	// Source is "live" when fetched for this call, "cache" when served from disk/memory.
+	Source string `json:"source"`
+	// Stale is true when the data is older than its TTL or the last refresh failed.
+	Stale bool `json:"stale"`
+	// LastError is the error of the most recent failed refresh, if any.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ConnectivityStatus reports whether the Kovaak's API is reachable and if offline mode is forced.
+type ConnectivityStatus struct {
+	Online      bool      `json:"online"`
+	OfflineMode bool      `json:"offlineMode"`
+	LastChange  time.Time `json:"lastChange"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+// LastScoresResult wraps the last scores of a scenario with freshness metadata.
+type LastScoresResult struct {
+	Scores    []KovaaksLastScore `json:"scores"`
+	Freshness Freshness          `json:"freshness"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Goal is a target set by the user, evaluated continuously from the run history and benchmark progress.
+type Goal struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"` // "score", "benchmark" or "playtime"
+
+	// Score goals: reach TargetScore on Scenario
+	Scenario    string  `json:"scenario,omitempty"`
+	TargetScore float64 `json:"targetScore,omitempty"`
+	// Benchmark goals: reach the overall rank named Rank in BenchmarkID (one difficulty of a benchmark)
+	BenchmarkID int    `json:"benchmarkId,omitempty"`
+	Rank        string `json:"rank,omitempty"`
+	// Playtime goals: play TargetHours of Scenario, or of the scenarios tagged Tag when Scenario is
+	// empty (any scenario when both are), between Start and Deadline
+	Tag         string  `json:"tag,omitempty"`
+	TargetHours float64 `json:"targetHours,omitempty"`
+
+	// Start is when playtime starts counting (defaults to creation)
+	Start    time.Time  `json:"start"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	AchievedAt *time.Time `json:"achievedAt,omitempty"`
+	// OffPace is set while the forecast misses the deadline; it is reported once per slip
+	OffPace bool `json:"offPace"`
+}
+
+// GoalStatus is the evaluation of a goal.
+type GoalStatus struct {
+	GoalID  string  `json:"goalId"`
+	Current float64 `json:"current"` // best score, overall rank or hours played
+	Target  float64 `json:"target"`  // target score, rank or hours
+	Percent float64 `json:"percent"` // 0..100
+	// Achieved is set once the goal has been reached, even if the current value dropped since
+	Achieved   bool       `json:"achieved"`
+	AchievedAt *time.Time `json:"achievedAt,omitempty"`
+	// Forecast is when the goal should be reached at the recent rate of improvement; nil when it
+	// isn't improving or there isn't enough recent data
+	Forecast *time.Time `json:"forecast,omitempty"`
+	// Pace is "achieved", "on-pace", "off-pace", "expired" (deadline passed) or "unknown"
+	Pace string `json:"pace"`
+	// Blocking lists the benchmark scenarios still below the target rank
+	Blocking    []string  `json:"blocking,omitempty"`
+	EvaluatedAt time.Time `json:"evaluatedAt"`
+}
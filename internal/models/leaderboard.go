@@ -0,0 +1,35 @@
+package models
+
+// LeaderboardEntry is one player's best score on a scenario leaderboard.
+type LeaderboardEntry struct {
+	Rank     int     `json:"rank"`
+	SteamID  string  `json:"steamId"`
+	Username string  `json:"username"`
+	Score    float64 `json:"score"`
+	Country  string  `json:"country,omitempty"`
+}
+
+// LeaderboardPage is a page of a scenario leaderboard. When filtered to friends,
+// Entries holds only the matching players (with their global ranks) and Total is
+// still the size of the global leaderboard.
+type LeaderboardPage struct {
+	Scenario      string             `json:"scenario"`
+	LeaderboardID int                `json:"leaderboardId"`
+	Page          int                `json:"page"`
+	PageSize      int                `json:"pageSize"`
+	Total         int                `json:"total"`
+	FriendsOnly   bool               `json:"friendsOnly"`
+	Entries       []LeaderboardEntry `json:"entries"`
+	Freshness     Freshness          `json:"freshness"`
+}
+
+// ScenarioPercentile places a score on the global leaderboard of a scenario.
+// Percentile is the share of ranked players the score is at or above (0..100).
+type ScenarioPercentile struct {
+	Scenario   string    `json:"scenario"`
+	Score      float64   `json:"score"`
+	Rank       int       `json:"rank"`
+	Total      int       `json:"total"`
+	Percentile float64   `json:"percentile"`
+	Freshness  Freshness `json:"freshness"`
+}
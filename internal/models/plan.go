@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// Plan is a training routine: an ordered list of scenarios to play, optionally on a daily or weekly
+// schedule, tracked against the runs the watcher picks up while the plan is active.
+type Plan struct {
+	ID              string       `json:"id"`
+	Name            string       `json:"name"`
+	Description     string       `json:"description,omitempty"`
+	Source          string       `json:"source"` // "ai" or "user"
+	SensitivityNote string       `json:"sensitivityNote,omitempty"`
+	Sharecode       string       `json:"sharecode,omitempty"` // Kovaak's playlist sharecode, when the plan mirrors a shared playlist
+	Items           []PlanItem   `json:"items"`
+	Schedule        PlanSchedule `json:"schedule"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
+	Active          bool         `json:"active"`
+	// ActivatedAt is when the plan was last activated; only runs played after it count
+	ActivatedAt *time.Time `json:"activatedAt,omitempty"`
+	// Runs are the runs matched to the plan's items, oldest first
+	Runs []PlanRun `json:"runs,omitempty"`
+	// Cursor is the item launching the plan starts from; launching a scenario of the plan moves it
+	// past that item. It resets at the start of every scheduled period.
+	Cursor   int        `json:"cursor"`
+	CursorAt *time.Time `json:"cursorAt,omitempty"`
+}
+
+// PlanSchedule says how often a plan is meant to be played. Progress restarts every period.
+type PlanSchedule struct {
+	Kind string `json:"kind"` // "" (once), "daily" or "weekly" (weeks start on Monday)
+	// Weekdays limits a daily plan to some days of the week (0 = Sunday); empty means every day
+	Weekdays []int `json:"weekdays,omitempty"`
+}
+
+// PlanItem is one scenario of a plan.
+type PlanItem struct {
+	Scenario    string  `json:"scenario"`
+	Runs        int     `json:"runs"`                    // 0 = no run goal
+	TargetMins  float64 `json:"targetMinutes,omitempty"` // playtime goal, 0 = none
+	Focus       string  `json:"focus,omitempty"`         // what to concentrate on while playing
+	TargetScore float64 `json:"targetScore,omitempty"`   // 0 = no score goal
+	SensNote    string  `json:"sensNote,omitempty"`
+}
+
+// PlanRun is a run matched to a plan item.
+type PlanRun struct {
+	FileName string    `json:"fileName"`
+	Item     int       `json:"item"` // index into Plan.Items
+	Score    float64   `json:"score"`
+	Seconds  float64   `json:"seconds"` // run duration
+	PlayedAt time.Time `json:"playedAt"`
+}
+
+// PlanProgress is how far the runs matched in the current period got through a plan.
+type PlanProgress struct {
+	PlanID string `json:"planId"`
+	// PeriodStart and PeriodEnd bound the current period of a scheduled plan
+	PeriodStart *time.Time `json:"periodStart,omitempty"`
+	PeriodEnd   *time.Time `json:"periodEnd,omitempty"`
+	// Scheduled is false on the days a daily plan is not scheduled
+	Scheduled bool               `json:"scheduled"`
+	Items     []PlanItemProgress `json:"items"`
+	Completed int                `json:"completed"` // items with all their goals met
+	Percent   float64            `json:"percent"`   // mean share of the item goals met, 0..100
+	// Next is the first incomplete item from the cursor on, -1 when the plan is complete
+	Next int `json:"next"`
+}
+
+// PlanItemProgress is the progress of one plan item.
+type PlanItemProgress struct {
+	Done      int     `json:"done"`
+	Minutes   float64 `json:"minutes"`
+	BestScore float64 `json:"bestScore"`
+	TargetHit bool    `json:"targetHit"`
+	Complete  bool    `json:"complete"`
+}
+
+// PlanAdherence summarises how consistently a plan was completed over its scheduled periods since activation.
+type PlanAdherence struct {
+	PlanID    string  `json:"planId"`
+	Periods   int     `json:"periods"`   // scheduled periods so far, the current one included
+	Completed int     `json:"completed"` // periods with every item complete
+	Percent   float64 `json:"percent"`   // Completed over Periods, 0..100
+	// CurrentStreak counts the latest consecutive completed periods; an unfinished current period doesn't break it
+	CurrentStreak int          `json:"currentStreak"`
+	BestStreak    int          `json:"bestStreak"`
+	History       []PlanPeriod `json:"history"` // most recent last, capped
+}
+
+// PlanPeriod is the outcome of one scheduled period of a plan.
+type PlanPeriod struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Percent  float64   `json:"percent"`
+	Complete bool      `json:"complete"`
+}
+
+// PlanLaunch reports how a plan was launched in Kovaak's.
+type PlanLaunch struct {
+	// PlaylistPath is the playlist file written to Kovaak's playlists folder ("" when the sharecode was used)
+	PlaylistPath string `json:"playlistPath,omitempty"`
+	// Scenario is the scenario Kovaak's was launched into ("" when the shared playlist was opened)
+	Scenario string `json:"scenario,omitempty"`
+}
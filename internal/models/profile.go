@@ -0,0 +1,23 @@
+package models
+
+// SteamAccount is one account that has logged in to Steam on this machine (config/loginusers.vdf).
+type SteamAccount struct {
+	SteamID     string `json:"steamId"`
+	AccountName string `json:"accountName"`
+	PersonaName string `json:"personaName"`
+	MostRecent  bool   `json:"mostRecent"`
+	// LastLogin is the Unix time of the account's last login (0 if unknown).
+	LastLogin int64 `json:"lastLogin"`
+}
+
+// Profile is a local player profile. Settings, notes, favorites, caches and history are kept per profile.
+// The default profile holds the data from before profiles existed; the others are bound to a Steam account.
+type Profile struct {
+	ID          string `json:"id"`
+	SteamID     string `json:"steamId,omitempty"`
+	AccountName string `json:"accountName,omitempty"`
+	PersonaName string `json:"personaName,omitempty"`
+	Active      bool   `json:"active"`
+	// Exists is false for Steam accounts that have no profile data yet (created on first switch).
+	Exists bool `json:"exists"`
+}
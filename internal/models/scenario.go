@@ -14,6 +14,9 @@ type ScenarioRecord struct {
 	TraceData string `json:"traceData,omitempty"`
 	// HasTrace indicates if a trace file exists on disk for this scenario.
 	HasTrace bool `json:"hasTrace"`
+	// Source is "remote" for runs synced from Kovaak's that have no local stats file
+	// (only score, sensitivity, FOV, kills and timing are known). Empty for local runs.
+	Source string `json:"source,omitempty"`
 }
 
 type MousePoint struct {
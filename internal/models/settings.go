@@ -7,19 +7,31 @@ type Settings struct {
 	PersonaNameOverride  string                  `json:"personaNameOverride,omitempty"`
 	StatsDir             string                  `json:"statsDir"`
 	TracesDir            string                  `json:"tracesDir"`
+	TraceRetention       TraceRetentionPolicy    `json:"traceRetention"`
 	SessionGapMinutes    int                     `json:"sessionGapMinutes"`
 	Theme                string                  `json:"theme"`
 	Font                 string                  `json:"font,omitempty"`
 	FavoriteBenchmarks   []string                `json:"favoriteBenchmarks,omitempty"`
+	Teammates            []Teammate              `json:"teammates,omitempty"`
 	MouseTrackingEnabled bool                    `json:"mouseTrackingEnabled"`
 	MouseBufferMinutes   int                     `json:"mouseBufferMinutes"`
 	MaxExistingOnStart   int                     `json:"maxExistingOnStart"`
 	AutostartEnabled     bool                    `json:"autostartEnabled"`
+	OfflineMode          bool                    `json:"offlineMode"`
 	GeminiAPIKey         string                  `json:"geminiApiKey,omitempty"`
+	AI                   AISettings              `json:"ai"`
 	ScenarioNotes        map[string]ScenarioNote `json:"scenarioNotes,omitempty"`
 	SessionNotes         map[string]SessionNote  `json:"sessionNotes,omitempty"`
 }
 
+// PathSuggestions lists the Steam and Kovaak's locations detected on this machine, best match first.
+type PathSuggestions struct {
+	SteamInstallDirs []string `json:"steamInstallDirs"`
+	LibraryDirs      []string `json:"libraryDirs"`
+	KovaaksDirs      []string `json:"kovaaksDirs"`
+	StatsDirs        []string `json:"statsDirs"`
+}
+
 // ScenarioNote holds user notes and sensitivity for a scenario.
 type ScenarioNote struct {
 	Notes string `json:"notes"`
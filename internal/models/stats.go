@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// ActivityStats summarises practice volume, streaks and consistency over the run history.
+type ActivityStats struct {
+	First       *time.Time `json:"first,omitempty"` // first run
+	Last        *time.Time `json:"last,omitempty"`  // last run
+	Runs        int        `json:"runs"`
+	PlaytimeSec float64    `json:"playtimeSec"` // sum of the runs' Duration
+	ActiveDays  int        `json:"activeDays"`
+	// Streaks of consecutive days / weeks (Monday to Sunday) with at least one run
+	DailyStreak  ActivityStreak `json:"dailyStreak"`
+	WeeklyStreak ActivityStreak `json:"weeklyStreak"`
+	// Days lists the days with runs, oldest first
+	Days      []DayActivity    `json:"days"`
+	Scenarios []ScenarioVolume `json:"scenarios"` // most played first
+	// ByHour (24 cells, local time) and ByWeekday (7 cells, 0 = Sunday) aggregate runs and performance
+	ByHour      []HeatmapCell         `json:"byHour"`
+	ByWeekday   []HeatmapCell         `json:"byWeekday"`
+	Consistency []ScenarioConsistency `json:"consistency"` // most played first
+}
+
+// ActivityStreak is a run of consecutive active periods. Current still counts when the latest
+// period has no run yet but the one before it does.
+type ActivityStreak struct {
+	Current int `json:"current"`
+	Best    int `json:"best"`
+}
+
+// DayActivity is the volume of one local day.
+type DayActivity struct {
+	Date        string  `json:"date"` // YYYY-MM-DD
+	Runs        int     `json:"runs"`
+	PlaytimeSec float64 `json:"playtimeSec"`
+}
+
+// ScenarioVolume is how much a scenario was played.
+type ScenarioVolume struct {
+	Scenario    string    `json:"scenario"`
+	Runs        int       `json:"runs"`
+	PlaytimeSec float64   `json:"playtimeSec"`
+	LastPlayed  time.Time `json:"lastPlayed"`
+}
+
+// HeatmapCell aggregates the runs of one hour of the day or day of the week.
+type HeatmapCell struct {
+	Slot        int     `json:"slot"` // hour 0..23 or weekday 0..6
+	Runs        int     `json:"runs"`
+	PlaytimeSec float64 `json:"playtimeSec"`
+	// Performance is the mean score relative to each scenario's mean score (100 = average), 0 without runs
+	Performance float64 `json:"performance"`
+}
+
+// ScenarioConsistency tracks the week-to-week score consistency of a scenario.
+type ScenarioConsistency struct {
+	Scenario string `json:"scenario"`
+	Runs     int    `json:"runs"`
+	// MeanCV is the mean of the weekly coefficients of variation (lower is more consistent)
+	MeanCV float64           `json:"meanCv"`
+	Weeks  []WeekConsistency `json:"weeks"` // oldest first, capped
+}
+
+// WeekConsistency is the score spread of a scenario within one week.
+type WeekConsistency struct {
+	Week   string  `json:"week"` // Monday, YYYY-MM-DD
+	Runs   int     `json:"runs"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	CV     float64 `json:"cv"` // StdDev / Mean
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+// Teammate is a player on the user's roster, identified by SteamID64.
+type Teammate struct {
+	SteamID string `json:"steamId"`
+	Name    string `json:"name"`
+}
+
+// TeammateProgress is one roster member's progress in a benchmark. Self marks the local user.
+// When the member's progress can't be fetched and nothing is cached, Error is set instead.
+type TeammateProgress struct {
+	Teammate Teammate           `json:"teammate"`
+	Self     bool               `json:"self"`
+	Progress *BenchmarkProgress `json:"progress,omitempty"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// ScenarioComparison compares the user and a teammate on one benchmark scenario.
+// Gaps are "mine minus theirs": positive means the user is ahead.
+type ScenarioComparison struct {
+	Name        string   `json:"name"`
+	MyScore     float64  `json:"myScore"`
+	TheirScore  float64  `json:"theirScore"`
+	ScoreGap    float64  `json:"scoreGap"`
+	MyRank      int      `json:"myRank"`
+	TheirRank   int      `json:"theirRank"`
+	RankGap     int      `json:"rankGap"`
+	MyEnergy    *float64 `json:"myEnergy,omitempty"`
+	TheirEnergy *float64 `json:"theirEnergy,omitempty"`
+	EnergyGap   *float64 `json:"energyGap,omitempty"`
+}
+
+// TeamComparison is a per-scenario comparison of the user against one teammate in a benchmark.
+type TeamComparison struct {
+	BenchmarkID    int                  `json:"benchmarkId"`
+	Teammate       Teammate             `json:"teammate"`
+	OverallRankGap int                  `json:"overallRankGap"`
+	ProgressGap    float64              `json:"progressGap"`
+	Scenarios      []ScenarioComparison `json:"scenarios"`
+	Freshness      Freshness            `json:"freshness"`
+}
+
+// ProgressSnapshot is a point-in-time summary of a player's benchmark progress,
+// stored once per day to compute improvement over time.
+type ProgressSnapshot struct {
+	TakenAt           time.Time          `json:"takenAt"`
+	OverallRank       int                `json:"overallRank"`
+	BenchmarkProgress float64            `json:"benchmarkProgress"`
+	Energy            float64            `json:"energy"`
+	Scores            map[string]float64 `json:"scores"`
+}
+
+// TeamImprovement summarises how much a roster member improved in a benchmark since Since.
+type TeamImprovement struct {
+	Teammate          Teammate  `json:"teammate"`
+	Self              bool      `json:"self"`
+	Since             time.Time `json:"since"`
+	ProgressDelta     float64   `json:"progressDelta"`
+	RankDelta         int       `json:"rankDelta"`
+	EnergyDelta       float64   `json:"energyDelta"`
+	ImprovedScenarios int       `json:"improvedScenarios"`
+}
+
+// BenchmarkRankChange is how the user's benchmark rank and progress changed over a period,
+// from the stored daily progress snapshots nearest its bounds.
+type BenchmarkRankChange struct {
+	BenchmarkID  int       `json:"benchmarkId"`
+	Benchmark    string    `json:"benchmark"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	RankFrom     string    `json:"rankFrom"`
+	RankTo       string    `json:"rankTo"`
+	ProgressFrom float64   `json:"progressFrom"`
+	ProgressTo   float64   `json:"progressTo"`
+}
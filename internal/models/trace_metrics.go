@@ -0,0 +1,150 @@
+package models
+
+// TraceMetrics holds per-run movement metrics computed from a mouse trace.
+// Distances are in trace units (mouse counts), times in milliseconds and speeds in units per second.
+type TraceMetrics struct {
+	// AlgorithmVersion identifies the analysis that produced the metrics; stale results are recomputed.
+	AlgorithmVersion int     `json:"algorithmVersion"`
+	Points           int     `json:"points"`
+	DurationMs       int64   `json:"durationMs"`
+	PathLength       float64 `json:"pathLength"`
+	// Speed and Acceleration summarise the sample-to-sample profiles (moving samples only).
+	Speed        MotionProfile `json:"speed"`
+	Acceleration MotionProfile `json:"acceleration"`
+	// SpeedProfile is the average speed per ProfileBinMs bucket from the start of the trace.
+	SpeedProfile []float64 `json:"speedProfile,omitempty"`
+	ProfileBinMs int64     `json:"profileBinMs"`
+	// Smoothness is the mean log dimensionless jerk of all flicks (closer to 0 = smoother).
+	Smoothness float64 `json:"smoothness"`
+	// JerkRMS is the root mean square jerk magnitude over the whole run (units/s³).
+	JerkRMS      float64         `json:"jerkRms"`
+	Flicks       []Flick         `json:"flicks,omitempty"`
+	FlickSummary FlickSummary    `json:"flickSummary"`
+	Clicks       ClickTiming     `json:"clicks"`
+	Direction    DirectionalBias `json:"direction"`
+}
+
+// MotionProfile summarises a distribution of speed or acceleration samples.
+type MotionProfile struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+	Peak   float64 `json:"peak"`
+}
+
+// Flick is one aiming movement: a burst of motion from rest until the cursor settles again.
+type Flick struct {
+	// OnsetMs is the start of the movement relative to the first trace point.
+	OnsetMs      int64   `json:"onsetMs"`
+	DurationMs   int64   `json:"durationMs"`
+	TimeToPeakMs int64   `json:"timeToPeakMs"`
+	PeakVelocity float64 `json:"peakVelocity"`
+	// Amplitude is the straight-line distance between the onset and the settled position.
+	Amplitude float64 `json:"amplitude"`
+	// DirectionDeg is the movement direction in screen coordinates (0 = right, 90 = down).
+	DirectionDeg float64 `json:"directionDeg"`
+	// Overshoot is how far the cursor went past the settled position along the movement direction.
+	Overshoot float64 `json:"overshoot"`
+	// Corrections counts the re-accelerations after the primary submovement.
+	Corrections int `json:"corrections"`
+	// SettleTimeMs is the time from the end of the primary submovement until the cursor came to rest.
+	SettleTimeMs int64 `json:"settleTimeMs"`
+	// Smoothness is the log dimensionless jerk of this flick (closer to 0 = smoother).
+	Smoothness float64 `json:"smoothness"`
+}
+
+// FlickSummary aggregates the flicks of a run.
+type FlickSummary struct {
+	Count           int     `json:"count"`
+	AvgPeakVelocity float64 `json:"avgPeakVelocity"`
+	AvgAmplitude    float64 `json:"avgAmplitude"`
+	AvgOvershoot    float64 `json:"avgOvershoot"`
+	// OvershootRate is the fraction of flicks that overshot by more than a small share of their amplitude.
+	OvershootRate   float64 `json:"overshootRate"`
+	AvgCorrections  float64 `json:"avgCorrections"`
+	AvgSettleTimeMs float64 `json:"avgSettleTimeMs"`
+}
+
+// ClickTiming relates button presses to movement stops.
+type ClickTiming struct {
+	Clicks int `json:"clicks"`
+	// WhileMoving counts presses made before the cursor had settled.
+	WhileMoving int `json:"whileMoving"`
+	// AfterStop counts presses made once the cursor was at rest.
+	AfterStop int `json:"afterStop"`
+	// AvgDelayAfterStopMs and MedianDelayAfterStopMs measure the time from the last stop to the press.
+	AvgDelayAfterStopMs    float64 `json:"avgDelayAfterStopMs"`
+	MedianDelayAfterStopMs float64 `json:"medianDelayAfterStopMs"`
+}
+
+// DirectionalBias describes how movement and overshoot are distributed across directions.
+type DirectionalBias struct {
+	// Right, Left, Up and Down are the shares of the path length moved in each direction (sum to 1).
+	Right float64 `json:"right"`
+	Left  float64 `json:"left"`
+	Up    float64 `json:"up"`
+	Down  float64 `json:"down"`
+	// Horizontal is (right-left)/(right+left); Vertical is (down-up)/(down+up).
+	Horizontal float64 `json:"horizontal"`
+	Vertical   float64 `json:"vertical"`
+	// Overshoot* is the average overshoot of flicks whose dominant direction is that way.
+	OvershootRight float64 `json:"overshootRight"`
+	OvershootLeft  float64 `json:"overshootLeft"`
+	OvershootUp    float64 `json:"overshootUp"`
+	OvershootDown  float64 `json:"overshootDown"`
+}
+
+// TraceMetricsHistoryEntry pairs a stored run with its trace metrics for history views.
+// Per-flick details and the speed profile are omitted to keep the payload small.
+type TraceMetricsHistoryEntry struct {
+	StatsFile  string       `json:"statsFile"`
+	Scenario   string       `json:"scenario"`
+	DatePlayed string       `json:"datePlayed,omitempty"`
+	Score      float64      `json:"score,omitempty"`
+	Metrics    TraceMetrics `json:"metrics"`
+}
+
+// KillSegment covers one target of a run: the trace from the previous kill (or the run start) to this kill.
+type KillSegment struct {
+	// Kill is the kill number from the stats file.
+	Kill int `json:"kill"`
+	// StartMs and EndMs are relative to the first trace point.
+	StartMs int64   `json:"startMs"`
+	EndMs   int64   `json:"endMs"`
+	TTKSec  float64 `json:"ttkSec"`
+	Shots   float64 `json:"shots"`
+	Hits    float64 `json:"hits"`
+	// ReactionMs is the time from the segment start until the cursor started moving (-1 if it never did).
+	ReactionMs int64 `json:"reactionMs"`
+	// TimeToFirstShotMs is the time from the segment start to the first button press (-1 if none was recorded).
+	TimeToFirstShotMs int64   `json:"timeToFirstShotMs"`
+	PathLength        float64 `json:"pathLength"`
+	Distance          float64 `json:"distance"`
+	// PathEfficiency is Distance/PathLength in 0..1 (1 = perfectly straight).
+	PathEfficiency float64 `json:"pathEfficiency"`
+	// Overshoot is how far the cursor went past the kill position along the approach direction.
+	Overshoot float64 `json:"overshoot"`
+	// MicroCorrections counts the re-accelerations after the primary movement towards the target.
+	MicroCorrections int     `json:"microCorrections"`
+	PeakVelocity     float64 `json:"peakVelocity"`
+}
+
+// KillSegmentPhase averages the kill segments of one part of a run (early, middle, late).
+type KillSegmentPhase struct {
+	Phase                string  `json:"phase"`
+	Kills                int     `json:"kills"`
+	AvgReactionMs        float64 `json:"avgReactionMs"`
+	AvgTimeToFirstShotMs float64 `json:"avgTimeToFirstShotMs"`
+	AvgMicroCorrections  float64 `json:"avgMicroCorrections"`
+	AvgPathEfficiency    float64 `json:"avgPathEfficiency"`
+	AvgOvershoot         float64 `json:"avgOvershoot"`
+}
+
+// KillSegmentation is a run's trace split into per-target segments aligned with its kill events.
+type KillSegmentation struct {
+	Segments []KillSegment `json:"segments"`
+	// Phases splits the segments into thirds so degradation over the run is visible (empty below 3 kills).
+	Phases []KillSegmentPhase `json:"phases,omitempty"`
+	// Unaligned counts kill events that fall outside the recorded trace.
+	Unaligned int `json:"unaligned"`
+}
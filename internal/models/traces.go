@@ -0,0 +1,86 @@
+package models
+
+// TraceMigrationResult summarises a rewrite of stored traces into the current format.
+type TraceMigrationResult struct {
+	Scanned     int      `json:"scanned"`
+	Migrated    int      `json:"migrated"`
+	Skipped     int      `json:"skipped"`
+	Failed      int      `json:"failed"`
+	BytesBefore int64    `json:"bytesBefore"`
+	BytesAfter  int64    `json:"bytesAfter"`
+	Errors      []string `json:"errors,omitempty"`
+}
+
+// TraceRetentionPolicy controls which stored traces the pruner deletes. Zero values disable a rule.
+type TraceRetentionPolicy struct {
+	// MaxTotalMB caps the size of the traces directory; the oldest traces are removed first.
+	MaxTotalMB int `json:"maxTotalMB"`
+	// MaxAgeDays removes traces of runs played longer ago than this.
+	MaxAgeDays int `json:"maxAgeDays"`
+	// KeepOnlyPB keeps only traces of runs that set a new personal best when they were played.
+	KeepOnlyPB bool `json:"keepOnlyPB"`
+	// KeepLastPerScenario keeps only the N most recent traces of each scenario.
+	KeepLastPerScenario int `json:"keepLastPerScenario"`
+}
+
+// TracePruneResult reports what a pruning pass removed.
+type TracePruneResult struct {
+	Scanned        int      `json:"scanned"`
+	Deleted        int      `json:"deleted"`
+	Failed         int      `json:"failed"`
+	BytesReclaimed int64    `json:"bytesReclaimed"`
+	BytesRemaining int64    `json:"bytesRemaining"`
+	PrunedAt       string   `json:"prunedAt"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// TracesStorageStats summarises the traces directory.
+type TracesStorageStats struct {
+	Dir           string               `json:"dir"`
+	FileCount     int                  `json:"fileCount"`
+	TotalBytes    int64                `json:"totalBytes"`
+	ScenarioCount int                  `json:"scenarioCount"`
+	OldestPlayed  string               `json:"oldestPlayed,omitempty"`
+	NewestPlayed  string               `json:"newestPlayed,omitempty"`
+	Policy        TraceRetentionPolicy `json:"policy"`
+	LastPrune     *TracePruneResult    `json:"lastPrune,omitempty"`
+}
+
+// TraceIndexEntry describes one stored trace in the traces index.
+type TraceIndexEntry struct {
+	// Name is the trace file name inside the traces directory (".trace" or legacy ".json").
+	Name string `json:"name"`
+	// StatsFile is the Kovaak's stats CSV the trace was recorded for.
+	StatsFile  string  `json:"statsFile"`
+	Scenario   string  `json:"scenario"`
+	DatePlayed string  `json:"datePlayed,omitempty"`
+	Score      float64 `json:"score,omitempty"`
+	Points     int     `json:"points"`
+	DurationMs int64   `json:"durationMs"`
+	// Version is the binary format version (0 for legacy JSON).
+	Version int   `json:"version"`
+	Size    int64 `json:"size"`
+}
+
+// TraceExportOptions selects which stored traces to export and in which format.
+type TraceExportOptions struct {
+	// Format is "csv" (default) or "ndjson".
+	Format string `json:"format"`
+	// FileNames exports specific runs by stats file name; when set, Scenario/From/To are ignored.
+	FileNames []string `json:"fileNames,omitempty"`
+	Scenario  string   `json:"scenario,omitempty"`
+	// From and To bound the date played (inclusive), as YYYY-MM-DD or RFC3339.
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	// OutputDir receives one file per trace; defaults to a timestamped folder under the app's exports directory.
+	OutputDir string `json:"outputDir,omitempty"`
+}
+
+// TraceExportResult reports the files written by a trace export.
+type TraceExportResult struct {
+	Dir      string   `json:"dir"`
+	Exported int      `json:"exported"`
+	Failed   int      `json:"failed"`
+	Files    []string `json:"files"`
+	Errors   []string `json:"errors,omitempty"`
+}
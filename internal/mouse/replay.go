@@ -0,0 +1,201 @@
+package mouse
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/traces"
+)
+
+// ReplayOptions controls how a Replay plays back its trace.
+type ReplayOptions struct {
+	// Speed scales playback: 1 replays on the real clock, 10 ten times faster.
+	// 0 or less makes the whole trace available as soon as Start is called.
+	Speed float64
+	// Anchor is the time the first point is replayed at; zero means the time Start is called.
+	Anchor time.Time
+	// Now overrides the wall clock (useful in tests); nil uses time.Now.
+	Now func() time.Time
+}
+
+// Replay is a Provider that plays back a recorded trace instead of sampling a device.
+// Point timestamps keep their original spacing and are rebased onto Anchor; a point becomes
+// visible to GetRange once the replay clock (Anchor + elapsed × Speed) reaches it.
+// It works on every platform, so the capture → persist → load flow can run without hardware.
+type Replay struct {
+	mu        sync.RWMutex
+	offsets   []models.MousePoint // TS holds the offset in ms from the first point
+	opts      ReplayOptions
+	bufDur    time.Duration
+	running   bool
+	anchor    time.Time
+	startedAt time.Time
+	done      chan struct{}
+	timer     *time.Timer
+}
+
+// NewReplay returns a Replay of points, which may use any time base. Points are sorted by timestamp.
+func NewReplay(points []models.MousePoint, opts ReplayOptions) *Replay {
+	offsets := append([]models.MousePoint(nil), points...)
+	sort.SliceStable(offsets, func(i, j int) bool { return offsets[i].TS < offsets[j].TS })
+	if len(offsets) > 0 {
+		t0 := offsets[0].TS
+		for i := range offsets {
+			offsets[i].TS -= t0
+		}
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Replay{
+		offsets: offsets,
+		opts:    opts,
+		bufDur:  time.Duration(constants.DefaultMouseBufferMinutes) * time.Minute,
+		done:    make(chan struct{}),
+	}
+}
+
+// NewReplayFromFile loads a recorded trace and returns a Replay of it. Files ending in .csv are read
+// in the trace export format; anything else as a stored trace (binary or legacy JSON).
+func NewReplayFromFile(path string, opts ReplayOptions) (*Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var data traces.ScenarioData
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		data, err = traces.ReadCSV(f)
+	} else {
+		data, err = traces.ReadBinary(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read trace %s: %w", path, err)
+	}
+	return NewReplay(data.MouseTrace, opts), nil
+}
+
+// Start begins playback. No-op if already running.
+func (r *Replay) Start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return nil
+	}
+	r.running = true
+	r.startedAt = r.opts.Now()
+	r.anchor = r.opts.Anchor
+	if r.anchor.IsZero() {
+		r.anchor = r.startedAt
+	}
+	r.done = make(chan struct{})
+
+	// Time until the last point becomes visible
+	var wait time.Duration
+	if n := len(r.offsets); n > 0 && r.opts.Speed > 0 {
+		wait = time.Duration(float64(time.Duration(r.offsets[n-1].TS)*time.Millisecond) / r.opts.Speed)
+	}
+	done := r.done
+	if wait <= 0 {
+		close(done)
+	} else {
+		r.timer = time.AfterFunc(wait, func() { close(done) })
+	}
+	return nil
+}
+
+// Stop ends playback; Start replays the trace from the beginning. No-op if not running.
+func (r *Replay) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.running {
+		return
+	}
+	r.running = false
+	if r.timer != nil && r.timer.Stop() {
+		close(r.done)
+	}
+	r.timer = nil
+}
+
+// SetBufferDuration sets the retention window; points older than the replay clock minus d are hidden.
+func (r *Replay) SetBufferDuration(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bufDur = d
+}
+
+// Enabled reports whether playback is running.
+func (r *Replay) Enabled() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.running
+}
+
+// Done returns a channel closed once the whole trace has been played back, timed on the wall clock
+// (immediately when Speed is 0), or when playback is stopped.
+func (r *Replay) Done() <-chan struct{} {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.done
+}
+
+// Clock returns the current replay time (zero when not running).
+func (r *Replay) Clock() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.running {
+		return time.Time{}
+	}
+	return r.clockLocked()
+}
+
+// GetRange returns a copy of the replayed points in [start, end] with timestamps rebased onto the anchor.
+func (r *Replay) GetRange(start, end time.Time) []models.MousePoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.running || len(r.offsets) == 0 {
+		return nil
+	}
+	base := r.anchor.UnixMilli()
+	startMs, endMs := start.UnixMilli(), end.UnixMilli()
+	if r.opts.Speed > 0 {
+		now := r.clockLocked()
+		endMs = min(endMs, now.UnixMilli())
+		if r.bufDur > 0 {
+			startMs = max(startMs, now.Add(-r.bufDur).UnixMilli())
+		}
+	}
+
+	i := sort.Search(len(r.offsets), func(i int) bool { return base+r.offsets[i].TS >= startMs })
+	out := make([]models.MousePoint, 0, 256)
+	for ; i < len(r.offsets); i++ {
+		p := r.offsets[i]
+		p.TS += base
+		if p.TS > endMs {
+			break
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// clockLocked returns the replay time. Caller must hold r.mu.
+func (r *Replay) clockLocked() time.Time {
+	if r.opts.Speed <= 0 {
+		if n := len(r.offsets); n > 0 {
+			return r.anchor.Add(time.Duration(r.offsets[n-1].TS) * time.Millisecond)
+		}
+		return r.anchor
+	}
+	elapsed := r.opts.Now().Sub(r.startedAt)
+	return r.anchor.Add(time.Duration(float64(elapsed) * r.opts.Speed))
+}
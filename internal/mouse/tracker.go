@@ -7,7 +7,8 @@ import (
 )
 
 // Provider exposes a time-windowed mouse trace store.
-// Implementations may be OS-specific; on non-Windows platforms, this is a no-op.
+// Implementations are OS-specific (Windows raw input, Linux evdev); elsewhere New returns a no-op.
+// Replay plays back a recorded trace on any platform.
 // All methods are safe for concurrent use.
 type Provider interface {
 	// Start begins sampling (if supported on this platform). No-op if already running.
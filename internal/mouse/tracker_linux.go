@@ -0,0 +1,370 @@
+//go:build linux
+
+package mouse
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	appsettings "refleks/internal/settings"
+)
+
+// Linux evdev-based mouse tracker.
+// Reads relative motion (REL_X/REL_Y) and button (BTN_*) events from /dev/input/event* devices
+// and accumulates them, one point per SYN_REPORT, into an unbounded virtual coordinate space,
+// the same way the Windows raw input tracker does. Point timestamps are the kernel event times.
+
+type trackerLinux struct {
+	mu      sync.RWMutex
+	running bool
+	buf     []models.MousePoint
+	bufDur  time.Duration
+
+	// open devices and their reader goroutines
+	files []*os.File
+	wg    sync.WaitGroup
+
+	// accumulation
+	vx int32
+	vy int32
+	// current button state bitmask (left/right/middle/etc.)
+	buttons uint32
+	// logical start index into buf for lazy pruning/compaction
+	start int
+	// event time of the last prune (rate-limit pruning)
+	lastPrune time.Time
+}
+
+// New returns a new Linux mouse tracker reading evdev devices.
+func New(sampleHz int) Provider { // sampleHz unused for evdev
+	return &trackerLinux{
+		bufDur: time.Duration(constants.DefaultMouseBufferMinutes) * time.Minute,
+	}
+}
+
+func (t *trackerLinux) Start() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running {
+		return nil
+	}
+	paths, err := mouseDevicePaths()
+	if err != nil {
+		return err
+	}
+	var files []*os.File
+	denied := false
+	var firstErr error
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			denied = denied || errors.Is(err, os.ErrPermission)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		if denied {
+			return fmt.Errorf("permission denied reading mouse input devices (%s): add your user to the 'input' group (sudo usermod -aG input $USER) and log in again, or set %s to a readable device",
+				strings.Join(paths, ", "), constants.EnvMouseDeviceVar)
+		}
+		return fmt.Errorf("open mouse input devices: %w", firstErr)
+	}
+
+	t.running = true
+	t.files = files
+	for _, f := range files {
+		t.wg.Add(1)
+		go func(f *os.File) {
+			defer t.wg.Done()
+			_ = t.consume(f)
+		}(f)
+	}
+	return nil
+}
+
+func (t *trackerLinux) Stop() {
+	t.mu.Lock()
+	if !t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = false
+	files := t.files
+	t.files = nil
+	t.mu.Unlock()
+
+	// Closing the devices unblocks the pending reads
+	for _, f := range files {
+		_ = f.Close()
+	}
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func (t *trackerLinux) SetBufferDuration(d time.Duration) {
+	t.mu.Lock()
+	t.bufDur = d
+	// prune immediately (lazy: update start index, compact only occasionally)
+	t.pruneLocked(time.Now())
+	t.mu.Unlock()
+}
+
+func (t *trackerLinux) Enabled() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.running
+}
+
+func (t *trackerLinux) GetRange(start, end time.Time) []models.MousePoint {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if len(t.buf) == 0 {
+		return nil
+	}
+	startMs := start.UnixMilli()
+	endMs := end.UnixMilli()
+	out := make([]models.MousePoint, 0, 256)
+	for i := t.start; i < len(t.buf); i++ {
+		p := t.buf[i]
+		if p.TS < startMs {
+			continue
+		}
+		if p.TS > endMs {
+			break
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// pruneLocked drops samples older than now-bufDur. Caller must hold t.mu.
+func (t *trackerLinux) pruneLocked(now time.Time) {
+	cutoff := now.Add(-t.bufDur).UnixMilli()
+	j := t.start
+	for j < len(t.buf) && t.buf[j].TS < cutoff {
+		j++
+	}
+	if j > t.start {
+		t.start = j
+		// Compact underlying slice only when start grows large to avoid frequent copies
+		if t.start > 2048 {
+			t.buf = append([]models.MousePoint(nil), t.buf[t.start:]...)
+			t.start = 0
+		}
+	}
+	t.lastPrune = now
+}
+
+// --- evdev ---
+
+// Event types and codes from linux/input-event-codes.h
+const (
+	evSyn = 0x00
+	evKey = 0x01
+	evRel = 0x02
+
+	synReport  = 0
+	synDropped = 3
+
+	relX = 0x00
+	relY = 0x01
+
+	btnLeft   = 0x110
+	btnRight  = 0x111
+	btnMiddle = 0x112
+	btnSide   = 0x113
+	btnExtra  = 0x114
+)
+
+// buttonBits maps BTN_* codes to the models.MousePoint.Buttons bitmask.
+var buttonBits = map[uint16]uint32{
+	btnLeft:   1 << 0,
+	btnRight:  1 << 1,
+	btnMiddle: 1 << 2,
+	btnSide:   1 << 3,
+	btnExtra:  1 << 4,
+}
+
+// inputEvent mirrors struct input_event.
+type inputEvent struct {
+	time  time.Time
+	typ   uint16
+	code  uint16
+	value int32
+}
+
+// timevalSize is the size of struct timeval: two C longs.
+const timevalSize = 2 * strconv.IntSize / 8
+
+// inputEventSize is the size of struct input_event on this platform.
+const inputEventSize = timevalSize + 8
+
+// decodeInputEvent decodes one struct input_event in native byte order.
+func decodeInputEvent(b []byte) inputEvent {
+	var sec, usec int64
+	if timevalSize == 16 {
+		sec = int64(binary.NativeEndian.Uint64(b[0:]))
+		usec = int64(binary.NativeEndian.Uint64(b[8:]))
+	} else {
+		sec = int64(int32(binary.NativeEndian.Uint32(b[0:])))
+		usec = int64(int32(binary.NativeEndian.Uint32(b[4:])))
+	}
+	b = b[timevalSize:]
+	return inputEvent{
+		time:  time.Unix(sec, usec*1000),
+		typ:   binary.NativeEndian.Uint16(b[0:]),
+		code:  binary.NativeEndian.Uint16(b[2:]),
+		value: int32(binary.NativeEndian.Uint32(b[4:])),
+	}
+}
+
+// consume reads input events from r until it fails or is closed. Motion and button changes
+// are batched per SYN_REPORT; a SYN_DROPPED discards the partial report.
+func (t *trackerLinux) consume(r io.Reader) error {
+	br := bufio.NewReaderSize(r, 64*inputEventSize)
+	raw := make([]byte, inputEventSize)
+	var dx, dy int32
+	var down, up uint32
+	dropped := false
+	for {
+		if _, err := io.ReadFull(br, raw); err != nil {
+			return err
+		}
+		ev := decodeInputEvent(raw)
+		switch ev.typ {
+		case evRel:
+			switch ev.code {
+			case relX:
+				dx += ev.value
+			case relY:
+				dy += ev.value
+			}
+		case evKey:
+			if bit, ok := buttonBits[ev.code]; ok {
+				if ev.value != 0 {
+					down |= bit
+					up &^= bit
+				} else {
+					up |= bit
+					down &^= bit
+				}
+			}
+		case evSyn:
+			switch ev.code {
+			case synDropped:
+				dropped = true
+			case synReport:
+				if !dropped {
+					t.apply(ev.time, dx, dy, down, up)
+				}
+				dx, dy, down, up, dropped = 0, 0, 0, 0, false
+			}
+		}
+	}
+}
+
+// apply adds one report's motion and button changes and records a point if anything changed.
+func (t *trackerLinux) apply(now time.Time, dx, dy int32, down, up uint32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	changed := false
+	if dx != 0 || dy != 0 {
+		t.vx += dx
+		t.vy += dy
+		changed = true
+	}
+	if buttons := (t.buttons | down) &^ up; buttons != t.buttons {
+		t.buttons = buttons
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	t.buf = append(t.buf, models.MousePoint{TS: now.UnixMilli(), X: t.vx, Y: t.vy, Buttons: int32(t.buttons)})
+	// prune occasionally
+	if now.Sub(t.lastPrune) > time.Second || (len(t.buf)-t.start) > 16384 {
+		t.pruneLocked(now)
+	}
+}
+
+// mouseDevicePaths returns the event devices to read: the comma-separated paths in
+// EnvMouseDeviceVar when set, otherwise every device reporting relative X/Y motion.
+func mouseDevicePaths() ([]string, error) {
+	if v := strings.TrimSpace(appsettings.GetEnv(constants.EnvMouseDeviceVar)); v != "" {
+		var out []string
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+		return out, nil
+	}
+	f, err := os.Open("/proc/bus/input/devices")
+	if err != nil {
+		return nil, fmt.Errorf("list input devices: %w", err)
+	}
+	defer f.Close()
+	out := parseInputDevices(f)
+	if len(out) == 0 {
+		return nil, errors.New("no mouse input device found")
+	}
+	return out, nil
+}
+
+// parseInputDevices parses /proc/bus/input/devices and returns the /dev/input/event* paths
+// of the devices whose REL capability includes both REL_X and REL_Y.
+func parseInputDevices(r io.Reader) []string {
+	var out []string
+	var event string
+	var rel uint64
+	flush := func() {
+		if event != "" && rel&(1<<relX|1<<relY) == 1<<relX|1<<relY {
+			out = append(out, filepath.Join("/dev/input", event))
+		}
+		event, rel = "", 0
+	}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "H: Handlers="):
+			for _, h := range strings.Fields(strings.TrimPrefix(line, "H: Handlers=")) {
+				if strings.HasPrefix(h, "event") {
+					event = h
+				}
+			}
+		case strings.HasPrefix(line, "B: REL="):
+			// Bitmask words are space-separated, most significant first; REL_X/REL_Y live in the last one
+			words := strings.Fields(strings.TrimPrefix(line, "B: REL="))
+			if len(words) > 0 {
+				rel, _ = strconv.ParseUint(words[len(words)-1], 16, 64)
+			}
+		}
+	}
+	flush()
+	return out
+}
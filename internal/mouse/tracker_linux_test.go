@@ -0,0 +1,148 @@
+//go:build linux
+
+package mouse
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"refleks/internal/models"
+)
+
+var fixtureBase = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// ev is a fixture input event at ms milliseconds after fixtureBase.
+type ev struct {
+	ms    int64
+	typ   uint16
+	code  uint16
+	value int32
+}
+
+func rel(ms int64, code uint16, v int32) ev { return ev{ms, evRel, code, v} }
+func key(ms int64, code uint16, v int32) ev { return ev{ms, evKey, code, v} }
+func syn(ms int64) ev                       { return ev{ms, evSyn, synReport, 0} }
+func dropped(ms int64) ev                   { return ev{ms, evSyn, synDropped, 0} }
+
+// encodeEvents writes events as struct input_event in native byte order.
+func encodeEvents(events []ev) []byte {
+	var buf bytes.Buffer
+	for _, e := range events {
+		b := make([]byte, inputEventSize)
+		t := fixtureBase.Add(time.Duration(e.ms) * time.Millisecond)
+		sec, usec := t.Unix(), int64(t.Nanosecond()/1000)
+		if timevalSize == 16 {
+			binary.NativeEndian.PutUint64(b[0:], uint64(sec))
+			binary.NativeEndian.PutUint64(b[8:], uint64(usec))
+		} else {
+			binary.NativeEndian.PutUint32(b[0:], uint32(sec))
+			binary.NativeEndian.PutUint32(b[4:], uint32(usec))
+		}
+		rest := b[timevalSize:]
+		binary.NativeEndian.PutUint16(rest[0:], e.typ)
+		binary.NativeEndian.PutUint16(rest[2:], e.code)
+		binary.NativeEndian.PutUint32(rest[4:], uint32(e.value))
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+func pt(ms int64, x, y int32, buttons int32) models.MousePoint {
+	return models.MousePoint{TS: fixtureBase.UnixMilli() + ms, X: x, Y: y, Buttons: buttons}
+}
+
+func TestConsume(t *testing.T) {
+	tests := []struct {
+		name   string
+		bufDur time.Duration
+		events []ev
+		want   []models.MousePoint
+	}{
+		{
+			name: "relative motion accumulates per report",
+			events: []ev{
+				rel(0, relX, 3), rel(0, relY, -2), rel(0, relX, 1), syn(0),
+				rel(8, relX, -5), syn(8),
+				rel(16, relY, 7), syn(16),
+			},
+			want: []models.MousePoint{pt(0, 4, -2, 0), pt(8, -1, -2, 0), pt(16, -1, 5, 0)},
+		},
+		{
+			name: "empty and unknown reports add no point",
+			events: []ev{
+				syn(0),
+				{4, evRel, 0x08, 1}, syn(4), // REL_WHEEL
+				{6, evKey, 0x1e, 1}, syn(6), // KEY_A
+				rel(8, relX, 2), syn(8),
+			},
+			want: []models.MousePoint{pt(8, 2, 0, 0)},
+		},
+		{
+			name: "button press and release",
+			events: []ev{
+				key(0, btnLeft, 1), syn(0),
+				rel(4, relX, 1), key(4, btnRight, 1), syn(4),
+				key(8, btnLeft, 0), syn(8),
+				key(12, btnRight, 0), key(12, btnExtra, 1), syn(12),
+				key(16, btnExtra, 1), syn(16), // autorepeat: no change
+			},
+			want: []models.MousePoint{pt(0, 0, 0, 1), pt(4, 1, 0, 3), pt(8, 1, 0, 2), pt(12, 1, 0, 16)},
+		},
+		{
+			name: "press and release within one report",
+			events: []ev{
+				key(0, btnMiddle, 1), key(0, btnMiddle, 0), rel(0, relY, 1), syn(0),
+			},
+			want: []models.MousePoint{pt(0, 0, 1, 0)},
+		},
+		{
+			name: "SYN_DROPPED discards the partial report and resyncs on the next",
+			events: []ev{
+				rel(0, relX, 1), syn(0),
+				rel(4, relX, 100), key(4, btnLeft, 1), dropped(4), rel(4, relY, 100), syn(4),
+				rel(8, relX, 2), syn(8),
+			},
+			want: []models.MousePoint{pt(0, 1, 0, 0), pt(8, 3, 0, 0)},
+		},
+		{
+			name:   "points older than the buffer are pruned",
+			bufDur: 2 * time.Second,
+			events: []ev{
+				rel(0, relX, 1), syn(0),
+				rel(1500, relX, 1), syn(1500),
+				rel(5000, relX, 1), syn(5000),
+			},
+			want: []models.MousePoint{pt(5000, 3, 0, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := New(0).(*trackerLinux)
+			if tt.bufDur > 0 {
+				tr.bufDur = tt.bufDur
+			}
+			if err := tr.consume(bytes.NewReader(encodeEvents(tt.events))); err != io.EOF {
+				t.Fatalf("consume = %v, want io.EOF", err)
+			}
+			got := tr.GetRange(fixtureBase.Add(-time.Hour), fixtureBase.Add(time.Hour))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("points = %+v\nwant     %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConsumeTruncatedEvent(t *testing.T) {
+	tr := New(0).(*trackerLinux)
+	b := encodeEvents([]ev{rel(0, relX, 1), syn(0)})
+	if err := tr.consume(bytes.NewReader(b[:len(b)-3])); err != io.ErrUnexpectedEOF {
+		t.Fatalf("consume = %v, want io.ErrUnexpectedEOF", err)
+	}
+	if got := tr.GetRange(fixtureBase.Add(-time.Hour), fixtureBase.Add(time.Hour)); len(got) != 0 {
+		t.Errorf("points = %+v, want none", got)
+	}
+}
@@ -1,4 +1,4 @@
-//go:build !windows
+//go:build !windows && !linux
 
 package mouse
 
@@ -13,7 +13,7 @@ type trackerNoop struct {
 	bufDur time.Duration
 }
 
-// New returns a no-op tracker on non-Windows platforms.
+// New returns a no-op tracker on platforms without a native tracker.
 func New(sampleHz int) Provider {
 	return &trackerNoop{bufDur: time.Duration(constants.DefaultMouseBufferMinutes) * time.Minute}
 }
@@ -0,0 +1,190 @@
+package plans
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// Schedule kinds
+const (
+	ScheduleOnce   = ""
+	ScheduleDaily  = "daily"
+	ScheduleWeekly = "weekly"
+)
+
+// period returns the scheduled period containing t, in local time. Plans without a schedule have a
+// single unbounded period (zero start and end). ok is false on the days a daily plan is not scheduled.
+func period(s models.PlanSchedule, t time.Time) (start, end time.Time, ok bool) {
+	t = t.Local()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	switch s.Kind {
+	case ScheduleDaily:
+		return day, day.AddDate(0, 0, 1), len(s.Weekdays) == 0 || slices.Contains(s.Weekdays, int(t.Weekday()))
+	case ScheduleWeekly:
+		start = day.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+		return start, start.AddDate(0, 0, 7), true
+	default:
+		return time.Time{}, time.Time{}, true
+	}
+}
+
+// inPeriod reports whether t lies in [start, end); a zero bound is open.
+func inPeriod(t, start, end time.Time) bool {
+	return (start.IsZero() || !t.Before(start)) && (end.IsZero() || t.Before(end))
+}
+
+// ProgressOf computes the progress of a plan in its current period.
+func ProgressOf(p models.Plan) models.PlanProgress {
+	return progressAt(p, time.Now())
+}
+
+// progressAt computes the progress of a plan in the period containing t.
+func progressAt(p models.Plan, t time.Time) models.PlanProgress {
+	start, end, ok := period(p.Schedule, t)
+	out := progressBetween(p, start, end)
+	out.Scheduled = ok
+	if !start.IsZero() {
+		out.PeriodStart, out.PeriodEnd = &start, &end
+	}
+	out.Next = nextItem(out.Items, cursorAt(p, start, end))
+	return out
+}
+
+// progressBetween computes the progress of a plan from its runs in [start, end) since activation.
+func progressBetween(p models.Plan, start, end time.Time) models.PlanProgress {
+	out := models.PlanProgress{PlanID: p.ID, Items: make([]models.PlanItemProgress, len(p.Items)), Next: -1}
+	for _, r := range p.Runs {
+		if r.Item < 0 || r.Item >= len(p.Items) || !inPeriod(r.PlayedAt, start, end) || (p.ActivatedAt != nil && r.PlayedAt.Before(*p.ActivatedAt)) {
+			continue
+		}
+		ip := &out.Items[r.Item]
+		ip.Done++
+		ip.Minutes += r.Seconds / 60
+		if r.Score > ip.BestScore {
+			ip.BestScore = r.Score
+		}
+	}
+	var sum float64
+	for i, it := range p.Items {
+		ip := &out.Items[i]
+		ip.TargetHit = it.TargetScore > 0 && ip.BestScore >= it.TargetScore
+		f := itemFraction(it, *ip)
+		ip.Complete = f >= 1
+		if ip.Complete {
+			out.Completed++
+		}
+		sum += f
+	}
+	if len(p.Items) > 0 {
+		out.Percent = sum / float64(len(p.Items)) * 100
+	}
+	return out
+}
+
+// itemFraction returns the share of an item's run and playtime goals met, 0..1 (the lower of the two).
+func itemFraction(it models.PlanItem, ip models.PlanItemProgress) float64 {
+	f := 1.0
+	if it.Runs > 0 {
+		f = min(f, float64(ip.Done)/float64(it.Runs))
+	}
+	if it.TargetMins > 0 {
+		f = min(f, ip.Minutes/it.TargetMins)
+	}
+	return f
+}
+
+// cursorAt returns the plan's cursor if it was moved in [start, end), else 0.
+func cursorAt(p models.Plan, start, end time.Time) int {
+	if p.CursorAt == nil || !inPeriod(*p.CursorAt, start, end) || p.Cursor < 0 || p.Cursor >= len(p.Items) {
+		return 0
+	}
+	return p.Cursor
+}
+
+// nextItem returns the first incomplete item from cursor on, wrapping around, or -1.
+func nextItem(items []models.PlanItemProgress, cursor int) int {
+	for k := range items {
+		i := (cursor + k) % len(items)
+		if !items[i].Complete {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchItem returns the item a run of scenario played at t counts towards: the first incomplete item
+// of that scenario in its period, else the last one (extra runs), or -1 if the plan doesn't include it
+// or isn't scheduled then.
+func matchItem(p models.Plan, scenario string, t time.Time) int {
+	prog := progressAt(p, t)
+	if !prog.Scheduled {
+		return -1
+	}
+	last := -1
+	for i, it := range p.Items {
+		if !strings.EqualFold(it.Scenario, scenario) {
+			continue
+		}
+		if !prog.Items[i].Complete {
+			return i
+		}
+		last = i
+	}
+	return last
+}
+
+// AdherenceOf summarises the completed periods of a plan from its activation until now (or, for an
+// inactive plan, until its last matched run).
+func AdherenceOf(p models.Plan) models.PlanAdherence {
+	out := models.PlanAdherence{PlanID: p.ID, History: []models.PlanPeriod{}}
+	if p.ActivatedAt == nil {
+		return out
+	}
+	until := time.Now()
+	if !p.Active {
+		until = *p.ActivatedAt
+		if n := len(p.Runs); n > 0 && p.Runs[n-1].PlayedAt.After(until) {
+			until = p.Runs[n-1].PlayedAt
+		}
+	}
+	var periods []models.PlanPeriod
+	if p.Schedule.Kind == ScheduleOnce {
+		prog := progressBetween(p, time.Time{}, time.Time{})
+		periods = append(periods, models.PlanPeriod{Start: *p.ActivatedAt, End: until, Percent: prog.Percent, Complete: prog.Completed == len(p.Items)})
+	} else {
+		for t := *p.ActivatedAt; !t.After(until); {
+			start, end, ok := period(p.Schedule, t)
+			if ok {
+				prog := progressBetween(p, start, end)
+				periods = append(periods, models.PlanPeriod{Start: start, End: end, Percent: prog.Percent, Complete: prog.Completed == len(p.Items)})
+			}
+			t = end
+		}
+	}
+
+	cur := 0
+	for i, pp := range periods {
+		out.Periods++
+		if pp.Complete {
+			out.Completed++
+			cur++
+			out.BestStreak = max(out.BestStreak, cur)
+		} else if i < len(periods)-1 || !p.Active {
+			// The current period can still be completed
+			cur = 0
+		}
+	}
+	out.CurrentStreak = cur
+	if out.Periods > 0 {
+		out.Percent = float64(out.Completed) / float64(out.Periods) * 100
+	}
+	if n := len(periods); n > constants.PlanAdherenceMaxPeriods {
+		periods = periods[n-constants.PlanAdherenceMaxPeriods:]
+	}
+	out.History = append(out.History, periods...)
+	return out
+}
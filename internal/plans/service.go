@@ -0,0 +1,437 @@
+// Package plans stores training plans and tracks them, period by period, against the runs the watcher parses.
+package plans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/settings"
+	"refleks/internal/util"
+)
+
+// Plan sources
+const (
+	SourceAI   = "ai"
+	SourceUser = "user"
+)
+
+// ErrNotFound is returned for an unknown plan ID.
+var ErrNotFound = errors.New("plan not found")
+
+// Service manages the training plans of the active profile ($HOME/.refleks/plans.json).
+type Service struct {
+	ctx         context.Context
+	settingsSvc *settings.Service
+
+	mu     sync.Mutex
+	plans  []models.Plan
+	loaded bool
+}
+
+// NewService creates a new plans service.
+func NewService(ctx context.Context, settingsSvc *settings.Service) *Service {
+	return &Service{ctx: ctx, settingsSvc: settingsSvc}
+}
+
+// Reload drops the loaded plans so they are read again from the active profile.
+func (s *Service) Reload() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.plans, s.loaded = nil, false
+}
+
+// List returns all plans, newest first.
+func (s *Service) List() []models.Plan {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	out := append([]models.Plan(nil), s.plans...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.After(out[j].CreatedAt) })
+	return out
+}
+
+// Get returns a plan by ID.
+func (s *Service) Get(id string) (models.Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	if i := s.indexLocked(id); i >= 0 {
+		return s.plans[i], nil
+	}
+	return models.Plan{}, ErrNotFound
+}
+
+// Save validates and stores a plan. A plan without ID is created; otherwise the stored plan's
+// definition is replaced, keeping its activation and matched runs.
+func (s *Service) Save(p models.Plan) (models.Plan, error) {
+	p = normalize(p)
+	if err := Validate(p); err != nil {
+		return models.Plan{}, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	now := time.Now()
+	p.UpdatedAt = now
+	if p.ID == "" {
+		p.ID = uuid.NewString()
+		p.CreatedAt = now
+		p.Runs = nil
+		if p.Active {
+			p.ActivatedAt = &now
+		}
+		if p.Source == "" {
+			p.Source = SourceUser
+		}
+		s.plans = append(s.plans, p)
+	} else {
+		i := s.indexLocked(p.ID)
+		if i < 0 {
+			return models.Plan{}, ErrNotFound
+		}
+		old := s.plans[i]
+		p.CreatedAt, p.Source, p.Active, p.ActivatedAt, p.Runs = old.CreatedAt, old.Source, old.Active, old.ActivatedAt, old.Runs
+		p.Cursor, p.CursorAt = 0, nil
+		// Items may have been reordered or removed: drop runs whose item no longer matches
+		kept := p.Runs[:0:0]
+		for _, r := range p.Runs {
+			if r.Item < len(old.Items) {
+				if j := itemIndex(p.Items, old.Items[r.Item].Scenario); j >= 0 {
+					r.Item = j
+					kept = append(kept, r)
+				}
+			}
+		}
+		p.Runs = kept
+		s.plans[i] = p
+	}
+	if err := s.saveLocked(); err != nil {
+		return models.Plan{}, err
+	}
+	return p, nil
+}
+
+// Delete removes a plan.
+func (s *Service) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return ErrNotFound
+	}
+	s.plans = append(s.plans[:i], s.plans[i+1:]...)
+	return s.saveLocked()
+}
+
+// SetActive activates or deactivates a plan. Activating restarts progress and adherence: only runs
+// played from now on count towards it.
+func (s *Service) SetActive(id string, active bool) (models.Plan, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	i := s.indexLocked(id)
+	if i < 0 {
+		return models.Plan{}, ErrNotFound
+	}
+	p := &s.plans[i]
+	if active && !p.Active {
+		now := time.Now()
+		p.ActivatedAt = &now
+		p.Runs, p.Cursor, p.CursorAt = nil, 0, nil
+	}
+	p.Active = active
+	p.UpdatedAt = time.Now()
+	if err := s.saveLocked(); err != nil {
+		return models.Plan{}, err
+	}
+	return *p, nil
+}
+
+// Progress returns the progress of a plan in its current period.
+func (s *Service) Progress(id string) (models.PlanProgress, error) {
+	p, err := s.Get(id)
+	if err != nil {
+		return models.PlanProgress{}, err
+	}
+	return ProgressOf(p), nil
+}
+
+// Adherence returns the completion stats of a plan over its scheduled periods.
+func (s *Service) Adherence(id string) (models.PlanAdherence, error) {
+	p, err := s.Get(id)
+	if err != nil {
+		return models.PlanAdherence{}, err
+	}
+	return AdherenceOf(p), nil
+}
+
+// Advance moves the cursor of the active plans that include scenario past its item, so launching
+// the plan again opens the item after it. It is called whenever a scenario is launched.
+func (s *Service) Advance(scenario string) {
+	now := time.Now()
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	var updated []models.Plan
+	for i := range s.plans {
+		p := &s.plans[i]
+		if !p.Active || len(p.Items) == 0 {
+			continue
+		}
+		start, end, _ := period(p.Schedule, now)
+		cur := cursorAt(*p, start, end)
+		item := -1
+		for k := range p.Items {
+			if j := (cur + k) % len(p.Items); strings.EqualFold(p.Items[j].Scenario, scenario) {
+				item = j
+				break
+			}
+		}
+		if item < 0 {
+			continue
+		}
+		p.Cursor, p.CursorAt = (item+1)%len(p.Items), &now
+		updated = append(updated, *p)
+	}
+	if len(updated) > 0 {
+		if err := s.saveLocked(); err != nil {
+			runtime.LogWarningf(s.ctx, "plans: save: %v", err)
+		}
+	}
+	s.mu.Unlock()
+	s.emitUpdated(updated)
+}
+
+// RecordRun matches a parsed run against the active plans. Runs played before a plan's activation,
+// on days it is not scheduled, runs already matched and scenarios outside the plan are ignored. Updated plans are saved and
+// announced with EventPlanUpdated.
+func (s *Service) RecordRun(rec models.ScenarioRecord) {
+	name, _ := rec.Stats["Scenario"].(string)
+	playedAt := util.DatePlayed(rec)
+	if strings.TrimSpace(name) == "" || playedAt.IsZero() {
+		return
+	}
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	var updated []models.Plan
+	for i := range s.plans {
+		p := &s.plans[i]
+		if !p.Active || p.ActivatedAt == nil || playedAt.Before(*p.ActivatedAt) || hasRun(p.Runs, rec.FileName) {
+			continue
+		}
+		item := matchItem(*p, name, playedAt)
+		if item < 0 {
+			continue
+		}
+		p.Runs = append(p.Runs, models.PlanRun{FileName: rec.FileName, Item: item, Score: util.ToFloat(rec.Stats["Score"]), Seconds: util.ToFloat(rec.Stats["Duration"]), PlayedAt: playedAt})
+		sort.SliceStable(p.Runs, func(a, b int) bool { return p.Runs[a].PlayedAt.Before(p.Runs[b].PlayedAt) })
+		if n := len(p.Runs); n > constants.PlanMaxStoredRuns {
+			p.Runs = append([]models.PlanRun(nil), p.Runs[n-constants.PlanMaxStoredRuns:]...)
+		}
+		updated = append(updated, *p)
+	}
+	if len(updated) > 0 {
+		if err := s.saveLocked(); err != nil {
+			runtime.LogWarningf(s.ctx, "plans: save: %v", err)
+		}
+	}
+	s.mu.Unlock()
+	s.emitUpdated(updated)
+}
+
+// emitUpdated announces updated plans with their progress and adherence.
+func (s *Service) emitUpdated(plans []models.Plan) {
+	for _, p := range plans {
+		runtime.EventsEmit(s.ctx, constants.EventPlanUpdated, map[string]any{"plan": p, "progress": ProgressOf(p), "adherence": AdherenceOf(p)})
+	}
+}
+
+// Validate checks that a plan is well-formed.
+func Validate(p models.Plan) error {
+	var errs []string
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, "name is required")
+	}
+	if len(p.Items) == 0 {
+		errs = append(errs, "at least one item is required")
+	}
+	if len(p.Items) > constants.PlanMaxItems {
+		errs = append(errs, fmt.Sprintf("at most %d items are allowed", constants.PlanMaxItems))
+	}
+	switch p.Schedule.Kind {
+	case ScheduleOnce, ScheduleDaily, ScheduleWeekly:
+	default:
+		errs = append(errs, fmt.Sprintf("unknown schedule %q", p.Schedule.Kind))
+	}
+	for _, d := range p.Schedule.Weekdays {
+		if d < 0 || d > 6 {
+			errs = append(errs, "schedule weekdays must be between 0 (Sunday) and 6")
+			break
+		}
+	}
+	for i, it := range p.Items {
+		if strings.TrimSpace(it.Scenario) == "" {
+			errs = append(errs, fmt.Sprintf("items[%d].scenario is required", i))
+		}
+		if it.Runs < 0 || it.Runs > constants.PlanMaxRunsPerItem {
+			errs = append(errs, fmt.Sprintf("items[%d].runs must be between 0 and %d", i, constants.PlanMaxRunsPerItem))
+		}
+		if it.TargetMins < 0 || it.TargetMins > constants.PlanMaxTargetMinutes {
+			errs = append(errs, fmt.Sprintf("items[%d].targetMinutes must be between 0 and %d", i, constants.PlanMaxTargetMinutes))
+		}
+		if it.Runs == 0 && it.TargetMins == 0 {
+			errs = append(errs, fmt.Sprintf("items[%d] needs a run count or a playtime goal", i))
+		}
+		if it.TargetScore < 0 {
+			errs = append(errs, fmt.Sprintf("items[%d].targetScore must not be negative", i))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// normalize trims the text fields of a plan.
+func normalize(p models.Plan) models.Plan {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Description = strings.TrimSpace(p.Description)
+	p.SensitivityNote = strings.TrimSpace(p.SensitivityNote)
+	p.Sharecode = strings.TrimSpace(p.Sharecode)
+	items := make([]models.PlanItem, len(p.Items))
+	for i, it := range p.Items {
+		it.Scenario = strings.TrimSpace(it.Scenario)
+		it.Focus = strings.TrimSpace(it.Focus)
+		it.SensNote = strings.TrimSpace(it.SensNote)
+		items[i] = it
+	}
+	p.Items = items
+	p.Schedule.Kind = strings.ToLower(strings.TrimSpace(p.Schedule.Kind))
+	if p.Schedule.Kind == ScheduleDaily {
+		slices.Sort(p.Schedule.Weekdays)
+		p.Schedule.Weekdays = slices.Compact(p.Schedule.Weekdays)
+	} else {
+		p.Schedule.Weekdays = nil
+	}
+	return p
+}
+
+// itemIndex returns the index of the first item of scenario, or -1.
+func itemIndex(items []models.PlanItem, scenario string) int {
+	for i, it := range items {
+		if strings.EqualFold(it.Scenario, scenario) {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasRun(runs []models.PlanRun, fileName string) bool {
+	for _, r := range runs {
+		if r.FileName == fileName {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) indexLocked(id string) int {
+	for i := range s.plans {
+		if s.plans[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ensureLoadedLocked lazily loads the plans file. Caller must hold s.mu.
+func (s *Service) ensureLoadedLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.plans = nil
+	var plans []models.Plan
+	if err := settings.ReadDataJSON(constants.PlansFileName, &plans); err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			runtime.LogWarningf(s.ctx, "plans: read %s: %v", constants.PlansFileName, err)
+		}
+		return
+	}
+	s.plans = plans
+}
+
+// saveLocked writes the plans file atomically. Caller must hold s.mu.
+func (s *Service) saveLocked() error {
+	plans := s.plans
+	if plans == nil {
+		plans = []models.Plan{}
+	}
+	return settings.WriteDataJSON(constants.PlansFileName, plans)
+}
+
+// kovaaksPlaylist is the playlist file format of Kovaak's.
+type kovaaksPlaylist struct {
+	PlaylistName  string                    `json:"playlistName"`
+	PlaylistID    int                       `json:"playlistId"`
+	AuthorName    string                    `json:"authorName"`
+	AuthorSteamID string                    `json:"authorSteamId"`
+	Description   string                    `json:"description"`
+	ScenarioList  []kovaaksPlaylistScenario `json:"scenarioList"`
+}
+
+type kovaaksPlaylistScenario struct {
+	ScenarioName string `json:"scenario_name"`
+	PlayCount    int    `json:"play_Count"`
+}
+
+// WritePlaylist writes a plan as a playlist into Kovaak's playlists folder (next to the stats
+// directory) so it shows up in the game, and returns the file path. An existing playlist of the
+// same name is overwritten.
+func (s *Service) WritePlaylist(p models.Plan) (string, error) {
+	statsDir := settings.StatsDirOf(s.settingsSvc.Get())
+	dir := filepath.Join(filepath.Dir(filepath.Clean(statsDir)), filepath.FromSlash(constants.KovaaksPlaylistsRelDir))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create playlists folder: %w", err)
+	}
+	name := "RefleKs - " + p.Name
+	pl := kovaaksPlaylist{PlaylistName: name, Description: p.Description, ScenarioList: make([]kovaaksPlaylistScenario, 0, len(p.Items))}
+	for _, it := range p.Items {
+		pl.ScenarioList = append(pl.ScenarioList, kovaaksPlaylistScenario{ScenarioName: it.Scenario, PlayCount: it.Runs})
+	}
+	b, err := json.MarshalIndent(pl, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, playlistFileName(name))
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// playlistFileName turns a playlist name into a safe file name.
+func playlistFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) || r < 32 {
+			return '_'
+		}
+		return r
+	}, name)
+	return strings.TrimSpace(name) + ".json"
+}
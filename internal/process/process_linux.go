@@ -0,0 +1,70 @@
+//go:build linux
+
+package process
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isRunning scans /proc for a process named name. Windows executables running under Wine/Proton
+// are matched by the executable in their command line (a Windows path) or their truncated comm name.
+func isRunning(name string) bool {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return false
+	}
+	for _, e := range entries {
+		if !e.IsDir() || !isPID(e.Name()) {
+			continue
+		}
+		dir := filepath.Join("/proc", e.Name())
+		if cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil && cmdlineMatches(cmdline, name) {
+			return true
+		}
+		if comm, err := os.ReadFile(filepath.Join(dir, "comm")); err == nil && commMatches(string(comm), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cmdlineMatches reports whether the executable (argv[0]) of a NUL-separated /proc cmdline is name.
+func cmdlineMatches(cmdline []byte, name string) bool {
+	argv0, _, _ := bytes.Cut(cmdline, []byte{0})
+	return strings.EqualFold(exeBase(string(argv0)), name)
+}
+
+// commMatches reports whether a /proc comm value is name. The kernel truncates comm to 15 bytes.
+func commMatches(comm, name string) bool {
+	comm = strings.TrimSpace(comm)
+	if comm == "" {
+		return false
+	}
+	if len(comm) >= 15 && len(name) > len(comm) {
+		return strings.EqualFold(name[:len(comm)], comm)
+	}
+	return strings.EqualFold(comm, name)
+}
+
+// exeBase returns the file name of a Unix or Windows (Wine) executable path.
+func exeBase(p string) string {
+	if i := strings.LastIndexAny(p, `/\`); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
+
+func isPID(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+// Package profiles manages local player profiles bound to Steam accounts, so several players
+// sharing a PC keep separate settings, caches and history.
+package profiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/settings"
+	"refleks/internal/steam"
+)
+
+// Service lists, switches and tracks run ownership of profiles.
+type Service struct {
+	mu           sync.Mutex
+	settingsSvc  *settings.Service
+	owners       map[string]string // stats file name -> profile ID that played it
+	ownersLoaded bool
+}
+
+// NewService creates a new profiles service.
+func NewService(settingsSvc *settings.Service) *Service {
+	return &Service{settingsSvc: settingsSvc}
+}
+
+// List returns the default profile, one profile per Steam account on this machine,
+// and any other profile with data on disk. The active profile is flagged.
+func (s *Service) List() []models.Profile {
+	active := settings.ActiveProfileID()
+	out := []models.Profile{{ID: constants.DefaultProfileID, Active: active == constants.DefaultProfileID, Exists: true}}
+	seen := map[string]bool{constants.DefaultProfileID: true}
+
+	accounts, _ := steam.ListAccounts(s.settingsSvc.Get())
+	for _, a := range accounts {
+		if seen[a.SteamID] || !settings.ValidProfileID(a.SteamID) {
+			continue
+		}
+		seen[a.SteamID] = true
+		out = append(out, models.Profile{
+			ID:          a.SteamID,
+			SteamID:     a.SteamID,
+			AccountName: a.AccountName,
+			PersonaName: a.PersonaName,
+			Active:      a.SteamID == active,
+			Exists:      settings.ProfileExists(a.SteamID),
+		})
+	}
+	for _, id := range settings.ProfileIDs() {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, models.Profile{ID: id, SteamID: id, Active: id == active, Exists: true})
+	}
+	return out
+}
+
+// Active returns the active profile.
+func (s *Service) Active() models.Profile {
+	for _, p := range s.List() {
+		if p.Active {
+			return p
+		}
+	}
+	id := settings.ActiveProfileID()
+	return models.Profile{ID: id, SteamID: id, Active: true, Exists: true}
+}
+
+// Switch makes id the active profile and loads its settings into the settings service.
+// A Steam account's profile is created on first use, seeded with the current machine settings
+// (paths, appearance, tracking) but none of the previous player's notes, favorites or team.
+// Callers are responsible for restarting the services that depend on settings and caches.
+func (s *Service) Switch(id string) (models.Profile, error) {
+	if !settings.ValidProfileID(id) {
+		return models.Profile{}, fmt.Errorf("invalid profile id: %q", id)
+	}
+	prev := settings.ActiveProfileID()
+	if id == prev {
+		return s.Active(), nil
+	}
+
+	create := id != constants.DefaultProfileID && !settings.ProfileExists(id)
+	var seed models.Settings
+	if create {
+		seed = newProfileSettings(s.settingsSvc.Get())
+	}
+	if err := settings.SetActiveProfileID(id); err != nil {
+		return models.Profile{}, err
+	}
+	var err error
+	if create {
+		err = s.settingsSvc.Update(seed)
+	} else {
+		err = s.settingsSvc.Load()
+	}
+	if err != nil {
+		// Roll back so settings and data directory stay consistent
+		_ = settings.SetActiveProfileID(prev)
+		_ = s.settingsSvc.Load()
+		return models.Profile{}, err
+	}
+	return s.Active(), nil
+}
+
+// AdmitRun decides whether a stats file belongs to the active profile's history. Runs that appear
+// while the app is watching (live) are claimed by the active profile; runs claimed by another profile
+// are excluded. Unclaimed runs (played before profiles existed or while the app was closed) are shared.
+func (s *Service) AdmitRun(fileName string, live bool) bool {
+	active := settings.ActiveProfileID()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadOwnersLocked()
+	if owner, ok := s.owners[fileName]; ok {
+		return owner == active
+	}
+	if live {
+		s.owners[fileName] = active
+		_ = s.saveOwnersLocked()
+	}
+	return true
+}
+
+// newProfileSettings derives a new profile's settings from the current ones, dropping per-player data.
+func newProfileSettings(cur models.Settings) models.Settings {
+	next := cur
+	next.SteamIDOverride = ""
+	next.PersonaNameOverride = ""
+	next.FavoriteBenchmarks = nil
+	next.Teammates = nil
+	next.ScenarioNotes = nil
+	next.SessionNotes = nil
+	// Traces in the default location move with the profile; a custom folder is kept
+	if settings.ExpandPathPlaceholders(cur.TracesDir) == settings.ExpandPathPlaceholders(settings.DefaultTracesDirString()) {
+		next.TracesDir = ""
+	}
+	return next
+}
+
+// loadOwnersLocked reads the run ownership ledger once. Caller must hold s.mu.
+func (s *Service) loadOwnersLocked() {
+	if s.ownersLoaded {
+		return
+	}
+	s.ownersLoaded = true
+	s.owners = map[string]string{}
+	base, err := settings.GetConfigDir()
+	if err != nil {
+		return
+	}
+	if b, err := os.ReadFile(filepath.Join(base, constants.RunOwnersFileName)); err == nil {
+		_ = json.Unmarshal(b, &s.owners)
+	}
+	if s.owners == nil {
+		s.owners = map[string]string{}
+	}
+}
+
+// saveOwnersLocked writes the run ownership ledger. Caller must hold s.mu.
+func (s *Service) saveOwnersLocked() error {
+	base, err := settings.EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(s.owners)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(base, constants.RunOwnersFileName), b, 0o644)
+}
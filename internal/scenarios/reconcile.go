@@ -0,0 +1,225 @@
+package scenarios
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/kovaaks"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// RemoteRecords returns the remote-only runs synced so far (oldest first).
+func (s *Service) RemoteRecords() []models.ScenarioRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureRemoteLoadedLocked()
+	return sortedRecords(s.remoteRuns)
+}
+
+// ReconcileRemote fetches the last scores of the most recently played scenarios in local and
+// stores runs that have no local counterpart (e.g. played on another PC) as remote records.
+// A remote score matches a local run when both carry the same hash, or when the scores are
+// equal and the runs ended within RemoteMatchToleranceSeconds of each other.
+// It returns the newly added records. Scenarios that fail to fetch are skipped; the error of
+// the last failure is returned only when nothing could be fetched at all.
+func (s *Service) ReconcileRemote(local []models.ScenarioRecord) ([]models.ScenarioRecord, error) {
+	byScenario := map[string][]models.ScenarioRecord{}
+	var names []string
+	// Remote runs older than the local history window can't be told apart from runs
+	// that simply weren't loaded, so they are ignored.
+	var oldest time.Time
+	for _, r := range local {
+		if t := util.DatePlayed(r); !t.IsZero() && r.Source != constants.RecordSourceRemote && (oldest.IsZero() || t.Before(oldest)) {
+			oldest = t
+		}
+		name, _ := r.Stats["Scenario"].(string)
+		name = strings.TrimSpace(name)
+		if name == "" || r.Source == constants.RecordSourceRemote {
+			continue
+		}
+		if _, ok := byScenario[name]; !ok {
+			names = append(names, name)
+		}
+		byScenario[name] = append(byScenario[name], r)
+	}
+	// local is most-recent-first, so names are ordered by last played
+	if len(names) > constants.RemoteSyncMaxScenarios {
+		names = names[:constants.RemoteSyncMaxScenarios]
+	}
+
+	var added []models.ScenarioRecord
+	var lastErr error
+	fetched := 0
+	for _, name := range names {
+		if s.ctx.Err() != nil {
+			break
+		}
+		res, err := s.GetLastScores(name)
+		if err != nil {
+			lastErr = err
+			if kovaaks.IsOffline(err) {
+				break
+			}
+			continue
+		}
+		fetched++
+
+		s.mu.Lock()
+		s.ensureRemoteLoadedLocked()
+		for _, sc := range res.Scores {
+			rec, ok := remoteRecord(name, sc)
+			if !ok {
+				continue
+			}
+			if util.DatePlayed(rec).Before(oldest) {
+				continue
+			}
+			if _, known := s.remoteRuns[rec.FilePath]; known {
+				continue
+			}
+			if matchesLocal(rec, sc.Attributes, byScenario[name]) {
+				continue
+			}
+			s.remoteRuns[rec.FilePath] = rec
+			added = append(added, rec)
+		}
+		s.mu.Unlock()
+	}
+
+	if len(added) > 0 {
+		s.mu.Lock()
+		_ = s.cacheSvc.Save(constants.RemoteRunsCacheFileName, s.remoteRuns)
+		s.mu.Unlock()
+	}
+	if fetched == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	sort.SliceStable(added, func(i, j int) bool { return util.DatePlayed(added[i]).Before(util.DatePlayed(added[j])) })
+	return added, nil
+}
+
+// ensureRemoteLoadedLocked lazily loads persisted remote runs. Caller must hold s.mu.
+func (s *Service) ensureRemoteLoadedLocked() {
+	if s.remoteLoaded {
+		return
+	}
+	s.remoteLoaded = true
+	if !s.cacheSvc.Exists(constants.RemoteRunsCacheFileName) {
+		return
+	}
+	var data map[string]models.ScenarioRecord
+	if err := s.cacheSvc.Load(constants.RemoteRunsCacheFileName, &data); err == nil && data != nil {
+		s.remoteRuns = data
+	}
+}
+
+// remoteRecord converts a Kovaak's last score into a ScenarioRecord with the fields available.
+// Date Played is the end of the run: challenge start plus the scenario length when known.
+func remoteRecord(scenario string, sc models.KovaaksLastScore) (models.ScenarioRecord, bool) {
+	a := sc.Attributes
+	start, ok := parseRemoteTime(a.ChallengeStart, a.Epoch)
+	if !ok {
+		return models.ScenarioRecord{}, false
+	}
+	length := constants.DefaultScenarioLengthSeconds
+	if m, ok := Get(scenario); ok && m.LengthSeconds > 0 {
+		length = m.LengthSeconds
+	}
+	end := start.Add(time.Duration(length) * time.Second)
+
+	id := strings.TrimSpace(sc.ID)
+	if id == "" {
+		id = strings.TrimSpace(a.Hash)
+	}
+	if id == "" {
+		id = strconv.FormatInt(start.UnixMilli(), 10)
+	}
+	key := "remote:" + scenario + ":" + id
+
+	stats := map[string]any{
+		"Scenario":        scenario,
+		"Score":           a.Score,
+		"Date Played":     end.Local().Format(time.RFC3339),
+		"Challenge Start": start.Local().Format("15:04:05.000"),
+		"Duration":        float64(length),
+		"cm/360":          a.Cm360,
+		"FOV":             a.Fov,
+		"FOVScale":        a.FovScale,
+		"Horiz Sens":      a.HorizSens,
+		"Vert Sens":       a.VertSens,
+		"Sens Scale":      a.SensScale,
+		"Kills":           a.Kills,
+		"Avg TTK":         a.AvgTtk,
+		"Avg FPS":         a.AvgFps,
+		"Resolution":      a.Resolution,
+		"Pause Count":     a.PauseCount,
+		"Pause Duration":  a.PauseDuration,
+		"Hash":            a.Hash,
+	}
+	return models.ScenarioRecord{
+		FilePath: key,
+		FileName: key,
+		Stats:    stats,
+		Source:   constants.RecordSourceRemote,
+	}, true
+}
+
+// matchesLocal reports whether a remote run corresponds to one of the local runs of its scenario.
+func matchesLocal(rec models.ScenarioRecord, a models.KovaaksScoreAttributes, local []models.ScenarioRecord) bool {
+	end := util.DatePlayed(rec)
+	tolerance := time.Duration(constants.RemoteMatchToleranceSeconds) * time.Second
+	for _, l := range local {
+		// Both sides hashed: the hash alone decides
+		if v, ok := l.Stats["Hash"]; ok && a.Hash != "" {
+			if h := strings.TrimSpace(fmt.Sprint(v)); h != "" {
+				if strings.EqualFold(h, a.Hash) {
+					return true
+				}
+				continue
+			}
+		}
+		if math.Abs(util.ToFloat(l.Stats["Score"])-a.Score) > 0.01 {
+			continue
+		}
+		d := util.DatePlayed(l).Sub(end)
+		if d < 0 {
+			d = -d
+		}
+		if d <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRemoteTime parses challengeStart (ISO 8601), falling back to epoch (Unix seconds or milliseconds).
+func parseRemoteTime(challengeStart, epoch string) (time.Time, bool) {
+	cs := strings.TrimSpace(challengeStart)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.000", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, cs); err == nil {
+			return t, true
+		}
+	}
+	if n, err := strconv.ParseInt(strings.TrimSpace(epoch), 10, 64); err == nil && n > 0 {
+		if n > 1e12 {
+			return time.UnixMilli(n), true
+		}
+		return time.Unix(n, 0), true
+	}
+	return time.Time{}, false
+}
+
+func sortedRecords(m map[string]models.ScenarioRecord) []models.ScenarioRecord {
+	out := make([]models.ScenarioRecord, 0, len(m))
+	for _, r := range m {
+		out = append(out, r)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return util.DatePlayed(out[i]).Before(util.DatePlayed(out[j])) })
+	return out
+}
@@ -1,52 +1,164 @@
 package scenarios
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"net/http"
-	"net/url"
+	"strings"
+	"sync"
 	"time"
 
+	"refleks/internal/cache"
 	"refleks/internal/constants"
+	"refleks/internal/freshness"
+	"refleks/internal/kovaaks"
 	"refleks/internal/models"
 	"refleks/internal/settings"
 	"refleks/internal/steam"
 )
 
+// lastScoresTTL is how long fetched last scores are served without revalidation.
+const lastScoresTTL = time.Duration(constants.LastScoresTTLMinutes) * time.Minute
+
+// lastScoresEntry is the persisted cache entry for one player's scenario last scores.
+type lastScoresEntry struct {
+	Username  string                    `json:"username"`
+	Scenario  string                    `json:"scenario"`
+	Scores    []models.KovaaksLastScore `json:"scores"`
+	Freshness *models.Freshness         `json:"freshness,omitempty"`
+}
+
 // Service manages scenario data fetching.
 type Service struct {
+	ctx         context.Context
 	settingsSvc *settings.Service
+	cacheSvc    *cache.Service
+	api         kovaaks.API
+
+	mu         sync.Mutex
+	lastScores map[string]lastScoresEntry // key: lower(username)|lower(scenario)
+	loaded     bool
+
+	remoteRuns   map[string]models.ScenarioRecord // key: FilePath (remote:<scenario>:<id>)
+	remoteLoaded bool
 }
 
 // NewService creates a new scenario service.
-func NewService(settingsSvc *settings.Service) *Service {
-	return &Service{settingsSvc: settingsSvc}
+func NewService(ctx context.Context, settingsSvc *settings.Service, cacheSvc *cache.Service, api kovaaks.API) *Service {
+	s := &Service{
+		ctx:         ctx,
+		settingsSvc: settingsSvc,
+		cacheSvc:    cacheSvc,
+		api:         api,
+		lastScores:  make(map[string]lastScoresEntry),
+		remoteRuns:  make(map[string]models.ScenarioRecord),
+	}
+	cacheSvc.RegisterOnClear(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.lastScores = make(map[string]lastScoresEntry)
+		s.loaded = true
+		s.remoteRuns = make(map[string]models.ScenarioRecord)
+		s.remoteLoaded = true
+	})
+	cacheSvc.RegisterOnReload(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.lastScores = make(map[string]lastScoresEntry)
+		s.loaded = false
+		s.remoteRuns = make(map[string]models.ScenarioRecord)
+		s.remoteLoaded = false
+	})
+	return s
 }
 
-// GetLastScores fetches the last 10 scores for a given scenario from KovaaK's API.
-func (s *Service) GetLastScores(scenarioName string) ([]models.KovaaksLastScore, error) {
+// GetLastScores returns the last 10 scores for a given scenario from KovaaK's API.
+// Fresh cached results are served directly; otherwise the API is queried and, if it
+// fails, the last cached result is returned marked stale with the error recorded.
+func (s *Service) GetLastScores(scenarioName string) (models.LastScoresResult, error) {
 	personaName := steam.GetPersonaName(s.settingsSvc.Get())
 	if personaName == "" {
-		return nil, fmt.Errorf("could not determine Steam PersonaName")
+		return models.LastScoresResult{}, fmt.Errorf("could not determine Steam PersonaName")
+	}
+	key := lastScoresKey(personaName, scenarioName)
+
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	entry, ok := s.lastScores[key]
+	s.mu.Unlock()
+	if ok && !freshness.IsStale(entry.Freshness, lastScoresTTL) {
+		return models.LastScoresResult{Scores: entry.Scores, Freshness: *freshness.FromCache(entry.Freshness, lastScoresTTL)}, nil
 	}
 
-	endpoint := fmt.Sprintf(constants.KovaaksLastScoresURL, url.QueryEscape(personaName), url.QueryEscape(scenarioName))
+	return s.fetchLastScores(personaName, scenarioName)
+}
+
+// fetchLastScores queries the API and updates the cache, falling back to cached data on failure.
+func (s *Service) fetchLastScores(personaName, scenarioName string) (models.LastScoresResult, error) {
+	key := lastScoresKey(personaName, scenarioName)
+	scores, err := s.api.LastScores(s.ctx, personaName, scenarioName)
 
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Get(endpoint)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
 	if err != nil {
-		return nil, err
+		entry, ok := s.lastScores[key]
+		if !ok {
+			return models.LastScoresResult{}, err
+		}
+		if freshness.ErrorChanged(entry.Freshness, err) {
+			entry.Freshness = freshness.WithError(entry.Freshness, err)
+			s.lastScores[key] = entry
+			_ = s.cacheSvc.Save(constants.LastScoresCacheFileName, s.lastScores)
+		}
+		return models.LastScoresResult{Scores: entry.Scores, Freshness: *freshness.FromCache(entry.Freshness, lastScoresTTL)}, nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status: %d", resp.StatusCode)
+	fr := freshness.Live()
+	s.lastScores[key] = lastScoresEntry{Username: personaName, Scenario: scenarioName, Scores: scores, Freshness: fr}
+	_ = s.cacheSvc.Save(constants.LastScoresCacheFileName, s.lastScores)
+	return models.LastScoresResult{Scores: scores, Freshness: *fr}, nil
+}
+
+// RevalidateStale refreshes stale cached last scores and returns how many were refreshed live.
+// Intended to be registered with a freshness.Scheduler.
+func (s *Service) RevalidateStale(ctx context.Context) int {
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	var stale []lastScoresEntry
+	for _, e := range s.lastScores {
+		if freshness.IsStale(e.Freshness, lastScoresTTL) {
+			stale = append(stale, e)
+		}
 	}
+	s.mu.Unlock()
 
-	var scores []models.KovaaksLastScore
-	if err := json.NewDecoder(resp.Body).Decode(&scores); err != nil {
-		return nil, err
+	refreshed := 0
+	for _, e := range stale {
+		if ctx.Err() != nil {
+			break
+		}
+		if res, err := s.fetchLastScores(e.Username, e.Scenario); err == nil && res.Freshness.Source == constants.DataSourceLive {
+			refreshed++
+		}
 	}
+	return refreshed
+}
+
+// ensureLoadedLocked lazily loads the persisted last scores cache. Caller must hold s.mu.
+func (s *Service) ensureLoadedLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	if !s.cacheSvc.Exists(constants.LastScoresCacheFileName) {
+		return
+	}
+	var data map[string]lastScoresEntry
+	if err := s.cacheSvc.Load(constants.LastScoresCacheFileName, &data); err == nil && data != nil {
+		s.lastScores = data
+	}
+}
 
-	return scores, nil
+func lastScoresKey(username, scenario string) string {
+	return strings.ToLower(strings.TrimSpace(username)) + "|" + strings.ToLower(strings.TrimSpace(scenario))
 }
@@ -0,0 +1,173 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"refleks/internal/constants"
+	"refleks/internal/util"
+)
+
+// profilesFile is the on-disk record of the active profile ($HOME/.refleks/profiles.json).
+type profilesFile struct {
+	Active string `json:"active"`
+}
+
+var (
+	profileMu     sync.RWMutex
+	profileLoaded bool
+	activeProfile string
+)
+
+// ActiveProfileID returns the ID of the active profile (DefaultProfileID unless another was selected).
+func ActiveProfileID() string {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	loadActiveProfileLocked()
+	return activeProfile
+}
+
+// SetActiveProfileID selects and persists the active profile. Settings, caches and traces are read
+// from the new profile's data directory from then on; services must reload to pick it up.
+func SetActiveProfileID(id string) error {
+	if !ValidProfileID(id) {
+		return fmt.Errorf("invalid profile id: %q", id)
+	}
+	base, err := EnsureConfigDir()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(profilesFile{Active: id}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(base, constants.ProfilesFileName), b, 0o644); err != nil {
+		return err
+	}
+	profileMu.Lock()
+	activeProfile, profileLoaded = id, true
+	profileMu.Unlock()
+	return nil
+}
+
+// ValidProfileID reports whether id names a profile: DefaultProfileID or a numeric SteamID64.
+func ValidProfileID(id string) bool {
+	return id == constants.DefaultProfileID || isNumeric(id)
+}
+
+// ProfileDataDir returns the data directory of a profile. The default profile uses the config
+// directory itself, so data from before profiles existed stays with it.
+func ProfileDataDir(id string) (string, error) {
+	base, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if id == constants.DefaultProfileID {
+		return base, nil
+	}
+	if !ValidProfileID(id) {
+		return "", fmt.Errorf("invalid profile id: %q", id)
+	}
+	return filepath.Join(base, constants.ProfilesSubdirName, id), nil
+}
+
+// ProfileExists reports whether a profile already has its own settings.
+func ProfileExists(id string) bool {
+	dir, err := ProfileDataDir(id)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(dir, constants.SettingsFileName))
+	return err == nil
+}
+
+// ProfileIDs returns the IDs of the profiles that have a data directory, default first.
+func ProfileIDs() []string {
+	out := []string{constants.DefaultProfileID}
+	base, err := GetConfigDir()
+	if err != nil {
+		return out
+	}
+	entries, err := os.ReadDir(filepath.Join(base, constants.ProfilesSubdirName))
+	if err != nil {
+		return out
+	}
+	var ids []string
+	for _, e := range entries {
+		if e.IsDir() && isNumeric(e.Name()) {
+			ids = append(ids, e.Name())
+		}
+	}
+	sort.Strings(ids)
+	return append(out, ids...)
+}
+
+// GetDataDir returns the active profile's data directory (settings, cache and default traces).
+// It does not ensure the directory exists.
+func GetDataDir() (string, error) {
+	return ProfileDataDir(ActiveProfileID())
+}
+
+// EnsureDataDir returns the active profile's data directory, creating it if necessary.
+func EnsureDataDir() (string, error) {
+	dir, err := GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// loadActiveProfileLocked reads the active profile once. Caller must hold profileMu.
+func loadActiveProfileLocked() {
+	if profileLoaded {
+		return
+	}
+	profileLoaded = true
+	activeProfile = constants.DefaultProfileID
+	base, err := GetConfigDir()
+	if err != nil {
+		return
+	}
+	b, err := os.ReadFile(filepath.Join(base, constants.ProfilesFileName))
+	if err != nil {
+		return
+	}
+	var f profilesFile
+	if json.Unmarshal(b, &f) == nil && ValidProfileID(f.Active) {
+		activeProfile = f.Active
+	}
+}
+
+// ReadDataJSON decodes the named JSON file of the active profile's data directory into v.
+// A missing file returns an error matching fs.ErrNotExist.
+func ReadDataJSON(name string, v any) error {
+	dir, err := GetDataDir()
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// WriteDataJSON atomically writes v as indented JSON to the named file of the active profile's data directory.
+func WriteDataJSON(name string, v any) error {
+	dir, err := EnsureDataDir()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(filepath.Join(dir, name), b, 0o644)
+}
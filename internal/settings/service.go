@@ -23,12 +23,12 @@ func NewService() *Service {
 	}
 }
 
-// Load reads settings from disk. If the file doesn't exist, it returns defaults.
+// Load reads the active profile's settings from disk. If the file doesn't exist, it returns defaults.
 func (s *Service) Load() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	dir, err := EnsureConfigDir()
+	dir, err := EnsureDataDir()
 	if err != nil {
 		return err
 	}
@@ -71,7 +71,7 @@ func (s *Service) Update(newSettings models.Settings) error {
 
 // saveLocked writes the current settings to disk. Caller must hold the lock.
 func (s *Service) saveLocked() error {
-	dir, err := EnsureConfigDir()
+	dir, err := EnsureDataDir()
 	if err != nil {
 		return err
 	}
@@ -102,3 +102,18 @@ func (s *Service) SetFavoriteBenchmarks(ids []string) error {
 	s.current.FavoriteBenchmarks = ids
 	return s.saveLocked()
 }
+
+// GetTeammates returns the team roster.
+func (s *Service) GetTeammates() []models.Teammate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]models.Teammate(nil), s.current.Teammates...)
+}
+
+// SetTeammates replaces the team roster and persists it.
+func (s *Service) SetTeammates(roster []models.Teammate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current.Teammates = roster
+	return s.saveLocked()
+}
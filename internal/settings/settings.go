@@ -16,6 +16,10 @@ func DefaultStatsDir() string {
 	if env := GetEnv(constants.EnvStatsDirVar); strings.TrimSpace(env) != "" {
 		return ExpandPathPlaceholders(strings.TrimSpace(env))
 	}
+	// Look for Kovaak's in every Steam library
+	if dir := discoverStatsDir(); dir != "" {
+		return dir
+	}
 	if runtime.GOOS == "windows" {
 		return constants.DefaultWindowsKovaaksStatsDir
 	}
@@ -26,7 +30,7 @@ func DefaultStatsDir() string {
 // Default returns sane default settings for a fresh install.
 func Default() models.Settings {
 	return models.Settings{
-		SteamInstallDir:      constants.DefaultWindowsSteamInstallDir,
+		SteamInstallDir:      DefaultSteamInstallDir(),
 		StatsDir:             DefaultStatsDir(),
 		TracesDir:            DefaultTracesDirString(),
 		SessionGapMinutes:    constants.DefaultSessionGapMinutes,
@@ -36,13 +40,14 @@ func Default() models.Settings {
 		MouseBufferMinutes:   constants.DefaultMouseBufferMinutes,
 		MaxExistingOnStart:   constants.DefaultMaxExistingOnStart,
 		AutostartEnabled:     false,
+		AI:                   models.AISettings{Provider: constants.AIDefaultProvider},
 	}
 }
 
 // Sanitize applies defaults to zero/empty fields and returns the updated copy.
 func Sanitize(s models.Settings) models.Settings {
 	if strings.TrimSpace(s.SteamInstallDir) == "" {
-		s.SteamInstallDir = constants.DefaultWindowsSteamInstallDir
+		s.SteamInstallDir = DefaultSteamInstallDir()
 	}
 	if s.StatsDir == "" {
 		s.StatsDir = DefaultStatsDir()
@@ -65,6 +70,27 @@ func Sanitize(s models.Settings) models.Settings {
 	if s.MaxExistingOnStart <= 0 {
 		s.MaxExistingOnStart = constants.DefaultMaxExistingOnStart
 	}
+	if s.TraceRetention.MaxTotalMB < 0 {
+		s.TraceRetention.MaxTotalMB = 0
+	}
+	if s.TraceRetention.MaxAgeDays < 0 {
+		s.TraceRetention.MaxAgeDays = 0
+	}
+	if s.TraceRetention.KeepLastPerScenario < 0 {
+		s.TraceRetention.KeepLastPerScenario = 0
+	}
+	switch s.AI.Provider {
+	case constants.AIProviderGemini, constants.AIProviderOpenAI, constants.AIProviderOllama:
+	default:
+		s.AI.Provider = constants.AIDefaultProvider
+	}
+	s.AI.Model = strings.TrimSpace(s.AI.Model)
+	s.AI.BaseURL = strings.TrimRight(strings.TrimSpace(s.AI.BaseURL), "/")
+	if s.AI.PromptTokenBudget < 0 {
+		s.AI.PromptTokenBudget = 0
+	} else if s.AI.PromptTokenBudget > 0 && s.AI.PromptTokenBudget < constants.AIMinPromptTokenBudget {
+		s.AI.PromptTokenBudget = constants.AIMinPromptTokenBudget
+	}
 	if s.ScenarioNotes == nil {
 		s.ScenarioNotes = make(map[string]models.ScenarioNote)
 	}
@@ -96,9 +122,9 @@ func EnsureConfigDir() (string, error) {
 	return base, nil
 }
 
-// DefaultTracesDirString returns the default traces directory as a concrete path string.
+// DefaultTracesDirString returns the default traces directory of the active profile as a concrete path string.
 func DefaultTracesDirString() string {
-	base, err := GetConfigDir()
+	base, err := GetDataDir()
 	if err != nil {
 		// Fallback to relative subdir if home/config cannot be determined
 		return filepath.ToSlash(constants.TracesSubdirName)
@@ -106,15 +132,24 @@ func DefaultTracesDirString() string {
 	return filepath.ToSlash(filepath.Join(base, constants.TracesSubdirName))
 }
 
-// DefaultTracesDir returns the resolved default traces directory ($HOME/.refleks/traces).
+// DefaultTracesDir returns the resolved default traces directory of the active profile ($HOME/.refleks/traces for the default profile).
 func DefaultTracesDir() (string, error) {
-	base, err := GetConfigDir()
+	base, err := GetDataDir()
 	if err != nil {
 		return "", err
 	}
 	return filepath.Join(base, constants.TracesSubdirName), nil
 }
 
+// DefaultExportsDir returns the directory trace exports are written to by default ($HOME/.refleks/exports).
+func DefaultExportsDir() (string, error) {
+	base, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, constants.ExportsSubdirName), nil
+}
+
 // ExpandPathPlaceholders normalizes a path string for the current OS. No placeholders are supported.
 func ExpandPathPlaceholders(p string) string {
 	if p == "" {
@@ -124,11 +159,20 @@ func ExpandPathPlaceholders(p string) string {
 	return filepath.FromSlash(p)
 }
 
-// Path returns the settings file path under the user home config directory ($HOME/.refleks).
+// StatsDirOf returns the stats directory s points at, with placeholders expanded and falling back to
+// DefaultStatsDir when unset.
+func StatsDirOf(s models.Settings) string {
+	if dir := ExpandPathPlaceholders(strings.TrimSpace(s.StatsDir)); dir != "" {
+		return dir
+	}
+	return DefaultStatsDir()
+}
+
+// Path returns the settings file path of the active profile ($HOME/.refleks/settings.json for the default profile).
 func Path() (string, error) {
-	base, err := GetConfigDir()
+	base, err := GetDataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(base, "settings.json"), nil
+	return filepath.Join(base, constants.SettingsFileName), nil
 }
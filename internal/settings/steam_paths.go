@@ -0,0 +1,192 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/vdf"
+)
+
+// DefaultSteamInstallDir returns the Steam install directory: the standard location on Windows,
+// otherwise the first Steam root found by DiscoverSteamDirs ("" when none exists).
+func DefaultSteamInstallDir() string {
+	if runtime.GOOS == "windows" {
+		return constants.DefaultWindowsSteamInstallDir
+	}
+	if dirs := DiscoverSteamDirs(); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+// DiscoverSteamDirs returns the existing Steam install directories: the standard location on Windows;
+// native, ~/.local/share and Flatpak installs on Linux (symlinks resolved, duplicates removed).
+func DiscoverSteamDirs() []string {
+	var candidates []string
+	switch runtime.GOOS {
+	case "windows":
+		candidates = []string{constants.DefaultWindowsSteamInstallDir}
+	case "linux":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		for _, rel := range []string{constants.LinuxSteamRootDir, constants.LinuxSteamDataDir, constants.LinuxFlatpakSteamDir} {
+			candidates = append(candidates, filepath.Join(home, filepath.FromSlash(rel)))
+		}
+	}
+	var out []string
+	seen := map[string]bool{}
+	for _, dir := range candidates {
+		if real, err := filepath.EvalSymlinks(dir); err == nil {
+			dir = real
+		}
+		if seen[dir] || !isDir(filepath.Join(dir, "steamapps")) {
+			continue
+		}
+		seen[dir] = true
+		out = append(out, dir)
+	}
+	return out
+}
+
+// SteamLibraryDirs returns the Steam library roots listed in steamDir's libraryfolders.vdf,
+// starting with steamDir itself. Both the current and the legacy (index → path) layouts are read.
+func SteamLibraryDirs(steamDir string) []string {
+	out := []string{steamDir}
+	kv, err := vdf.ParseFile(filepath.Join(steamDir, filepath.FromSlash(constants.SteamLibraryFoldersFile)))
+	if err != nil {
+		return out
+	}
+	folders := kv.Map("libraryfolders")
+	if folders == nil {
+		return out
+	}
+	keys := make([]string, 0, len(folders))
+	for k := range folders {
+		keys = append(keys, k)
+	}
+	// Numeric keys are the library order
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	for _, k := range keys {
+		if !isNumeric(k) {
+			continue
+		}
+		var path string
+		switch v := folders[k].(type) {
+		case string:
+			path = v
+		case vdf.KeyValues:
+			path = v.String("path")
+		}
+		if path = strings.TrimSpace(path); path != "" && !containsPath(out, path) {
+			out = append(out, filepath.Clean(path))
+		}
+	}
+	return out
+}
+
+// FindKovaaksInstalls returns Kovaak's install directories found in the libraries of steamDir,
+// using each library's appmanifest to resolve the install folder.
+func FindKovaaksInstalls(steamDir string) []string {
+	var out []string
+	for _, lib := range SteamLibraryDirs(steamDir) {
+		steamapps := filepath.Join(lib, "steamapps")
+		installDir := "FPSAimTrainer"
+		manifest, err := vdf.ParseFile(filepath.Join(steamapps, fmt.Sprintf("appmanifest_%d.acf", constants.KovaaksSteamAppID)))
+		if err != nil {
+			continue
+		}
+		if v := strings.TrimSpace(manifest.Map("AppState").String("installdir")); v != "" {
+			installDir = v
+		}
+		if dir := filepath.Join(steamapps, "common", installDir); isDir(dir) {
+			out = append(out, dir)
+		}
+	}
+	return out
+}
+
+// KovaaksStatsDirIn returns the Kovaak's stats directory inside a Steam library root.
+func KovaaksStatsDirIn(steamDir string) string {
+	return filepath.Join(steamDir, filepath.FromSlash(constants.KovaaksStatsRelDir))
+}
+
+// SuggestPaths lists the detected Steam install, library, Kovaak's install and stats directories.
+func SuggestPaths() models.PathSuggestions {
+	res := models.PathSuggestions{
+		SteamInstallDirs: []string{},
+		LibraryDirs:      []string{},
+		KovaaksDirs:      []string{},
+		StatsDirs:        []string{},
+	}
+	for _, steamDir := range DiscoverSteamDirs() {
+		res.SteamInstallDirs = append(res.SteamInstallDirs, steamDir)
+		for _, lib := range SteamLibraryDirs(steamDir) {
+			if !containsPath(res.LibraryDirs, lib) {
+				res.LibraryDirs = append(res.LibraryDirs, lib)
+			}
+		}
+		for _, dir := range FindKovaaksInstalls(steamDir) {
+			if containsPath(res.KovaaksDirs, dir) {
+				continue
+			}
+			res.KovaaksDirs = append(res.KovaaksDirs, dir)
+			if stats := filepath.Join(dir, filepath.FromSlash(constants.KovaaksStatsSubdir)); isDir(stats) {
+				res.StatsDirs = append(res.StatsDirs, stats)
+			}
+		}
+	}
+	// Installs without an appmanifest (e.g. copied over) in the default location
+	for _, lib := range res.LibraryDirs {
+		if stats := KovaaksStatsDirIn(lib); isDir(stats) && !containsPath(res.StatsDirs, stats) {
+			res.StatsDirs = append(res.StatsDirs, stats)
+		}
+	}
+	return res
+}
+
+// discoverStatsDir returns the first Kovaak's stats directory found in any Steam library.
+func discoverStatsDir() string {
+	if dirs := SuggestPaths().StatsDirs; len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+func containsPath(list []string, p string) bool {
+	for _, v := range list {
+		if filepath.Clean(v) == filepath.Clean(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDir(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
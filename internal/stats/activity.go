@@ -0,0 +1,221 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// dateLayout keys days and weeks.
+const dateLayout = "2006-01-02"
+
+// Compute derives the activity stats of records. Days, hours and weeks are in local time; now
+// decides whether the streaks are still current.
+func Compute(records []models.ScenarioRecord, now time.Time) models.ActivityStats {
+	runs := util.RunsOf(records)
+	for i := range runs {
+		runs[i].At = runs[i].At.Local()
+	}
+
+	out := models.ActivityStats{
+		Days:        []models.DayActivity{},
+		Scenarios:   []models.ScenarioVolume{},
+		ByHour:      make([]models.HeatmapCell, 24),
+		ByWeekday:   make([]models.HeatmapCell, 7),
+		Consistency: []models.ScenarioConsistency{},
+	}
+	for i := range out.ByHour {
+		out.ByHour[i].Slot = i
+	}
+	for i := range out.ByWeekday {
+		out.ByWeekday[i].Slot = i
+	}
+	if len(runs) == 0 {
+		return out
+	}
+	first, last := runs[0].At, runs[len(runs)-1].At
+	out.First, out.Last = &first, &last
+
+	// Volume per day and per scenario; scenario means for the relative performance
+	byScenario := map[string]*models.ScenarioVolume{}
+	sums := map[string]float64{}
+	weeks := map[string]bool{}
+	for _, r := range runs {
+		out.Runs++
+		out.PlaytimeSec += r.Seconds
+		date := r.At.Format(dateLayout)
+		if n := len(out.Days); n == 0 || out.Days[n-1].Date != date {
+			out.Days = append(out.Days, models.DayActivity{Date: date})
+		}
+		d := &out.Days[len(out.Days)-1]
+		d.Runs++
+		d.PlaytimeSec += r.Seconds
+		weeks[weekOf(r.At).Format(dateLayout)] = true
+
+		key := strings.ToLower(r.Scenario)
+		sv, ok := byScenario[key]
+		if !ok {
+			sv = &models.ScenarioVolume{Scenario: r.Scenario}
+			byScenario[key] = sv
+		}
+		sv.Runs++
+		sv.PlaytimeSec += r.Seconds
+		sv.LastPlayed = r.At
+		sums[key] += r.Score
+	}
+	out.ActiveDays = len(out.Days)
+	for _, sv := range byScenario {
+		out.Scenarios = append(out.Scenarios, *sv)
+	}
+	sort.SliceStable(out.Scenarios, func(i, j int) bool {
+		if out.Scenarios[i].Runs != out.Scenarios[j].Runs {
+			return out.Scenarios[i].Runs > out.Scenarios[j].Runs
+		}
+		return out.Scenarios[i].Scenario < out.Scenarios[j].Scenario
+	})
+
+	// Heatmaps: performance relative to each scenario's mean so scenarios of different scales mix
+	hourPerf := make([]float64, 24)
+	dayPerf := make([]float64, 7)
+	hourN := make([]int, 24)
+	dayN := make([]int, 7)
+	for _, r := range runs {
+		h, wd := r.At.Hour(), int(r.At.Weekday())
+		out.ByHour[h].Runs++
+		out.ByHour[h].PlaytimeSec += r.Seconds
+		out.ByWeekday[wd].Runs++
+		out.ByWeekday[wd].PlaytimeSec += r.Seconds
+		key := strings.ToLower(r.Scenario)
+		if mean := sums[key] / float64(byScenario[key].Runs); mean > 0 {
+			rel := r.Score / mean * 100
+			hourPerf[h] += rel
+			hourN[h]++
+			dayPerf[wd] += rel
+			dayN[wd]++
+		}
+	}
+	for i := range out.ByHour {
+		if hourN[i] > 0 {
+			out.ByHour[i].Performance = util.Round(hourPerf[i]/float64(hourN[i]), 2)
+		}
+	}
+	for i := range out.ByWeekday {
+		if dayN[i] > 0 {
+			out.ByWeekday[i].Performance = util.Round(dayPerf[i]/float64(dayN[i]), 2)
+		}
+	}
+
+	out.DailyStreak = streak(out.Days, now, func(t time.Time) time.Time { return t.AddDate(0, 0, -1) }, day)
+	weekList := make([]models.DayActivity, 0, len(weeks))
+	for w := range weeks {
+		weekList = append(weekList, models.DayActivity{Date: w})
+	}
+	sort.Slice(weekList, func(i, j int) bool { return weekList[i].Date < weekList[j].Date })
+	out.WeeklyStreak = streak(weekList, now, func(t time.Time) time.Time { return t.AddDate(0, 0, -7) }, weekOf)
+
+	out.Consistency = consistency(runs, out.Scenarios)
+	return out
+}
+
+// streak counts consecutive periods in active (sorted period keys). prev steps one period back
+// and start maps a time to the start of its period.
+func streak(active []models.DayActivity, now time.Time, prev func(time.Time) time.Time, start func(time.Time) time.Time) models.ActivityStreak {
+	var out models.ActivityStreak
+	set := make(map[string]bool, len(active))
+	n := 0
+	var prevKey string
+	for _, a := range active {
+		set[a.Date] = true
+		t, err := time.ParseInLocation(dateLayout, a.Date, time.Local)
+		if err != nil {
+			continue
+		}
+		if prevKey != "" && prev(t).Format(dateLayout) == prevKey {
+			n++
+		} else {
+			n = 1
+		}
+		prevKey = a.Date
+		out.Best = max(out.Best, n)
+	}
+	// The current period may not have a run yet
+	t := start(now.Local())
+	if !set[t.Format(dateLayout)] {
+		t = prev(t)
+	}
+	for set[t.Format(dateLayout)] {
+		out.Current++
+		t = prev(t)
+	}
+	return out
+}
+
+// consistency computes the weekly coefficient of variation of the score of the most played scenarios.
+func consistency(runs []util.Run, volumes []models.ScenarioVolume) []models.ScenarioConsistency {
+	type weekKey struct{ scenario, week string }
+	scores := map[weekKey][]float64{}
+	for _, r := range runs {
+		k := weekKey{strings.ToLower(r.Scenario), weekOf(r.At).Format(dateLayout)}
+		scores[k] = append(scores[k], r.Score)
+	}
+	byScenario := map[string][]models.WeekConsistency{}
+	for k, vs := range scores {
+		if len(vs) < constants.StatsConsistencyMinRuns {
+			continue
+		}
+		mean, sd := meanStdDev(vs)
+		if mean <= 0 {
+			continue
+		}
+		byScenario[k.scenario] = append(byScenario[k.scenario], models.WeekConsistency{Week: k.week, Runs: len(vs), Mean: util.Round(mean, 2), StdDev: util.Round(sd, 2), CV: util.Round(sd/mean, 4)})
+	}
+
+	out := []models.ScenarioConsistency{}
+	for _, v := range volumes {
+		weeks := byScenario[strings.ToLower(v.Scenario)]
+		if len(weeks) == 0 {
+			continue
+		}
+		sort.Slice(weeks, func(i, j int) bool { return weeks[i].Week < weeks[j].Week })
+		if n := len(weeks); n > constants.StatsConsistencyMaxWeeks {
+			weeks = weeks[n-constants.StatsConsistencyMaxWeeks:]
+		}
+		var sum float64
+		for _, w := range weeks {
+			sum += w.CV
+		}
+		out = append(out, models.ScenarioConsistency{Scenario: v.Scenario, Runs: v.Runs, MeanCV: util.Round(sum/float64(len(weeks)), 4), Weeks: weeks})
+		if len(out) == constants.StatsConsistencyMaxScenarios {
+			break
+		}
+	}
+	return out
+}
+
+// day returns the start of t's local day.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// weekOf returns the Monday starting t's week.
+func weekOf(t time.Time) time.Time {
+	d := day(t)
+	return d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+}
+
+// meanStdDev returns the mean and population standard deviation of vs.
+func meanStdDev(vs []float64) (mean, sd float64) {
+	for _, v := range vs {
+		mean += v
+	}
+	mean /= float64(len(vs))
+	for _, v := range vs {
+		sd += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(vs)))
+}
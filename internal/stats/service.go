@@ -0,0 +1,40 @@
+// Package stats derives practice statistics (volume, streaks, heatmaps, consistency) from the run history.
+package stats
+
+import (
+	"sync"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// Service computes activity stats over the run history. The computation itself is Compute, so any
+// caller holding records can use it directly.
+type Service struct {
+	mu      sync.Mutex
+	history func() []models.ScenarioRecord
+}
+
+// NewService creates a new stats service.
+func NewService() *Service {
+	return &Service{}
+}
+
+// SetHistorySource sets the provider of the run history the stats are computed on.
+func (s *Service) SetHistorySource(fn func() []models.ScenarioRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = fn
+}
+
+// Activity computes the activity stats of the whole history.
+func (s *Service) Activity() models.ActivityStats {
+	s.mu.Lock()
+	history := s.history
+	s.mu.Unlock()
+	var records []models.ScenarioRecord
+	if history != nil {
+		records = history()
+	}
+	return Compute(records, time.Now())
+}
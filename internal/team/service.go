@@ -0,0 +1,520 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"refleks/internal/benchmarks"
+	"refleks/internal/cache"
+	"refleks/internal/constants"
+	"refleks/internal/freshness"
+	"refleks/internal/kovaaks"
+	"refleks/internal/models"
+	"refleks/internal/settings"
+	"refleks/internal/steam"
+)
+
+// progressTTL matches the local user's benchmark progress TTL.
+const progressTTL = time.Duration(constants.BenchmarkProgressTTLMinutes) * time.Minute
+
+// steamID64Pattern matches a SteamID64 (17 digits, starting with 7656119).
+var steamID64Pattern = regexp.MustCompile(`^7656119\d{10}$`)
+
+// teamCache is the persisted team data: teammates' latest progress and everyone's daily snapshots.
+type teamCache struct {
+	Progress  map[string]map[int]models.BenchmarkProgress  `json:"progress"`  // steamID -> benchmarkId -> progress
+	Snapshots map[string]map[int][]models.ProgressSnapshot `json:"snapshots"` // steamID -> benchmarkId -> oldest first
+}
+
+func newTeamCache() teamCache {
+	return teamCache{
+		Progress:  make(map[string]map[int]models.BenchmarkProgress),
+		Snapshots: make(map[string]map[int][]models.ProgressSnapshot),
+	}
+}
+
+// Service manages the teammate roster and compares benchmark progress across it.
+type Service struct {
+	ctx          context.Context
+	settingsSvc  *settings.Service
+	cacheSvc     *cache.Service
+	benchmarkSvc *benchmarks.Service
+	api          kovaaks.API
+
+	mu     sync.Mutex
+	data   teamCache
+	loaded bool
+}
+
+// NewService creates a new team service.
+func NewService(ctx context.Context, settingsSvc *settings.Service, cacheSvc *cache.Service, benchmarkSvc *benchmarks.Service, api kovaaks.API) *Service {
+	s := &Service{
+		ctx:          ctx,
+		settingsSvc:  settingsSvc,
+		cacheSvc:     cacheSvc,
+		benchmarkSvc: benchmarkSvc,
+		api:          api,
+		data:         newTeamCache(),
+	}
+	cacheSvc.RegisterOnClear(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.data = newTeamCache()
+		s.loaded = true
+	})
+	cacheSvc.RegisterOnReload(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.data = newTeamCache()
+		s.loaded = false
+	})
+	return s
+}
+
+// GetRoster returns the configured teammates.
+func (s *Service) GetRoster() []models.Teammate {
+	return s.settingsSvc.GetTeammates()
+}
+
+// NameOf returns the roster display name of a teammate, or "" when steamID isn't on the
+// roster or was added without a known name.
+func (s *Service) NameOf(steamID string) string {
+	for _, tm := range s.settingsSvc.GetTeammates() {
+		if tm.SteamID == steamID && tm.Name != steamID {
+			return tm.Name
+		}
+	}
+	return ""
+}
+
+// AddTeammate resolves a SteamID64 or Kovaak's/Steam persona name and adds it to the roster.
+// Adding someone already on the roster refreshes their display name.
+func (s *Service) AddTeammate(idOrName string) (models.Teammate, error) {
+	tm, err := s.resolve(idOrName)
+	if err != nil {
+		return models.Teammate{}, err
+	}
+	if self := steam.GetSteamID(s.settingsSvc.Get()); self != "" && self == tm.SteamID {
+		return models.Teammate{}, errors.New("cannot add yourself as a teammate")
+	}
+	roster := s.settingsSvc.GetTeammates()
+	for i := range roster {
+		if roster[i].SteamID == tm.SteamID {
+			roster[i].Name = tm.Name
+			return tm, s.settingsSvc.SetTeammates(roster)
+		}
+	}
+	return tm, s.settingsSvc.SetTeammates(append(roster, tm))
+}
+
+// RemoveTeammate removes a teammate from the roster and drops their cached data.
+func (s *Service) RemoveTeammate(steamID string) error {
+	roster := s.settingsSvc.GetTeammates()
+	out := roster[:0]
+	for _, tm := range roster {
+		if tm.SteamID != steamID {
+			out = append(out, tm)
+		}
+	}
+	if err := s.settingsSvc.SetTeammates(out); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ensureLoadedLocked()
+	delete(s.data.Progress, steamID)
+	delete(s.data.Snapshots, steamID)
+	return s.saveLocked()
+}
+
+// GetTeamProgress returns the benchmark progress of the user followed by every teammate.
+// Teammates' progress is cached; when a refresh fails the last known progress is returned as stale.
+func (s *Service) GetTeamProgress(benchmarkId int) ([]models.TeammateProgress, error) {
+	st := s.settingsSvc.Get()
+	selfID := steam.GetSteamID(st)
+	if selfID == "" {
+		return nil, errors.New("steam ID not found")
+	}
+	roster := s.settingsSvc.GetTeammates()
+
+	out := make([]models.TeammateProgress, len(roster)+1)
+	out[0] = models.TeammateProgress{Teammate: models.Teammate{SteamID: selfID, Name: steam.GetPersonaName(st)}, Self: true}
+	if p, _, err := s.benchmarkSvc.GetBenchmarkProgress(benchmarkId, true); err != nil {
+		out[0].Error = err.Error()
+	} else {
+		out[0].Progress = &p
+	}
+
+	var wg sync.WaitGroup
+	for i, tm := range roster {
+		wg.Add(1)
+		go func(i int, tm models.Teammate) {
+			defer wg.Done()
+			tp := models.TeammateProgress{Teammate: tm}
+			if p, err := s.memberProgress(benchmarkId, tm.SteamID); err != nil {
+				tp.Error = err.Error()
+			} else {
+				tp.Progress = &p
+			}
+			out[i+1] = tp
+		}(i, tm)
+	}
+	wg.Wait()
+
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	for _, tp := range out {
+		if tp.Progress != nil {
+			s.recordSnapshotLocked(tp.Teammate.SteamID, benchmarkId, *tp.Progress)
+		}
+	}
+	_ = s.saveLocked()
+	s.mu.Unlock()
+
+	return out, nil
+}
+
+// CompareWithTeammate compares the user against one teammate, scenario by scenario.
+func (s *Service) CompareWithTeammate(benchmarkId int, steamID string) (models.TeamComparison, error) {
+	var tm models.Teammate
+	for _, r := range s.settingsSvc.GetTeammates() {
+		if r.SteamID == steamID {
+			tm = r
+			break
+		}
+	}
+	if tm.SteamID == "" {
+		return models.TeamComparison{}, fmt.Errorf("%s is not on the roster", steamID)
+	}
+	mine, _, err := s.benchmarkSvc.GetBenchmarkProgress(benchmarkId, true)
+	if err != nil {
+		return models.TeamComparison{}, err
+	}
+	theirs, err := s.memberProgress(benchmarkId, steamID)
+	if err != nil {
+		return models.TeamComparison{}, err
+	}
+
+	out := models.TeamComparison{
+		BenchmarkID:    benchmarkId,
+		Teammate:       tm,
+		OverallRankGap: mine.OverallRank - theirs.OverallRank,
+		ProgressGap:    mine.BenchmarkProgress - theirs.BenchmarkProgress,
+	}
+	if theirs.Freshness != nil {
+		out.Freshness = *theirs.Freshness
+	}
+	theirScenarios := map[string]models.ScenarioProgress{}
+	for _, sp := range flatten(theirs) {
+		theirScenarios[strings.ToLower(sp.Name)] = sp
+	}
+	for _, m := range flatten(mine) {
+		t, ok := theirScenarios[strings.ToLower(m.Name)]
+		if !ok {
+			continue
+		}
+		c := models.ScenarioComparison{
+			Name:        m.Name,
+			MyScore:     m.Score,
+			TheirScore:  t.Score,
+			ScoreGap:    m.Score - t.Score,
+			MyRank:      m.ScenarioRank,
+			TheirRank:   t.ScenarioRank,
+			RankGap:     m.ScenarioRank - t.ScenarioRank,
+			MyEnergy:    m.Energy,
+			TheirEnergy: t.Energy,
+		}
+		if m.Energy != nil && t.Energy != nil {
+			gap := *m.Energy - *t.Energy
+			c.EnergyGap = &gap
+		}
+		out.Scenarios = append(out.Scenarios, c)
+	}
+	return out, nil
+}
+
+// GetMostImproved ranks the user and teammates by benchmark progress gained over the last days
+// (DefaultImprovementWindowDays when days <= 0), based on stored daily snapshots.
+// Members without a snapshot older than today are left out.
+func (s *Service) GetMostImproved(benchmarkId int, days int) ([]models.TeamImprovement, error) {
+	if days <= 0 {
+		days = constants.DefaultImprovementWindowDays
+	}
+	// Refresh first so the latest snapshot of everyone is current
+	members, err := s.GetTeamProgress(benchmarkId)
+	if err != nil {
+		return nil, err
+	}
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []models.TeamImprovement
+	for _, m := range members {
+		snaps := s.data.Snapshots[m.Teammate.SteamID][benchmarkId]
+		if len(snaps) < 2 {
+			continue
+		}
+		latest := snaps[len(snaps)-1]
+		// Baseline: the newest snapshot at or before the cutoff, else the oldest inside the window
+		base := snaps[0]
+		for _, sn := range snaps[:len(snaps)-1] {
+			if sn.TakenAt.After(cutoff) {
+				break
+			}
+			base = sn
+		}
+		imp := models.TeamImprovement{
+			Teammate:      m.Teammate,
+			Self:          m.Self,
+			Since:         base.TakenAt,
+			ProgressDelta: latest.BenchmarkProgress - base.BenchmarkProgress,
+			RankDelta:     latest.OverallRank - base.OverallRank,
+			EnergyDelta:   latest.Energy - base.Energy,
+		}
+		for name, score := range latest.Scores {
+			if prev, ok := base.Scores[name]; ok && score > prev {
+				imp.ImprovedScenarios++
+			}
+		}
+		out = append(out, imp)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].ProgressDelta != out[j].ProgressDelta {
+			return out[i].ProgressDelta > out[j].ProgressDelta
+		}
+		return out[i].EnergyDelta > out[j].EnergyDelta
+	})
+	return out, nil
+}
+
+// RankChanges returns the user's benchmark rank changes between from and to, for every benchmark
+// with stored snapshots in the period. Each change compares the newest snapshot at or before from
+// (else the oldest in the period) with the newest snapshot at or before to.
+func (s *Service) RankChanges(from, to time.Time) []models.BenchmarkRankChange {
+	selfID := steam.GetSteamID(s.settingsSvc.Get())
+	if selfID == "" {
+		return nil
+	}
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	type period struct {
+		id         int
+		base, last models.ProgressSnapshot
+	}
+	var periods []period
+	for id, snaps := range s.data.Snapshots[selfID] {
+		var base, last *models.ProgressSnapshot
+		for i := range snaps {
+			sn := &snaps[i]
+			if sn.TakenAt.After(to) {
+				break
+			}
+			if base == nil || !sn.TakenAt.After(from) {
+				base = sn
+			}
+			last = sn
+		}
+		if base == nil || last == nil || last.TakenAt.Before(from) || base == last {
+			continue
+		}
+		periods = append(periods, period{id: id, base: *base, last: *last})
+	}
+	s.mu.Unlock()
+
+	out := make([]models.BenchmarkRankChange, 0, len(periods))
+	for _, p := range periods {
+		var ranks []models.RankDef
+		if cached, ok := s.benchmarkSvc.GetCachedBenchmarkProgress(p.id); ok {
+			ranks = cached.Ranks
+		}
+		out = append(out, models.BenchmarkRankChange{
+			BenchmarkID:  p.id,
+			Benchmark:    s.benchmarkSvc.BenchmarkName(p.id),
+			From:         p.base.TakenAt,
+			To:           p.last.TakenAt,
+			RankFrom:     rankName(ranks, p.base.OverallRank),
+			RankTo:       rankName(ranks, p.last.OverallRank),
+			ProgressFrom: p.base.BenchmarkProgress,
+			ProgressTo:   p.last.BenchmarkProgress,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].BenchmarkID < out[j].BenchmarkID })
+	return out
+}
+
+// rankName returns the name of a 1-based overall rank, "Unranked" for 0 and the number when the rank list is unknown.
+func rankName(ranks []models.RankDef, rank int) string {
+	if rank <= 0 {
+		return "Unranked"
+	}
+	if rank <= len(ranks) {
+		return ranks[rank-1].Name
+	}
+	return strconv.Itoa(rank)
+}
+
+// memberProgress returns a teammate's progress, from cache while fresh.
+func (s *Service) memberProgress(benchmarkId int, steamID string) (models.BenchmarkProgress, error) {
+	s.mu.Lock()
+	s.ensureLoadedLocked()
+	cached, ok := s.data.Progress[steamID][benchmarkId]
+	s.mu.Unlock()
+	if ok && !freshness.IsStale(cached.Freshness, progressTTL) {
+		cached.Freshness = freshness.FromCache(cached.Freshness, progressTTL)
+		return cached, nil
+	}
+
+	p, err := s.benchmarkSvc.GetProgressForSteamID(s.ctx, benchmarkId, steamID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The cache may have been cleared or reloaded during the request
+	s.ensureLoadedLocked()
+	if err != nil {
+		if !ok {
+			return models.BenchmarkProgress{}, err
+		}
+		if cur, still := s.data.Progress[steamID][benchmarkId]; still {
+			cached = cur
+			if freshness.ErrorChanged(cached.Freshness, err) {
+				cached.Freshness = freshness.WithError(cached.Freshness, err)
+				s.data.Progress[steamID][benchmarkId] = cached
+				_ = s.saveLocked()
+			}
+		} else {
+			cached.Freshness = freshness.WithError(cached.Freshness, err)
+		}
+		cached.Freshness = freshness.FromCache(cached.Freshness, progressTTL)
+		return cached, nil
+	}
+	if s.data.Progress[steamID] == nil {
+		s.data.Progress[steamID] = make(map[int]models.BenchmarkProgress)
+	}
+	s.data.Progress[steamID][benchmarkId] = p
+	_ = s.saveLocked()
+	return p, nil
+}
+
+// resolve turns a SteamID64 or player name into a roster entry.
+func (s *Service) resolve(idOrName string) (models.Teammate, error) {
+	q := strings.TrimSpace(idOrName)
+	if q == "" {
+		return models.Teammate{}, errors.New("missing Steam ID or name")
+	}
+	if steamID64Pattern.MatchString(q) {
+		return models.Teammate{SteamID: q, Name: q}, nil
+	}
+	users, err := s.api.SearchUsers(s.ctx, q)
+	if err != nil {
+		return models.Teammate{}, err
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Username, q) || strings.EqualFold(u.SteamAccountName, q) {
+			return models.Teammate{SteamID: u.SteamID, Name: displayName(u)}, nil
+		}
+	}
+	if len(users) == 1 {
+		return models.Teammate{SteamID: users[0].SteamID, Name: displayName(users[0])}, nil
+	}
+	return models.Teammate{}, fmt.Errorf("no Kovaak's player named %q: %w", q, kovaaks.ErrNotFound)
+}
+
+// recordSnapshotLocked stores a snapshot of p, keeping at most one per day and
+// pruning snapshots past the retention window. Caller must hold s.mu.
+func (s *Service) recordSnapshotLocked(steamID string, benchmarkId int, p models.BenchmarkProgress) {
+	snap := snapshotOf(p)
+	if s.data.Snapshots[steamID] == nil {
+		s.data.Snapshots[steamID] = make(map[int][]models.ProgressSnapshot)
+	}
+	list := s.data.Snapshots[steamID][benchmarkId]
+	if n := len(list); n > 0 && sameDay(list[n-1].TakenAt, snap.TakenAt) {
+		list[n-1] = snap
+	} else if n == 0 || snap.TakenAt.After(list[n-1].TakenAt) {
+		list = append(list, snap)
+	}
+	cutoff := time.Now().AddDate(0, 0, -constants.TeamSnapshotRetentionDays)
+	for len(list) > 0 && list[0].TakenAt.Before(cutoff) {
+		list = list[1:]
+	}
+	s.data.Snapshots[steamID][benchmarkId] = list
+}
+
+// ensureLoadedLocked lazily loads the persisted cache. Caller must hold s.mu.
+func (s *Service) ensureLoadedLocked() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	if !s.cacheSvc.Exists(constants.TeamCacheFileName) {
+		return
+	}
+	var data teamCache
+	if err := s.cacheSvc.Load(constants.TeamCacheFileName, &data); err != nil {
+		return
+	}
+	if data.Progress == nil {
+		data.Progress = make(map[string]map[int]models.BenchmarkProgress)
+	}
+	if data.Snapshots == nil {
+		data.Snapshots = make(map[string]map[int][]models.ProgressSnapshot)
+	}
+	s.data = data
+}
+
+// saveLocked persists the team cache. Caller must hold s.mu.
+func (s *Service) saveLocked() error {
+	return s.cacheSvc.Save(constants.TeamCacheFileName, s.data)
+}
+
+// snapshotOf summarises progress, dated by when it was fetched.
+func snapshotOf(p models.BenchmarkProgress) models.ProgressSnapshot {
+	snap := models.ProgressSnapshot{
+		TakenAt:           time.Now(),
+		OverallRank:       p.OverallRank,
+		BenchmarkProgress: p.BenchmarkProgress,
+		Scores:            make(map[string]float64),
+	}
+	if p.Freshness != nil && !p.Freshness.FetchedAt.IsZero() {
+		snap.TakenAt = p.Freshness.FetchedAt
+	}
+	for _, sp := range flatten(p) {
+		snap.Scores[sp.Name] = sp.Score
+		if sp.Energy != nil {
+			snap.Energy += *sp.Energy
+		}
+	}
+	return snap
+}
+
+func flatten(p models.BenchmarkProgress) []models.ScenarioProgress {
+	var out []models.ScenarioProgress
+	for _, cat := range p.Categories {
+		for _, g := range cat.Groups {
+			out = append(out, g.Scenarios...)
+		}
+	}
+	return out
+}
+
+func displayName(u kovaaks.UserSearchResult) string {
+	if n := strings.TrimSpace(u.Username); n != "" {
+		return n
+	}
+	if n := strings.TrimSpace(u.SteamAccountName); n != "" {
+		return n
+	}
+	return u.SteamID
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
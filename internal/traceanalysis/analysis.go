@@ -0,0 +1,217 @@
+// Package traceanalysis computes per-run movement metrics from recorded mouse traces.
+package traceanalysis
+
+import (
+	"math"
+	"sort"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// sample is the motion between two consecutive trace points.
+type sample struct {
+	t      int64   // end of the interval, ms since the first point
+	dt     float64 // seconds
+	dx, dy float64
+	vx, vy float64 // smoothed velocity, units/s
+	speed  float64 // smoothed speed, units/s
+	begin  int     // index of the interval's start point
+	end    int     // index of the interval's end point
+	gap    bool    // interval spans a tracker gap; no velocity available
+}
+
+// Analyze computes movement metrics for a mouse trace. Points should be ordered by time;
+// points stamped earlier than their predecessor (e.g. the wall clock stepped back mid-run)
+// are merged into the next in-order interval.
+func Analyze(points []models.MousePoint) models.TraceMetrics {
+	m := models.TraceMetrics{
+		AlgorithmVersion: constants.TraceAnalysisVersion,
+		Points:           len(points),
+		ProfileBinMs:     constants.TraceProfileBinMs,
+	}
+	if len(points) < 2 {
+		return m
+	}
+
+	samples := buildSamples(points)
+	if len(samples) > 0 {
+		// Sample times strictly increase, so the last one is the latest
+		m.DurationMs = samples[len(samples)-1].t
+	}
+	var right, left, up, down float64
+	bins := make([]float64, m.DurationMs/constants.TraceProfileBinMs+1)
+	for _, s := range samples {
+		dist := math.Hypot(s.dx, s.dy)
+		m.PathLength += dist
+		right += math.Max(s.dx, 0)
+		left += math.Max(-s.dx, 0)
+		down += math.Max(s.dy, 0)
+		up += math.Max(-s.dy, 0)
+		if !s.gap {
+			bins[s.t/constants.TraceProfileBinMs] += dist
+		}
+	}
+	binSec := float64(constants.TraceProfileBinMs) / 1000
+	m.SpeedProfile = make([]float64, len(bins))
+	for i, d := range bins {
+		m.SpeedProfile[i] = math.Round(d/binSec*10) / 10
+	}
+	m.Direction = directionalShares(right, left, up, down)
+
+	moveThr := movementThreshold(samples)
+	var speeds, accels []float64
+	var jerkSq float64
+	var jerkN int
+	for i, s := range samples {
+		if s.gap {
+			continue
+		}
+		if s.speed > moveThr {
+			speeds = append(speeds, s.speed)
+		}
+		if i == 0 || samples[i-1].gap {
+			continue
+		}
+		p := samples[i-1]
+		ax, ay := (s.vx-p.vx)/s.dt, (s.vy-p.vy)/s.dt
+		if s.speed > moveThr {
+			accels = append(accels, math.Hypot(ax, ay))
+		}
+		if i >= 2 && !samples[i-2].gap {
+			pp := samples[i-2]
+			pax, pay := (p.vx-pp.vx)/p.dt, (p.vy-pp.vy)/p.dt
+			jx, jy := (ax-pax)/s.dt, (ay-pay)/s.dt
+			jerkSq += jx*jx + jy*jy
+			jerkN++
+		}
+	}
+	m.Speed = profile(speeds)
+	m.Acceleration = profile(accels)
+	if jerkN > 0 {
+		m.JerkRMS = math.Sqrt(jerkSq / float64(jerkN))
+	}
+
+	segments := segmentMovements(samples, moveThr)
+	m.Flicks = analyzeFlicks(points, samples, segments, moveThr, percentile(speeds, 0.9))
+	m.FlickSummary = summarizeFlicks(m.Flicks)
+	applyOvershootBias(&m.Direction, m.Flicks)
+	var smooth []float64
+	for _, f := range m.Flicks {
+		if f.Smoothness != 0 {
+			smooth = append(smooth, f.Smoothness)
+		}
+	}
+	m.Smoothness = mean(smooth)
+	m.Clicks = clickTiming(points, samples, segments)
+	return m
+}
+
+// buildSamples converts points into motion samples with a 3-sample moving average on velocity.
+// Points sharing a timestamp, or stamped before the previous sample's end, are merged into the
+// next interval, so sample times strictly increase.
+func buildSamples(points []models.MousePoint) []sample {
+	t0 := points[0].TS
+	out := make([]sample, 0, len(points)-1)
+	var pdx, pdy float64
+	last := 0
+	for i := 1; i < len(points); i++ {
+		pdx += float64(points[i].X) - float64(points[i-1].X)
+		pdy += float64(points[i].Y) - float64(points[i-1].Y)
+		dtMs := points[i].TS - points[last].TS
+		if dtMs <= 0 {
+			continue
+		}
+		s := sample{t: points[i].TS - t0, dt: float64(dtMs) / 1000, dx: pdx, dy: pdy, begin: last, end: i}
+		s.gap = dtMs > constants.TraceMaxGapMs
+		out = append(out, s)
+		pdx, pdy, last = 0, 0, i
+	}
+
+	raw := make([][2]float64, len(out))
+	for i, s := range out {
+		if !s.gap {
+			raw[i] = [2]float64{s.dx / s.dt, s.dy / s.dt}
+		}
+	}
+	for i := range out {
+		if out[i].gap {
+			continue
+		}
+		var sx, sy float64
+		n := 0
+		for j := i - 1; j <= i+1; j++ {
+			if j < 0 || j >= len(out) || out[j].gap {
+				continue
+			}
+			sx += raw[j][0]
+			sy += raw[j][1]
+			n++
+		}
+		out[i].vx, out[i].vy = sx/float64(n), sy/float64(n)
+		out[i].speed = math.Hypot(out[i].vx, out[i].vy)
+	}
+	return out
+}
+
+// movementThreshold returns the speed above which the cursor is considered moving.
+func movementThreshold(samples []sample) float64 {
+	var speeds []float64
+	for _, s := range samples {
+		if !s.gap && s.speed > 0 {
+			speeds = append(speeds, s.speed)
+		}
+	}
+	return math.Max(constants.TraceMinMoveSpeed, constants.TraceMoveSpeedRatio*percentile(speeds, 0.9))
+}
+
+func directionalShares(right, left, up, down float64) models.DirectionalBias {
+	var d models.DirectionalBias
+	if total := right + left + up + down; total > 0 {
+		d.Right, d.Left, d.Up, d.Down = right/total, left/total, up/total, down/total
+	}
+	if h := right + left; h > 0 {
+		d.Horizontal = (right - left) / h
+	}
+	if v := up + down; v > 0 {
+		d.Vertical = (down - up) / v
+	}
+	return d
+}
+
+func profile(vs []float64) models.MotionProfile {
+	if len(vs) == 0 {
+		return models.MotionProfile{}
+	}
+	return models.MotionProfile{
+		Mean:   mean(vs),
+		Median: percentile(vs, 0.5),
+		P90:    percentile(vs, 0.9),
+		Peak:   percentile(vs, 1),
+	}
+}
+
+// percentile returns the nearest-rank percentile (q in 0..1) of vs without modifying it.
+func percentile(vs []float64, q float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), vs...)
+	sort.Float64s(sorted)
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func mean(vs []float64) float64 {
+	if len(vs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range vs {
+		sum += v
+	}
+	return sum / float64(len(vs))
+}
@@ -0,0 +1,69 @@
+package traceanalysis
+
+import (
+	"testing"
+
+	"refleks/internal/models"
+)
+
+// line returns n points moving right by 2 units every 5 ms, starting at ts.
+func line(ts int64, x int32, n int) []models.MousePoint {
+	out := make([]models.MousePoint, n)
+	for i := range out {
+		out[i] = models.MousePoint{TS: ts + int64(i)*5, X: x + int32(i)*2}
+	}
+	return out
+}
+
+func TestAnalyzeNonMonotonic(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []models.MousePoint
+		maxMs  int64
+	}{
+		{
+			// Clock stepped back 300 ms mid-run, then carried on
+			name:   "step back mid-run",
+			points: append(line(10_000, 0, 100), line(10_200, 200, 100)...),
+			maxMs:  695,
+		},
+		{
+			// Last point stamped before the first: the naive duration is negative
+			name:   "last before first",
+			points: append(line(10_000, 0, 100), models.MousePoint{TS: 9_000, X: 500}),
+			maxMs:  495,
+		},
+		{
+			name:   "shuffled",
+			points: []models.MousePoint{{TS: 50, X: 0}, {TS: 10, X: 5}, {TS: 70, X: 9}, {TS: 30, X: 12}, {TS: 90, X: 20}},
+			maxMs:  40,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Analyze(tt.points)
+			if m.DurationMs != tt.maxMs {
+				t.Errorf("DurationMs = %d, want %d", m.DurationMs, tt.maxMs)
+			}
+			if m.DurationMs < 0 {
+				t.Fatalf("negative duration %d", m.DurationMs)
+			}
+			if want := int(m.DurationMs/m.ProfileBinMs) + 1; len(m.SpeedProfile) != want {
+				t.Errorf("len(SpeedProfile) = %d, want %d", len(m.SpeedProfile), want)
+			}
+			if m.PathLength <= 0 {
+				t.Errorf("PathLength = %v, want > 0", m.PathLength)
+			}
+		})
+	}
+}
+
+func TestAnalyzeOrdered(t *testing.T) {
+	m := Analyze(line(1_000, 0, 201))
+	if m.DurationMs != 1000 {
+		t.Errorf("DurationMs = %d, want 1000", m.DurationMs)
+	}
+	if m.PathLength != 400 {
+		t.Errorf("PathLength = %v, want 400", m.PathLength)
+	}
+}
@@ -0,0 +1,230 @@
+package traceanalysis
+
+import (
+	"math"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+)
+
+// segment is a burst of movement between two rests, as inclusive sample indexes.
+type segment struct {
+	start, end int
+}
+
+// segmentMovements splits samples into movements. A movement ends once the speed stays below
+// moveThr for TraceSettleMs, or at a tracker gap.
+func segmentMovements(samples []sample, moveThr float64) []segment {
+	var out []segment
+	start, lastMoving := -1, -1
+	for i, s := range samples {
+		if start >= 0 && (s.gap || s.t-samples[lastMoving].t >= constants.TraceSettleMs) {
+			out = append(out, segment{start, lastMoving})
+			start = -1
+		}
+		if s.gap || s.speed <= moveThr {
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		lastMoving = i
+	}
+	if start >= 0 {
+		out = append(out, segment{start, lastMoving})
+	}
+	return out
+}
+
+// analyzeFlicks measures the movements that are large and fast enough to be aiming flicks.
+func analyzeFlicks(points []models.MousePoint, samples []sample, segments []segment, moveThr, p90 float64) []models.Flick {
+	t0 := points[0].TS
+	var out []models.Flick
+	for _, seg := range segments {
+		peak := seg.start
+		for i := seg.start; i <= seg.end; i++ {
+			if samples[i].speed > samples[peak].speed {
+				peak = i
+			}
+		}
+		vPeak := samples[peak].speed
+		if vPeak < constants.TraceFlickMinPeakRatio*p90 {
+			continue
+		}
+		first := samples[seg.start].begin
+		last := samples[seg.end].end
+		sx, sy := float64(points[first].X), float64(points[first].Y)
+		dx, dy := float64(points[last].X)-sx, float64(points[last].Y)-sy
+		amp := math.Hypot(dx, dy)
+		if amp < constants.TraceFlickMinAmplitude {
+			continue
+		}
+
+		primary := primaryEnd(samples, peak, seg.end)
+		corrections := countCorrections(samples, primary, seg.end, math.Max(moveThr, constants.TraceCorrectionRatio*vPeak))
+
+		onset := points[first].TS - t0
+		dir := math.Atan2(dy, dx) * 180 / math.Pi
+		if dir < 0 {
+			dir += 360
+		}
+		out = append(out, models.Flick{
+			OnsetMs:      onset,
+			DurationMs:   samples[seg.end].t - onset,
+			TimeToPeakMs: samples[peak].t - onset,
+			PeakVelocity: vPeak,
+			Amplitude:    amp,
+			DirectionDeg: dir,
+			Overshoot:    overshootPast(points[first:last+1], points[first], points[last]),
+			Corrections:  corrections,
+			SettleTimeMs: samples[seg.end].t - samples[primary].t,
+			Smoothness:   logDimensionlessJerk(samples[seg.start:seg.end+1], float64(samples[seg.end].t-onset)/1000, vPeak),
+		})
+	}
+	return out
+}
+
+// primaryEnd returns the sample where the primary submovement ends: the bottom of the first
+// slowdown below TracePrimaryEndRatio of the peak speed (or end when it never slows down).
+func primaryEnd(samples []sample, peak, end int) int {
+	vPeak := samples[peak].speed
+	for i := peak + 1; i <= end; i++ {
+		if samples[i].speed < constants.TracePrimaryEndRatio*vPeak {
+			for i < end && samples[i+1].speed < samples[i].speed {
+				i++
+			}
+			return i
+		}
+	}
+	return end
+}
+
+// countCorrections counts the re-accelerations above thr in samples (from, end].
+func countCorrections(samples []sample, from, end int, thr float64) int {
+	n := 0
+	below := samples[from].speed < thr
+	for i := from + 1; i <= end; i++ {
+		if below && samples[i].speed >= thr {
+			n++
+		}
+		below = samples[i].speed < thr
+	}
+	return n
+}
+
+// overshootPast returns how far points went beyond target along the from→target direction.
+func overshootPast(points []models.MousePoint, from, target models.MousePoint) float64 {
+	sx, sy := float64(from.X), float64(from.Y)
+	dx, dy := float64(target.X)-sx, float64(target.Y)-sy
+	amp := math.Hypot(dx, dy)
+	if amp == 0 {
+		return 0
+	}
+	ux, uy := dx/amp, dy/amp
+	maxProj := 0.0
+	for _, p := range points {
+		maxProj = math.Max(maxProj, (float64(p.X)-sx)*ux+(float64(p.Y)-sy)*uy)
+	}
+	return math.Max(0, maxProj-amp)
+}
+
+// logDimensionlessJerk returns -ln(T³/v²peak · ∫|jerk|² dt) over the samples of one movement
+// (0 when it can't be computed). Smoother movements score closer to 0.
+func logDimensionlessJerk(samples []sample, duration, vPeak float64) float64 {
+	if len(samples) < 3 || duration <= 0 || vPeak <= 0 {
+		return 0
+	}
+	var integral float64
+	for i := 2; i < len(samples); i++ {
+		s, p, pp := samples[i], samples[i-1], samples[i-2]
+		ax, ay := (s.vx-p.vx)/s.dt, (s.vy-p.vy)/s.dt
+		pax, pay := (p.vx-pp.vx)/p.dt, (p.vy-pp.vy)/p.dt
+		jx, jy := (ax-pax)/s.dt, (ay-pay)/s.dt
+		integral += (jx*jx + jy*jy) * s.dt
+	}
+	if integral <= 0 {
+		return 0
+	}
+	return -math.Log(math.Pow(duration, 3) / (vPeak * vPeak) * integral)
+}
+
+func summarizeFlicks(flicks []models.Flick) models.FlickSummary {
+	sum := models.FlickSummary{Count: len(flicks)}
+	if len(flicks) == 0 {
+		return sum
+	}
+	overshot := 0
+	for _, f := range flicks {
+		sum.AvgPeakVelocity += f.PeakVelocity
+		sum.AvgAmplitude += f.Amplitude
+		sum.AvgOvershoot += f.Overshoot
+		sum.AvgCorrections += float64(f.Corrections)
+		sum.AvgSettleTimeMs += float64(f.SettleTimeMs)
+		if f.Overshoot > constants.TraceOvershootMinRatio*f.Amplitude {
+			overshot++
+		}
+	}
+	n := float64(len(flicks))
+	sum.AvgPeakVelocity /= n
+	sum.AvgAmplitude /= n
+	sum.AvgOvershoot /= n
+	sum.AvgCorrections /= n
+	sum.AvgSettleTimeMs /= n
+	sum.OvershootRate = float64(overshot) / n
+	return sum
+}
+
+// applyOvershootBias fills the per-direction average overshoot from each flick's dominant axis.
+func applyOvershootBias(d *models.DirectionalBias, flicks []models.Flick) {
+	var sums, counts [4]float64 // right, down, left, up
+	for _, f := range flicks {
+		k := int(math.Mod(f.DirectionDeg+45, 360) / 90)
+		sums[k] += f.Overshoot
+		counts[k]++
+	}
+	avg := func(k int) float64 {
+		if counts[k] == 0 {
+			return 0
+		}
+		return sums[k] / counts[k]
+	}
+	d.OvershootRight, d.OvershootDown, d.OvershootLeft, d.OvershootUp = avg(0), avg(1), avg(2), avg(3)
+}
+
+// clickTiming classifies button presses as made while moving or after the cursor came to rest.
+func clickTiming(points []models.MousePoint, samples []sample, segments []segment) models.ClickTiming {
+	var c models.ClickTiming
+	t0 := points[0].TS
+	var delays []float64
+	for i := 1; i < len(points); i++ {
+		if points[i].Buttons&^points[i-1].Buttons == 0 {
+			continue
+		}
+		c.Clicks++
+		t := points[i].TS - t0
+		moving := false
+		lastStop := int64(-1)
+		for _, seg := range segments {
+			onset := points[samples[seg.start].begin].TS - t0
+			if onset > t {
+				break
+			}
+			if t <= samples[seg.end].t {
+				moving = true
+				break
+			}
+			lastStop = samples[seg.end].t
+		}
+		if moving {
+			c.WhileMoving++
+			continue
+		}
+		c.AfterStop++
+		if lastStop >= 0 {
+			delays = append(delays, float64(t-lastStop))
+		}
+	}
+	c.AvgDelayAfterStopMs = mean(delays)
+	c.MedianDelayAfterStopMs = percentile(delays, 0.5)
+	return c
+}
@@ -0,0 +1,182 @@
+package traceanalysis
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/util"
+)
+
+// KillEvent is a kill row of a stats file placed on the trace's clock.
+type KillEvent struct {
+	Kill   int
+	TS     int64 // UnixMilli, same clock as MousePoint.TS
+	TTKSec float64
+	Shots  float64
+	Hits   float64
+}
+
+// SegmentRecord aligns a record's kill events with its mouse trace and splits the run per target.
+// It uses the record's "Date Played" (run end) and "Challenge Start" stats to place the events.
+func SegmentRecord(points []models.MousePoint, rec models.ScenarioRecord) models.KillSegmentation {
+	end := util.DatePlayed(rec)
+	if end.IsZero() {
+		return models.KillSegmentation{Segments: []models.KillSegment{}}
+	}
+	end = end.Local()
+	var start time.Time
+	if cs, ok := rec.Stats["Challenge Start"].(string); ok {
+		if t, ok := util.ParseTODOnDate(cs, end); ok {
+			if t.After(end) {
+				t = t.AddDate(0, 0, -1)
+			}
+			start = t
+		}
+	}
+	return SegmentByKills(points, ParseKillEvents(rec.Events, end), start)
+}
+
+// ParseKillEvents reads kill rows (Kill #, time of day, bot, weapon, TTK, shots, hits, ...) and places
+// them on the day of datePlayed. Times after the end of the run belong to the previous day (midnight crossing).
+func ParseKillEvents(events [][]string, datePlayed time.Time) []KillEvent {
+	var out []KillEvent
+	for _, row := range events {
+		if len(row) < 7 {
+			continue
+		}
+		kill, err := strconv.Atoi(strings.TrimSpace(row[0]))
+		if err != nil {
+			continue
+		}
+		t, ok := util.ParseTODOnDate(strings.TrimSpace(row[1]), datePlayed)
+		if !ok {
+			continue
+		}
+		if t.After(datePlayed.Add(time.Second)) {
+			t = t.AddDate(0, 0, -1)
+		}
+		out = append(out, KillEvent{
+			Kill:   kill,
+			TS:     t.UnixMilli(),
+			TTKSec: util.ToFloat(strings.TrimSuffix(strings.TrimSpace(row[4]), "s")),
+			Shots:  util.ToFloat(row[5]),
+			Hits:   util.ToFloat(row[6]),
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].TS < out[j].TS })
+	return out
+}
+
+// SegmentByKills splits a trace at each kill. The first segment starts at start (or the first point
+// when start is zero or earlier). Kills outside the trace are counted as unaligned.
+func SegmentByKills(points []models.MousePoint, kills []KillEvent, start time.Time) models.KillSegmentation {
+	res := models.KillSegmentation{Segments: []models.KillSegment{}}
+	if len(points) < 2 || len(kills) == 0 {
+		res.Unaligned = len(kills)
+		return res
+	}
+	samples := buildSamples(points)
+	moveThr := movementThreshold(samples)
+	t0, tEnd := points[0].TS, points[len(points)-1].TS
+
+	prev := t0
+	if !start.IsZero() && start.UnixMilli() > t0 {
+		prev = start.UnixMilli()
+	}
+	for _, k := range kills {
+		if k.TS <= prev || k.TS > tEnd {
+			res.Unaligned++
+			prev = max(prev, k.TS)
+			continue
+		}
+		seg := measureSegment(points, samples, prev, k.TS, moveThr)
+		seg.Kill, seg.TTKSec, seg.Shots, seg.Hits = k.Kill, k.TTKSec, k.Shots, k.Hits
+		res.Segments = append(res.Segments, seg)
+		prev = k.TS
+	}
+	res.Phases = segmentPhases(res.Segments)
+	return res
+}
+
+// measureSegment computes the metrics of the trace between two absolute times (UnixMilli).
+func measureSegment(points []models.MousePoint, samples []sample, from, to int64, moveThr float64) models.KillSegment {
+	t0 := points[0].TS
+	seg := models.KillSegment{StartMs: from - t0, EndMs: to - t0, ReactionMs: -1, TimeToFirstShotMs: -1, PathEfficiency: 1}
+	pi := sort.Search(len(points), func(i int) bool { return points[i].TS >= from })
+	pj := sort.Search(len(points), func(i int) bool { return points[i].TS >= to })
+	pj = min(pj, len(points)-1)
+	if pi >= pj {
+		return seg
+	}
+
+	for i := pi + 1; i <= pj; i++ {
+		seg.PathLength += math.Hypot(float64(points[i].X)-float64(points[i-1].X), float64(points[i].Y)-float64(points[i-1].Y))
+		if seg.TimeToFirstShotMs < 0 && points[i].Buttons&^points[i-1].Buttons != 0 {
+			seg.TimeToFirstShotMs = points[i].TS - from
+		}
+	}
+	seg.Distance = math.Hypot(float64(points[pj].X)-float64(points[pi].X), float64(points[pj].Y)-float64(points[pi].Y))
+	if seg.PathLength > 0 {
+		seg.PathEfficiency = math.Min(1, seg.Distance/seg.PathLength)
+	}
+	seg.Overshoot = overshootPast(points[pi:pj+1], points[pi], points[pj])
+
+	// Samples ending inside (pi, pj]
+	sa := sort.Search(len(samples), func(i int) bool { return samples[i].end > pi })
+	sb := sort.Search(len(samples), func(i int) bool { return samples[i].end > pj }) - 1
+	if sa > sb {
+		return seg
+	}
+	peak := sa
+	for i := sa; i <= sb; i++ {
+		if seg.ReactionMs < 0 && !samples[i].gap && samples[i].speed > moveThr {
+			seg.ReactionMs = max(0, points[samples[i].begin].TS-from)
+		}
+		if samples[i].speed > samples[peak].speed {
+			peak = i
+		}
+	}
+	seg.PeakVelocity = samples[peak].speed
+	if seg.PeakVelocity > moveThr {
+		primary := primaryEnd(samples, peak, sb)
+		seg.MicroCorrections = countCorrections(samples, primary, sb, math.Max(moveThr, constants.TraceCorrectionRatio*seg.PeakVelocity))
+	}
+	return seg
+}
+
+// segmentPhases averages the segments per third of the run.
+func segmentPhases(segs []models.KillSegment) []models.KillSegmentPhase {
+	if len(segs) < 3 {
+		return nil
+	}
+	names := []string{"early", "middle", "late"}
+	out := make([]models.KillSegmentPhase, 0, len(names))
+	for p, name := range names {
+		part := segs[p*len(segs)/3 : (p+1)*len(segs)/3]
+		ph := models.KillSegmentPhase{Phase: name, Kills: len(part)}
+		var reaction, firstShot, corr, eff, over []float64
+		for _, s := range part {
+			if s.ReactionMs >= 0 {
+				reaction = append(reaction, float64(s.ReactionMs))
+			}
+			if s.TimeToFirstShotMs >= 0 {
+				firstShot = append(firstShot, float64(s.TimeToFirstShotMs))
+			}
+			corr = append(corr, float64(s.MicroCorrections))
+			eff = append(eff, s.PathEfficiency)
+			over = append(over, s.Overshoot)
+		}
+		ph.AvgReactionMs = mean(reaction)
+		ph.AvgTimeToFirstShotMs = mean(firstShot)
+		ph.AvgMicroCorrections = mean(corr)
+		ph.AvgPathEfficiency = mean(eff)
+		ph.AvgOvershoot = mean(over)
+		out = append(out, ph)
+	}
+	return out
+}
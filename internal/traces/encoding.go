@@ -1,13 +1,19 @@
 package traces
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"encoding/base64"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
 
 	"refleks/internal/models"
 )
@@ -15,20 +21,85 @@ import (
 const (
 	MagicHeader = "RTRC" // Refleks Trace
 	Version1    = 1
+	Version2    = 2
+
+	// Header flags
+	FlagGzip = 0x01 // points block is gzip-compressed
+	FlagZstd = 0x02 // points block is zstd-compressed (v2 only)
+)
+
+// Compression selects how the v2 points block is compressed.
+type Compression byte
+
+const (
+	CompressionNone Compression = 0
+	CompressionGzip Compression = FlagGzip
+	CompressionZstd Compression = FlagZstd
+
+	// DefaultCompression is used by WriteBinary.
+	DefaultCompression = CompressionZstd
 )
 
 // BinaryHeader represents the file structure.
-// [Magic:4][Version:1][Flags:1][MetaLen:4][MetaJSON...][Count:4][Points...]
+//
+// v1: [Magic:4][Version:1][Flags:1][MetaLen:4][MetaJSON...][Count:4][Points...]
+// Points are 20 bytes each: TS(int64 nanos) | X(int32) | Y(int32) | Buttons(int32).
 // Flags: 0x01 = Gzipped Points
+//
+// v2: [Magic:4][Version:1][Flags:1][MetaLen:4][MetaJSON...][Count:4][BlockLen:4][Block...][CRC32:4]
+// The block holds, per point, zigzag varint deltas from the previous point of TS (ms), X and Y,
+// followed by a uvarint Buttons mask. Flags 0x01/0x02 mark the block as gzip/zstd compressed.
+// CRC32 (IEEE) covers every preceding byte of the file.
 
 type TraceMetadata struct {
-	FileName     string `json:"fileName"`
-	ScenarioName string `json:"scenarioName,omitempty"`
-	DatePlayed   string `json:"datePlayed,omitempty"`
+	FileName     string  `json:"fileName"`
+	ScenarioName string  `json:"scenarioName,omitempty"`
+	DatePlayed   string  `json:"datePlayed,omitempty"`
+	Score        float64 `json:"score,omitempty"`
 }
 
-// WriteBinary writes the scenario data in a compact binary format.
+// ErrChecksum is returned when a v2 trace fails its CRC check (truncated or corrupted file).
+var ErrChecksum = errors.New("trace checksum mismatch")
+
+// WriteBinary writes the scenario data in the current (v2) binary format with DefaultCompression.
 func WriteBinary(w io.Writer, data ScenarioData) error {
+	return WriteBinaryWith(w, data, DefaultCompression)
+}
+
+// WriteBinaryWith writes the scenario data in the v2 binary format using the given block compression.
+func WriteBinaryWith(w io.Writer, data ScenarioData, c Compression) error {
+	metaBytes, err := json.Marshal(TraceMetadata{
+		FileName:     data.FileName,
+		ScenarioName: data.ScenarioName,
+		DatePlayed:   data.DatePlayed,
+		Score:        data.Score,
+	})
+	if err != nil {
+		return err
+	}
+	block, err := compressBlock(encodeDeltas(data.MouseTrace), c)
+	if err != nil {
+		return err
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, 18+len(metaBytes)+len(block)))
+	buf.WriteString(MagicHeader)
+	buf.WriteByte(Version2)
+	buf.WriteByte(byte(c))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(metaBytes)))
+	buf.Write(metaBytes)
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(data.MouseTrace)))
+	_ = binary.Write(buf, binary.LittleEndian, uint32(len(block)))
+	buf.Write(block)
+	_ = binary.Write(buf, binary.LittleEndian, crc32.ChecksumIEEE(buf.Bytes()))
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// WriteBinaryV1 writes the legacy v1 format (20 raw bytes per point, uncompressed).
+// Kept for compatibility with older RefleK's versions.
+func WriteBinaryV1(w io.Writer, data ScenarioData) error {
 	// 1. Write Header
 	if _, err := w.Write([]byte(MagicHeader)); err != nil {
 		return err
@@ -36,7 +107,6 @@ func WriteBinary(w io.Writer, data ScenarioData) error {
 	if _, err := w.Write([]byte{Version1}); err != nil {
 		return err
 	}
-	// Flags: 0 for now (no compression on points yet, maybe later)
 	if _, err := w.Write([]byte{0}); err != nil {
 		return err
 	}
@@ -86,76 +156,301 @@ func writePoints(w io.Writer, points []models.MousePoint) error {
 	return nil
 }
 
-// ReadBinary reads the scenario data from the binary format.
+// ReadBinary reads scenario data in any supported format: binary v1, binary v2 or legacy JSON.
 func ReadBinary(r io.Reader) (ScenarioData, error) {
-	// 1. Check Header
-	magic := make([]byte, 4)
-	if _, err := io.ReadFull(r, magic); err != nil {
-		return ScenarioData{}, err
-	}
-	if string(magic) != MagicHeader {
-		return ScenarioData{}, fmt.Errorf("invalid magic header")
+	br := bufio.NewReader(r)
+	// Legacy JSON traces start with '{' (possibly after whitespace)
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return ScenarioData{}, err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.Discard(1)
+			continue
+		case '{':
+			var data ScenarioData
+			if err := json.NewDecoder(br).Decode(&data); err != nil {
+				return ScenarioData{}, err
+			}
+			return data, nil
+		}
+		break
 	}
 
-	ver := make([]byte, 1)
-	if _, err := io.ReadFull(r, ver); err != nil {
+	raw, err := io.ReadAll(br)
+	if err != nil {
 		return ScenarioData{}, err
 	}
-	if ver[0] != Version1 {
-		return ScenarioData{}, fmt.Errorf("unsupported version: %d", ver[0])
+	version, flags, meta, rest, err := readHeader(raw)
+	if err != nil {
+		return ScenarioData{}, err
 	}
 
-	flags := make([]byte, 1)
-	if _, err := io.ReadFull(r, flags); err != nil {
+	var points []models.MousePoint
+	switch version {
+	case Version1:
+		points, err = readPointsV1(rest, flags)
+	case Version2:
+		points, err = readPointsV2(raw, rest, flags)
+	default:
+		err = fmt.Errorf("unsupported version: %d", version)
+	}
+	if err != nil {
 		return ScenarioData{}, err
 	}
 
-	// 2. Read Metadata
-	var metaLen uint32
-	if err := binary.Read(r, binary.LittleEndian, &metaLen); err != nil {
-		return ScenarioData{}, err
+	return ScenarioData{
+		Version:      int(version),
+		FileName:     meta.FileName,
+		ScenarioName: meta.ScenarioName,
+		DatePlayed:   meta.DatePlayed,
+		Score:        meta.Score,
+		MouseTrace:   points,
+	}, nil
+}
+
+// ReadVersion returns the binary format version of a trace (0 for legacy JSON).
+func ReadVersion(r io.Reader) (int, error) {
+	head := make([]byte, 5)
+	n, err := io.ReadFull(r, head)
+	if n > 0 && head[0] == '{' {
+		return 0, nil
 	}
-	metaBytes := make([]byte, metaLen)
-	if _, err := io.ReadFull(r, metaBytes); err != nil {
-		return ScenarioData{}, err
+	if err != nil {
+		return 0, err
+	}
+	if string(head[:4]) != MagicHeader {
+		return 0, fmt.Errorf("invalid magic header")
+	}
+	return int(head[4]), nil
+}
+
+// ReadMetadata returns the metadata of a trace without decoding its points.
+// Legacy JSON traces have no separate header and are decoded in full.
+func ReadMetadata(r io.Reader) (TraceMetadata, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(10)
+	if len(head) > 0 && (head[0] == '{' || head[0] == ' ' || head[0] == '\t' || head[0] == '\r' || head[0] == '\n') {
+		data, err := ReadBinary(br)
+		if err != nil {
+			return TraceMetadata{}, err
+		}
+		return TraceMetadata{FileName: data.FileName, ScenarioName: data.ScenarioName, DatePlayed: data.DatePlayed, Score: data.Score}, nil
+	}
+	if err != nil {
+		return TraceMetadata{}, err
+	}
+	if string(head[:4]) != MagicHeader {
+		return TraceMetadata{}, fmt.Errorf("invalid magic header")
+	}
+	metaLen := int64(binary.LittleEndian.Uint32(head[6:10]))
+	if _, err := br.Discard(10); err != nil {
+		return TraceMetadata{}, err
+	}
+	// Read through a limit instead of allocating metaLen up front: a torn or crafted
+	// header can claim up to 4 GiB, but the buffer only grows with the bytes present
+	metaBytes, err := io.ReadAll(io.LimitReader(br, metaLen))
+	if err != nil {
+		return TraceMetadata{}, err
+	}
+	if int64(len(metaBytes)) < metaLen {
+		return TraceMetadata{}, io.ErrUnexpectedEOF
 	}
 	var meta TraceMetadata
 	if err := json.Unmarshal(metaBytes, &meta); err != nil {
-		return ScenarioData{}, err
+		return TraceMetadata{}, err
 	}
+	return meta, nil
+}
 
-	// 3. Read Points
-	var count uint32
-	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
-		return ScenarioData{}, err
+// readHeader parses the common header and returns the remaining bytes (starting at Count).
+func readHeader(raw []byte) (version, flags byte, meta TraceMetadata, rest []byte, err error) {
+	if len(raw) < 10 || string(raw[:4]) != MagicHeader {
+		err = fmt.Errorf("invalid magic header")
+		return
+	}
+	version, flags = raw[4], raw[5]
+	metaLen := int(binary.LittleEndian.Uint32(raw[6:10]))
+	if metaLen < 0 || 10+metaLen > len(raw) {
+		err = io.ErrUnexpectedEOF
+		return
+	}
+	if err = json.Unmarshal(raw[10:10+metaLen], &meta); err != nil {
+		return
 	}
+	rest = raw[10+metaLen:]
+	return
+}
 
-	points := make([]models.MousePoint, count)
-	buf := make([]byte, 20)
-	for i := uint32(0); i < count; i++ {
-		if _, err := io.ReadFull(r, buf); err != nil {
-			return ScenarioData{}, err
+func readPointsV1(rest []byte, flags byte) ([]models.MousePoint, error) {
+	if len(rest) < 4 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	count := binary.LittleEndian.Uint32(rest)
+	body := rest[4:]
+	if flags&FlagGzip != 0 {
+		b, err := DecompressGzip(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
 		}
-		tsNano := int64(binary.LittleEndian.Uint64(buf[0:]))
-		x := int32(binary.LittleEndian.Uint32(buf[8:]))
-		y := int32(binary.LittleEndian.Uint32(buf[12:]))
-		buttons := int32(binary.LittleEndian.Uint32(buf[16:]))
+		body = b
+	}
+	if uint64(len(body)) < uint64(count)*20 {
+		return nil, io.ErrUnexpectedEOF
+	}
 
+	points := make([]models.MousePoint, count)
+	for i := range points {
+		buf := body[i*20 : i*20+20]
+		tsNano := int64(binary.LittleEndian.Uint64(buf[0:]))
 		points[i] = models.MousePoint{
 			TS:      tsNano / 1000000,
-			X:       x,
-			Y:       y,
-			Buttons: buttons,
+			X:       int32(binary.LittleEndian.Uint32(buf[8:])),
+			Y:       int32(binary.LittleEndian.Uint32(buf[12:])),
+			Buttons: int32(binary.LittleEndian.Uint32(buf[16:])),
 		}
 	}
+	return points, nil
+}
 
-	return ScenarioData{
-		Version:      int(ver[0]),
-		FileName:     meta.FileName,
-		ScenarioName: meta.ScenarioName,
-		DatePlayed:   meta.DatePlayed,
-		MouseTrace:   points,
-	}, nil
+func readPointsV2(raw, rest []byte, flags byte) ([]models.MousePoint, error) {
+	if len(rest) < 8 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	count := binary.LittleEndian.Uint32(rest)
+	blockLen := int(binary.LittleEndian.Uint32(rest[4:]))
+	if blockLen < 0 || 8+blockLen+4 > len(rest) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	end := len(raw) - len(rest) + 8 + blockLen
+	if crc32.ChecksumIEEE(raw[:end]) != binary.LittleEndian.Uint32(raw[end:]) {
+		return nil, ErrChecksum
+	}
+	block, err := decompressBlock(rest[8:8+blockLen], flags)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDeltas(block, int(count))
+}
+
+// encodeDeltas encodes points as zigzag varint deltas (TS, X, Y) plus a uvarint Buttons mask.
+func encodeDeltas(points []models.MousePoint) []byte {
+	out := make([]byte, 0, len(points)*5)
+	var tmp [binary.MaxVarintLen64]byte
+	var prev models.MousePoint
+	for _, p := range points {
+		n := binary.PutVarint(tmp[:], p.TS-prev.TS)
+		out = append(out, tmp[:n]...)
+		n = binary.PutVarint(tmp[:], int64(p.X)-int64(prev.X))
+		out = append(out, tmp[:n]...)
+		n = binary.PutVarint(tmp[:], int64(p.Y)-int64(prev.Y))
+		out = append(out, tmp[:n]...)
+		n = binary.PutUvarint(tmp[:], uint64(uint32(p.Buttons)))
+		out = append(out, tmp[:n]...)
+		prev = p
+	}
+	return out
+}
+
+func decodeDeltas(block []byte, count int) ([]models.MousePoint, error) {
+	// Every point takes at least 4 bytes (one per varint), so a larger count is corrupt
+	if count < 0 || count > len(block)/4 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	points := make([]models.MousePoint, count)
+	var prev models.MousePoint
+	pos := 0
+	next := func() (int64, error) {
+		v, n := binary.Varint(block[pos:])
+		if n <= 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		pos += n
+		return v, nil
+	}
+	for i := 0; i < count; i++ {
+		dts, err := next()
+		if err != nil {
+			return nil, err
+		}
+		dx, err := next()
+		if err != nil {
+			return nil, err
+		}
+		dy, err := next()
+		if err != nil {
+			return nil, err
+		}
+		btn, n := binary.Uvarint(block[pos:])
+		if n <= 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		pos += n
+		p := models.MousePoint{
+			TS:      prev.TS + dts,
+			X:       int32(int64(prev.X) + dx),
+			Y:       int32(int64(prev.Y) + dy),
+			Buttons: int32(uint32(btn)),
+		}
+		points[i] = p
+		prev = p
+	}
+	return points, nil
+}
+
+var (
+	zstdOnce sync.Once
+	zstdEnc  *zstd.Encoder
+	zstdDec  *zstd.Decoder
+	zstdErr  error
+)
+
+// zstdCodecs returns shared zstd codecs (EncodeAll/DecodeAll are safe for concurrent use).
+func zstdCodecs() (*zstd.Encoder, *zstd.Decoder, error) {
+	zstdOnce.Do(func() {
+		zstdEnc, zstdErr = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		if zstdErr != nil {
+			return
+		}
+		zstdDec, zstdErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+	return zstdEnc, zstdDec, zstdErr
+}
+
+func compressBlock(block []byte, c Compression) ([]byte, error) {
+	switch c {
+	case CompressionNone:
+		return block, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		if err := CompressGzip(&buf, block); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		enc, _, err := zstdCodecs()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(block, nil), nil
+	}
+	return nil, fmt.Errorf("unsupported compression: %d", c)
+}
+
+func decompressBlock(block []byte, flags byte) ([]byte, error) {
+	switch {
+	case flags&FlagZstd != 0:
+		_, dec, err := zstdCodecs()
+		if err != nil {
+			return nil, err
+		}
+		return dec.DecodeAll(block, nil)
+	case flags&FlagGzip != 0:
+		return DecompressGzip(bytes.NewReader(block))
+	}
+	return block, nil
 }
 
 // CompressGzip wraps the writer in a gzip writer.
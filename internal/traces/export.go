@@ -0,0 +1,338 @@
+package traces
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	appsettings "refleks/internal/settings"
+)
+
+// Export formats supported by Export and WriteExport.
+const (
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+)
+
+// exportMeta is the metadata written at the top of every export.
+type exportMeta struct {
+	FileName     string         `json:"fileName"`
+	ScenarioName string         `json:"scenarioName,omitempty"`
+	DatePlayed   string         `json:"datePlayed,omitempty"`
+	Score        float64        `json:"score,omitempty"`
+	Points       int            `json:"points"`
+	Stats        map[string]any `json:"stats,omitempty"`
+}
+
+// exportPoint is one NDJSON point record. Velocity is the speed from the previous point in counts/s.
+type exportPoint struct {
+	Type     string  `json:"type"`
+	TS       int64   `json:"ts"`
+	X        int32   `json:"x"`
+	Y        int32   `json:"y"`
+	Buttons  int32   `json:"buttons"`
+	Velocity float64 `json:"velocity"`
+}
+
+// Export writes the selected stored traces, one file per trace, into opts.OutputDir
+// (a timestamped folder under the default exports directory when empty).
+func (s *Service) Export(opts models.TraceExportOptions) (models.TraceExportResult, error) {
+	res := models.TraceExportResult{Files: []string{}}
+	format := strings.ToLower(strings.TrimSpace(opts.Format))
+	if format == "" {
+		format = ExportCSV
+	}
+	if format != ExportCSV && format != ExportNDJSON {
+		return res, fmt.Errorf("unsupported export format: %s", opts.Format)
+	}
+	entries, err := s.exportSelection(opts)
+	if err != nil {
+		return res, err
+	}
+
+	out := strings.TrimSpace(opts.OutputDir)
+	if out == "" {
+		base, err := appsettings.DefaultExportsDir()
+		if err != nil {
+			return res, err
+		}
+		out = filepath.Join(base, time.Now().Format("20060102-150405"))
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return res, err
+	}
+	res.Dir = out
+
+	for _, e := range entries {
+		path := filepath.Join(out, traceKey(e.Name)+"."+format)
+		if err := s.exportOne(e, path, format); err != nil {
+			res.Failed++
+			res.Errors = append(res.Errors, e.StatsFile+": "+err.Error())
+			continue
+		}
+		res.Exported++
+		res.Files = append(res.Files, path)
+	}
+	return res, nil
+}
+
+// WriteExport writes one trace with its metadata and stats in the given format.
+//
+// CSV starts with "# key: value" metadata lines (stats as a single JSON line), followed by
+// a header and one row per point: ts, x, y, buttons, velocity.
+// NDJSON starts with a {"type":"meta",...} record followed by one {"type":"point",...} record per point.
+func WriteExport(w io.Writer, data ScenarioData, stats map[string]any, format string) error {
+	meta := exportMeta{
+		FileName:     data.FileName,
+		ScenarioName: data.ScenarioName,
+		DatePlayed:   data.DatePlayed,
+		Score:        data.Score,
+		Points:       len(data.MouseTrace),
+		Stats:        stats,
+	}
+	bw := bufio.NewWriter(w)
+	switch format {
+	case ExportCSV:
+		fmt.Fprintf(bw, "# fileName: %s\n# scenario: %s\n# datePlayed: %s\n# score: %s\n# points: %d\n",
+			meta.FileName, meta.ScenarioName, meta.DatePlayed, strconv.FormatFloat(meta.Score, 'f', -1, 64), meta.Points)
+		if len(stats) > 0 {
+			b, err := json.Marshal(stats)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(bw, "# stats: %s\n", b)
+		}
+		cw := csv.NewWriter(bw)
+		_ = cw.Write([]string{"ts", "x", "y", "buttons", "velocity"})
+		for i, p := range data.MouseTrace {
+			_ = cw.Write([]string{
+				strconv.FormatInt(p.TS, 10),
+				strconv.FormatInt(int64(p.X), 10),
+				strconv.FormatInt(int64(p.Y), 10),
+				strconv.FormatInt(int64(p.Buttons), 10),
+				strconv.FormatFloat(pointVelocity(data.MouseTrace, i), 'f', 2, 64),
+			})
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	case ExportNDJSON:
+		enc := json.NewEncoder(bw)
+		if err := enc.Encode(struct {
+			Type string `json:"type"`
+			exportMeta
+		}{"meta", meta}); err != nil {
+			return err
+		}
+		for i, p := range data.MouseTrace {
+			v := math.Round(pointVelocity(data.MouseTrace, i)*100) / 100
+			if err := enc.Encode(exportPoint{Type: "point", TS: p.TS, X: p.X, Y: p.Y, Buttons: p.Buttons, Velocity: v}); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported export format: %s", format)
+	}
+	return bw.Flush()
+}
+
+// ReadCSV reads a trace in the CSV export format. Metadata comment lines are optional; the header
+// must name at least the ts, x and y columns (buttons is optional, other columns are ignored).
+func ReadCSV(r io.Reader) (ScenarioData, error) {
+	var data ScenarioData
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.Peek(1)
+		if err != nil || b[0] != '#' {
+			break
+		}
+		line, err := br.ReadString('\n')
+		key, val, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "#")), ":")
+		if ok {
+			val = strings.TrimSpace(val)
+			switch strings.TrimSpace(key) {
+			case "fileName":
+				data.FileName = val
+			case "scenario":
+				data.ScenarioName = val
+			case "datePlayed":
+				data.DatePlayed = val
+			case "score":
+				data.Score, _ = strconv.ParseFloat(val, 64)
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return data, fmt.Errorf("read csv header: %w", err)
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	iTS, okTS := cols["ts"]
+	iX, okX := cols["x"]
+	iY, okY := cols["y"]
+	iBtn, okBtn := cols["buttons"]
+	if !okTS || !okX || !okY {
+		return data, fmt.Errorf("csv header must contain ts, x and y columns")
+	}
+
+	field := func(rec []string, i int) string {
+		if i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return data, err
+		}
+		ts, err1 := strconv.ParseInt(field(rec, iTS), 10, 64)
+		x, err2 := strconv.ParseInt(field(rec, iX), 10, 32)
+		y, err3 := strconv.ParseInt(field(rec, iY), 10, 32)
+		if err := errors.Join(err1, err2, err3); err != nil {
+			return data, fmt.Errorf("csv row %d: %w", line, err)
+		}
+		p := models.MousePoint{TS: ts, X: int32(x), Y: int32(y)}
+		if okBtn {
+			if b, err := strconv.ParseInt(field(rec, iBtn), 10, 32); err == nil {
+				p.Buttons = int32(b)
+			}
+		}
+		data.MouseTrace = append(data.MouseTrace, p)
+	}
+	return data, nil
+}
+
+// exportSelection resolves the options to index entries, oldest first.
+func (s *Service) exportSelection(opts models.TraceExportOptions) ([]models.TraceIndexEntry, error) {
+	if len(opts.FileNames) > 0 {
+		dir, err := s.tracesDir()
+		if err != nil {
+			return nil, err
+		}
+		var out []models.TraceIndexEntry
+		for _, name := range opts.FileNames {
+			e, ok := s.indexLookup(dir, s.toTraceBaseName(name))
+			if !ok {
+				return nil, fmt.Errorf("trace not found: %s", name)
+			}
+			out = append(out, e)
+		}
+		return out, nil
+	}
+
+	from, err := parseExportDate(opts.From, false)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseExportDate(opts.To, true)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.List(opts.Scenario)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]models.TraceIndexEntry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		t := entryTime(entries[i])
+		if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && t.After(to)) {
+			continue
+		}
+		out = append(out, entries[i])
+	}
+	return out, nil
+}
+
+// exportOne loads a trace and its stats and writes the export file.
+func (s *Service) exportOne(e models.TraceIndexEntry, path, format string) error {
+	data, err := s.Load(e.StatsFile)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(data.FileName) == "" {
+		data.FileName = e.StatsFile
+	}
+	if data.ScenarioName == "" {
+		data.ScenarioName = e.Scenario
+	}
+	if data.DatePlayed == "" {
+		data.DatePlayed = e.DatePlayed
+	}
+
+	s.mu.RLock()
+	statsDir := s.statsDir
+	s.mu.RUnlock()
+	var stats map[string]any
+	if strings.TrimSpace(statsDir) != "" {
+		if _, st, err := parser.ParseStatsFile(filepath.Join(statsDir, e.StatsFile)); err == nil {
+			stats = st
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteExport(f, data, stats, format); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// parseExportDate parses YYYY-MM-DD (start or end of that local day) or RFC3339. Empty means unbounded.
+func parseExportDate(s string, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD or RFC3339)", s)
+	}
+	return t, nil
+}
+
+// pointVelocity returns the speed from the previous point in counts/s (0 for the first point or no elapsed time).
+func pointVelocity(points []models.MousePoint, i int) float64 {
+	if i == 0 {
+		return 0
+	}
+	dt := points[i].TS - points[i-1].TS
+	if dt <= 0 {
+		return 0
+	}
+	d := math.Hypot(float64(points[i].X)-float64(points[i-1].X), float64(points[i].Y)-float64(points[i-1].Y))
+	return d / float64(dt) * 1000
+}
@@ -0,0 +1,253 @@
+package traces
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	"refleks/internal/util"
+)
+
+// indexFileVersion is bumped when the index layout changes; older indexes are rebuilt.
+const indexFileVersion = 1
+
+// indexFile is the on-disk layout of the traces index. Entries are keyed by trace base name.
+type indexFile struct {
+	Version int                               `json:"version"`
+	Entries map[string]models.TraceIndexEntry `json:"entries"`
+}
+
+// RebuildIndex rescans the traces directory and rewrites the index. It returns the number of indexed traces.
+func (s *Service) RebuildIndex() (int, error) {
+	dir, err := s.tracesDir()
+	if err != nil {
+		return 0, err
+	}
+	s.idxMu.Lock()
+	defer s.idxMu.Unlock()
+	if err := s.rebuildIndexLocked(dir); err != nil {
+		return 0, err
+	}
+	return len(s.index), nil
+}
+
+// List returns the indexed traces of a scenario (all scenarios when empty), most recent first.
+func (s *Service) List(scenario string) ([]models.TraceIndexEntry, error) {
+	entries, err := s.indexEntries()
+	if err != nil {
+		return nil, err
+	}
+	scenario = strings.TrimSpace(scenario)
+	out := make([]models.TraceIndexEntry, 0, len(entries))
+	for _, e := range entries {
+		if scenario == "" || strings.EqualFold(e.Scenario, scenario) {
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return entryTime(out[i]).After(entryTime(out[j])) })
+	return out, nil
+}
+
+// Orphans returns the indexed traces whose stats CSV no longer exists in the stats directory.
+func (s *Service) Orphans() ([]models.TraceIndexEntry, error) {
+	s.mu.RLock()
+	statsDir := s.statsDir
+	s.mu.RUnlock()
+	if strings.TrimSpace(statsDir) == "" {
+		return nil, errors.New("stats directory not configured")
+	}
+	if _, err := os.Stat(statsDir); err != nil {
+		// An unreachable stats dir would flag every trace as orphaned
+		return nil, err
+	}
+	entries, err := s.List("")
+	if err != nil {
+		return nil, err
+	}
+	var out []models.TraceIndexEntry
+	for _, e := range entries {
+		if _, err := os.Stat(filepath.Join(statsDir, e.StatsFile)); os.IsNotExist(err) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// indexEntries returns a snapshot of the index for the current traces directory.
+func (s *Service) indexEntries() ([]models.TraceIndexEntry, error) {
+	dir, err := s.tracesDir()
+	if err != nil {
+		return nil, err
+	}
+	s.idxMu.Lock()
+	defer s.idxMu.Unlock()
+	s.loadIndexLocked(dir)
+	out := make([]models.TraceIndexEntry, 0, len(s.index))
+	for _, e := range s.index {
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// indexLookup returns the index entry of a trace base name.
+func (s *Service) indexLookup(dir, base string) (models.TraceIndexEntry, bool) {
+	s.idxMu.Lock()
+	defer s.idxMu.Unlock()
+	s.loadIndexLocked(dir)
+	e, ok := s.index[base]
+	return e, ok
+}
+
+// indexPut adds or replaces index entries and persists the index.
+func (s *Service) indexPut(dir string, entries ...models.TraceIndexEntry) {
+	s.idxMu.Lock()
+	defer s.idxMu.Unlock()
+	s.loadIndexLocked(dir)
+	for _, e := range entries {
+		s.index[traceKey(e.Name)] = e
+	}
+	_ = s.saveIndexLocked(dir)
+}
+
+// indexRemove drops the entries of the given trace file names and persists the index.
+func (s *Service) indexRemove(dir string, names ...string) {
+	s.idxMu.Lock()
+	defer s.idxMu.Unlock()
+	s.loadIndexLocked(dir)
+	for _, name := range names {
+		if e, ok := s.index[traceKey(name)]; ok && e.Name == name {
+			delete(s.index, traceKey(name))
+		}
+	}
+	_ = s.saveIndexLocked(dir)
+}
+
+// loadIndexLocked loads the index of dir, rebuilding it when missing or unreadable. Caller must hold s.idxMu.
+func (s *Service) loadIndexLocked(dir string) {
+	if s.index != nil && s.indexDir == dir {
+		return
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, constants.TraceIndexFileName)); err == nil {
+		var f indexFile
+		if err := json.Unmarshal(b, &f); err == nil && f.Version == indexFileVersion && f.Entries != nil {
+			s.index, s.indexDir = f.Entries, dir
+			return
+		}
+	}
+	_ = s.rebuildIndexLocked(dir)
+}
+
+// rebuildIndexLocked scans dir and replaces the in-memory and on-disk index. Caller must hold s.idxMu.
+func (s *Service) rebuildIndexLocked(dir string) error {
+	s.index, s.indexDir = map[string]models.TraceIndexEntry{}, dir
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, de := range entries {
+		if de.IsDir() || !isTraceFileName(de.Name()) {
+			continue
+		}
+		key := traceKey(de.Name())
+		// Binary traces take precedence over legacy JSON of the same run
+		if prev, ok := s.index[key]; ok && filepath.Ext(prev.Name) == ".trace" {
+			continue
+		}
+		e, err := indexTraceFile(dir, de.Name())
+		if err != nil {
+			continue
+		}
+		s.index[key] = e
+	}
+	return s.saveIndexLocked(dir)
+}
+
+// saveIndexLocked writes the index atomically. Caller must hold s.idxMu.
+func (s *Service) saveIndexLocked(dir string) error {
+	b, err := json.Marshal(indexFile{Version: indexFileVersion, Entries: s.index})
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(filepath.Join(dir, constants.TraceIndexFileName), b, 0o644)
+}
+
+// indexTraceFile decodes a stored trace and builds its index entry.
+func indexTraceFile(dir, name string) (models.TraceIndexEntry, error) {
+	raw, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return models.TraceIndexEntry{}, err
+	}
+	data, err := ReadBinary(bytes.NewReader(raw))
+	if err != nil {
+		return models.TraceIndexEntry{}, err
+	}
+	if v, err := ReadVersion(bytes.NewReader(raw)); err == nil {
+		data.Version = v
+	}
+	e := newIndexEntry(name, data, int64(len(raw)))
+	if e.DatePlayed == "" {
+		if info, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			e.DatePlayed = info.ModTime().Format(time.RFC3339)
+		}
+	}
+	return e, nil
+}
+
+// newIndexEntry builds the index entry of a trace file from its decoded data.
+// Scenario and date fall back to what the stats filename encodes.
+func newIndexEntry(name string, data ScenarioData, size int64) models.TraceIndexEntry {
+	base := traceKey(name)
+	e := models.TraceIndexEntry{
+		Name:       name,
+		StatsFile:  base + " Stats.csv",
+		Scenario:   strings.TrimSpace(data.ScenarioName),
+		DatePlayed: data.DatePlayed,
+		Score:      data.Score,
+		Points:     len(data.MouseTrace),
+		Version:    data.Version,
+		Size:       size,
+	}
+	if n := len(data.MouseTrace); n > 1 {
+		e.DurationMs = data.MouseTrace[n-1].TS - data.MouseTrace[0].TS
+	}
+	if info, err := parser.ParseFilename(e.StatsFile); err == nil {
+		if e.Scenario == "" {
+			e.Scenario = info.ScenarioName
+		}
+		if e.DatePlayed == "" {
+			e.DatePlayed = info.DatePlayed.Format(time.RFC3339)
+		}
+	}
+	if e.Scenario == "" {
+		e.Scenario = base
+	}
+	return e
+}
+
+// entryTime returns when the run of an index entry was played (zero if unknown).
+func entryTime(e models.TraceIndexEntry) time.Time {
+	t, _ := time.Parse(time.RFC3339, e.DatePlayed)
+	return t
+}
+
+// isTraceFileName reports whether a file in the traces directory holds a trace (not the index or a temp file).
+func isTraceFileName(name string) bool {
+	if name == constants.TraceIndexFileName {
+		return false
+	}
+	ext := filepath.Ext(name)
+	return ext == ".trace" || ext == ".json"
+}
+
+// traceKey returns the base name a trace file is indexed under.
+func traceKey(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
@@ -0,0 +1,77 @@
+package traces
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"refleks/internal/constants"
+	"refleks/internal/models"
+	"refleks/internal/traceanalysis"
+)
+
+// metricsExt is the extension of the metrics file stored next to each trace.
+const metricsExt = ".metrics"
+
+// Metrics returns the movement metrics of a scenario's trace. Stored metrics are reused
+// unless they were produced by an older analysis, in which case they are recomputed and saved.
+func (s *Service) Metrics(originalFileName string) (models.TraceMetrics, error) {
+	dir, err := s.tracesDir()
+	if err != nil {
+		return models.TraceMetrics{}, err
+	}
+	base := s.toTraceBaseName(originalFileName)
+	path := filepath.Join(dir, base+metricsExt)
+	if b, err := os.ReadFile(path); err == nil {
+		var m models.TraceMetrics
+		if err := json.Unmarshal(b, &m); err == nil && m.AlgorithmVersion == constants.TraceAnalysisVersion {
+			return m, nil
+		}
+	}
+
+	data, err := s.Load(originalFileName)
+	if err != nil {
+		return models.TraceMetrics{}, err
+	}
+	m := traceanalysis.Analyze(data.MouseTrace)
+	_ = writeMetrics(dir, base, m)
+	return m, nil
+}
+
+// MetricsHistory returns the metrics of a scenario's stored traces, most recent first.
+// limit <= 0 returns all of them. Traces that fail to load are skipped.
+func (s *Service) MetricsHistory(scenario string, limit int) ([]models.TraceMetricsHistoryEntry, error) {
+	entries, err := s.List(scenario)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	out := make([]models.TraceMetricsHistoryEntry, 0, len(entries))
+	for _, e := range entries {
+		m, err := s.Metrics(e.StatsFile)
+		if err != nil {
+			continue
+		}
+		m.Flicks = nil
+		m.SpeedProfile = nil
+		out = append(out, models.TraceMetricsHistoryEntry{
+			StatsFile:  e.StatsFile,
+			Scenario:   e.Scenario,
+			DatePlayed: e.DatePlayed,
+			Score:      e.Score,
+			Metrics:    m,
+		})
+	}
+	return out, nil
+}
+
+// writeMetrics stores the metrics file of a trace base name.
+func writeMetrics(dir, base string, m models.TraceMetrics) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, base+metricsExt), b, 0o644)
+}
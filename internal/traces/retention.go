@@ -0,0 +1,250 @@
+package traces
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/parser"
+	"refleks/internal/util"
+)
+
+// traceFile describes one stored trace as seen by the pruner.
+type traceFile struct {
+	name     string
+	path     string
+	size     int64
+	scenario string
+	played   time.Time
+	score    float64
+	hasScore bool
+}
+
+// SetStatsDir sets the Kovaak's stats directory, used to look up the score of
+// traces saved before scores were stored in the trace metadata.
+func (s *Service) SetStatsDir(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statsDir = dir
+}
+
+// LastPrune returns the result of the most recent pruning pass, or nil if none ran yet.
+func (s *Service) LastPrune() *models.TracePruneResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.lastPrune == nil {
+		return nil
+	}
+	res := *s.lastPrune
+	return &res
+}
+
+// StorageStats summarises the traces directory under the given policy.
+func (s *Service) StorageStats(policy models.TraceRetentionPolicy) (models.TracesStorageStats, error) {
+	stats := models.TracesStorageStats{Policy: policy, LastPrune: s.LastPrune()}
+	dir, err := s.tracesDir()
+	if err != nil {
+		return stats, err
+	}
+	stats.Dir = dir
+	files, err := s.scanTraceFiles(dir, false)
+	if err != nil {
+		return stats, err
+	}
+
+	scenarios := map[string]struct{}{}
+	var oldest, newest time.Time
+	for _, f := range files {
+		stats.FileCount++
+		stats.TotalBytes += f.size
+		scenarios[f.scenario] = struct{}{}
+		if oldest.IsZero() || f.played.Before(oldest) {
+			oldest = f.played
+		}
+		if f.played.After(newest) {
+			newest = f.played
+		}
+	}
+	stats.ScenarioCount = len(scenarios)
+	if !oldest.IsZero() {
+		stats.OldestPlayed = oldest.Format(time.RFC3339)
+		stats.NewestPlayed = newest.Format(time.RFC3339)
+	}
+	return stats, nil
+}
+
+// Prune deletes stored traces according to the policy. Each enabled rule is applied independently
+// and a trace is deleted as soon as one rule rejects it:
+//   - MaxAgeDays: the run was played longer ago than the limit.
+//   - KeepLastPerScenario: the trace isn't among the N most recent of its scenario.
+//   - KeepOnlyPB: the run didn't beat every earlier run of its scenario (traces with an unknown score are kept).
+//
+// MaxTotalMB is enforced last by deleting the oldest remaining traces, sparing each scenario's
+// best run until nothing else is left.
+func (s *Service) Prune(policy models.TraceRetentionPolicy) (models.TracePruneResult, error) {
+	res := models.TracePruneResult{PrunedAt: time.Now().Format(time.RFC3339)}
+	dir, err := s.tracesDir()
+	if err != nil {
+		return res, err
+	}
+	files, err := s.scanTraceFiles(dir, policy.KeepOnlyPB)
+	if err != nil {
+		return res, err
+	}
+	res.Scanned = len(files)
+
+	byScenario := map[string][]*traceFile{}
+	for i := range files {
+		f := &files[i]
+		byScenario[f.scenario] = append(byScenario[f.scenario], f)
+	}
+
+	doomed := map[*traceFile]bool{}
+	best := map[*traceFile]bool{}
+	cutoff := time.Time{}
+	if policy.MaxAgeDays > 0 {
+		cutoff = time.Now().AddDate(0, 0, -policy.MaxAgeDays)
+	}
+	for _, runs := range byScenario {
+		// Oldest first, so "personal best at the time" is a running maximum
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].played.Before(runs[j].played) })
+
+		var pb *traceFile
+		for _, f := range runs {
+			if !f.hasScore {
+				continue
+			}
+			if pb == nil || f.score > pb.score {
+				pb = f
+			} else if policy.KeepOnlyPB {
+				doomed[f] = true
+			}
+		}
+		if pb != nil {
+			best[pb] = true
+		}
+		if n := policy.KeepLastPerScenario; n > 0 && len(runs) > n {
+			for _, f := range runs[:len(runs)-n] {
+				doomed[f] = true
+			}
+		}
+		if !cutoff.IsZero() {
+			for _, f := range runs {
+				if f.played.Before(cutoff) {
+					doomed[f] = true
+				}
+			}
+		}
+	}
+
+	var kept []*traceFile
+	var total int64
+	for i := range files {
+		f := &files[i]
+		if !doomed[f] {
+			kept = append(kept, f)
+			total += f.size
+		}
+	}
+	if policy.MaxTotalMB > 0 {
+		limit := int64(policy.MaxTotalMB) * 1024 * 1024
+		sort.SliceStable(kept, func(i, j int) bool {
+			if best[kept[i]] != best[kept[j]] {
+				return !best[kept[i]]
+			}
+			return kept[i].played.Before(kept[j].played)
+		})
+		for _, f := range kept {
+			if total <= limit {
+				break
+			}
+			doomed[f] = true
+			total -= f.size
+		}
+	}
+
+	var removed []string
+	for i := range files {
+		f := &files[i]
+		if !doomed[f] {
+			res.BytesRemaining += f.size
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			res.Failed++
+			res.BytesRemaining += f.size
+			res.Errors = append(res.Errors, f.name+": "+err.Error())
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, traceKey(f.name)+metricsExt))
+		removed = append(removed, f.name)
+		res.Deleted++
+		res.BytesReclaimed += f.size
+	}
+	if len(removed) > 0 {
+		s.indexRemove(dir, removed...)
+	}
+
+	s.mu.Lock()
+	last := res
+	s.lastPrune = &last
+	s.mu.Unlock()
+	return res, nil
+}
+
+// RunPruner prunes traces every interval until ctx is cancelled, reading the policy on each pass
+// so settings changes apply without a restart. onPruned, if set, is called after every pass.
+func (s *Service) RunPruner(ctx context.Context, interval time.Duration, policy func() models.TraceRetentionPolicy, onPruned func(models.TracePruneResult)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if p := policy(); p != (models.TraceRetentionPolicy{}) {
+			if res, err := s.Prune(p); err == nil && onPruned != nil {
+				onPruned(res)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scanTraceFiles lists stored traces from the traces index.
+// Scores missing from the index are only resolved when withScores is set, since that needs the stats CSV parsed.
+func (s *Service) scanTraceFiles(dir string, withScores bool) ([]traceFile, error) {
+	entries, err := s.indexEntries()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	statsDir := s.statsDir
+	s.mu.RUnlock()
+
+	out := make([]traceFile, 0, len(entries))
+	for _, e := range entries {
+		f := traceFile{
+			name:     e.Name,
+			path:     filepath.Join(dir, e.Name),
+			size:     e.Size,
+			scenario: e.Scenario,
+			played:   entryTime(e),
+			score:    e.Score,
+			hasScore: e.Score != 0,
+		}
+		if !f.hasScore && withScores && strings.TrimSpace(statsDir) != "" {
+			if _, stats, err := parser.ParseStatsFile(filepath.Join(statsDir, e.StatsFile)); err == nil {
+				if v, ok := stats["Score"]; ok {
+					f.score, f.hasScore = util.ToFloat(v), true
+				}
+			}
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
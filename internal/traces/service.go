@@ -1,6 +1,7 @@
 package traces
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 
 	"refleks/internal/models"
 	appsettings "refleks/internal/settings"
+	"refleks/internal/traceanalysis"
+	"refleks/internal/util"
 )
 
 // ScenarioData is a versioned container for per-scenario persisted data.
@@ -18,6 +21,7 @@ type ScenarioData struct {
 	FileName     string              `json:"fileName"`
 	ScenarioName string              `json:"scenarioName,omitempty"`
 	DatePlayed   string              `json:"datePlayed,omitempty"`
+	Score        float64             `json:"score,omitempty"`
 	MouseTrace   []models.MousePoint `json:"mouseTrace,omitempty"`
 }
 
@@ -68,6 +72,13 @@ func (s *ScenarioData) UnmarshalJSON(data []byte) error {
 type Service struct {
 	mu        sync.RWMutex
 	customDir string
+	statsDir  string
+	lastPrune *models.TracePruneResult
+
+	// idxMu guards the traces index, loaded lazily per traces directory
+	idxMu    sync.Mutex
+	index    map[string]models.TraceIndexEntry
+	indexDir string
 }
 
 // NewService creates a new traces service.
@@ -123,28 +134,18 @@ func (s *Service) toTraceBaseName(original string) string {
 }
 
 // Exists checks if a trace file exists for the given scenario filename.
-// Checks for .trace (binary) first, then .json (legacy).
+// It is answered from the traces index, so no file is touched per call.
 func (s *Service) Exists(originalFileName string) bool {
 	dir, err := s.tracesDir()
 	if err != nil {
 		return false
 	}
-
-	base := s.toTraceBaseName(originalFileName)
-
-	// Check binary
-	if _, err := os.Stat(filepath.Join(dir, base+".trace")); err == nil {
-		return true
-	}
-	// Check legacy json
-	if _, err := os.Stat(filepath.Join(dir, base+".json")); err == nil {
-		return true
-	}
-	return false
+	_, ok := s.indexLookup(dir, s.toTraceBaseName(originalFileName))
+	return ok
 }
 
 // Save stores the trace data for a scenario.
-// Uses the new binary format (.trace).
+// Uses the new binary format (.trace), records it in the traces index and stores its movement metrics.
 func (s *Service) Save(data ScenarioData) error {
 	dir, err := s.tracesDir()
 	if err != nil {
@@ -157,15 +158,20 @@ func (s *Service) Save(data ScenarioData) error {
 	}
 
 	base := s.toTraceBaseName(data.FileName)
-	path := filepath.Join(dir, base+".trace")
+	name := base + ".trace"
 
-	f, err := os.Create(path)
-	if err != nil {
+	var buf bytes.Buffer
+	if err := WriteBinary(&buf, data); err != nil {
+		return err
+	}
+	if err := util.WriteFileAtomic(filepath.Join(dir, name), buf.Bytes(), 0o644); err != nil {
 		return err
 	}
-	defer f.Close()
 
-	return WriteBinary(f, data)
+	data.Version = Version2
+	s.indexPut(dir, newIndexEntry(name, data, int64(buf.Len())))
+	_ = writeMetrics(dir, base, traceanalysis.Analyze(data.MouseTrace))
+	return nil
 }
 
 // Load retrieves trace data for a scenario filename.
@@ -192,10 +198,85 @@ func (s *Service) Load(originalFileName string) (ScenarioData, error) {
 		return ScenarioData{}, err
 	}
 	defer f.Close()
+	return ReadBinary(f)
+}
 
-	var data ScenarioData
-	if err := json.NewDecoder(f).Decode(&data); err != nil {
-		return ScenarioData{}, err
+// Migrate rewrites every stored trace that isn't in the current format (legacy JSON, binary v1)
+// as a v2 .trace file. Legacy JSON files are removed once their replacement is written;
+// a JSON file that already has a .trace counterpart is left alone.
+func (s *Service) Migrate() (models.TraceMigrationResult, error) {
+	var res models.TraceMigrationResult
+	dir, err := s.tracesDir()
+	if err != nil {
+		return res, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return res, err
+	}
+
+	fail := func(name string, err error) {
+		res.Failed++
+		res.Errors = append(res.Errors, name+": "+err.Error())
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if !isTraceFileName(name) {
+			continue
+		}
+		ext := filepath.Ext(name)
+		res.Scanned++
+		path := filepath.Join(dir, name)
+		base := strings.TrimSuffix(name, ext)
+		target := filepath.Join(dir, base+".trace")
+
+		if ext == ".json" {
+			if _, err := os.Stat(target); err == nil {
+				res.Skipped++
+				continue
+			}
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			fail(name, err)
+			continue
+		}
+		if ext == ".trace" {
+			if v, err := ReadVersion(bytes.NewReader(raw)); err == nil && v == Version2 {
+				res.Skipped++
+				continue
+			}
+		}
+		data, err := ReadBinary(bytes.NewReader(raw))
+		if err != nil {
+			fail(name, err)
+			continue
+		}
+		if strings.TrimSpace(data.FileName) == "" {
+			data.FileName = base + " Stats.csv"
+		}
+
+		var out bytes.Buffer
+		if err := WriteBinary(&out, data); err != nil {
+			fail(name, err)
+			continue
+		}
+		// Written atomically so an interrupted migration never loses the original
+		if err := util.WriteFileAtomic(target, out.Bytes(), 0o644); err != nil {
+			fail(name, err)
+			continue
+		}
+		if ext == ".json" {
+			_ = os.Remove(path)
+		}
+		data.Version = Version2
+		s.indexPut(dir, newIndexEntry(base+".trace", data, int64(out.Len())))
+		res.Migrated++
+		res.BytesBefore += int64(len(raw))
+		res.BytesAfter += int64(out.Len())
 	}
-	return data, nil
+	return res, nil
 }
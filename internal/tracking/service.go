@@ -3,6 +3,10 @@ package tracking
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/wailsapp/wails/v2/pkg/runtime"
@@ -14,6 +18,7 @@ import (
 	"refleks/internal/process"
 	appsettings "refleks/internal/settings"
 	"refleks/internal/traces"
+	"refleks/internal/util"
 	"refleks/internal/watcher"
 )
 
@@ -21,12 +26,19 @@ import (
 type Service struct {
 	ctx             context.Context
 	watcher         *watcher.Watcher
+	history         *watcher.History
 	mouse           mouse.Provider
 	settingsSvc     *appsettings.Service
 	benchmarkSvc    *benchmarks.Service
 	tracesSvc       *traces.Service
 	procWatcher     *process.Watcher
 	procWatcherStop context.CancelFunc
+	runFilter       func(fileName string, live bool) bool
+
+	// listeners of parsed runs and benchmark progress updates
+	parsedMu   sync.RWMutex
+	onParsed   []func(models.ScenarioRecord)
+	onProgress []func(int, models.BenchmarkProgress)
 }
 
 // NewService constructs and wires the subservices.
@@ -36,20 +48,24 @@ func NewService(ctx context.Context, settingsSvc *appsettings.Service, benchmark
 		settingsSvc:  settingsSvc,
 		benchmarkSvc: benchmarkSvc,
 		tracesSvc:    tracesSvc,
+		history:      watcher.NewHistory(),
 	}
 
 	settings := settingsSvc.Get()
 
 	// Mouse provider initialization
-	svc.mouse = mouse.New(constants.DefaultMouseSampleHz)
+	svc.mouse = svc.newMouseProvider()
 	svc.mouse.SetBufferDuration(time.Duration(settings.MouseBufferMinutes) * time.Minute)
-	if settings.MouseTrackingEnabled {
+	if _, ok := svc.mouse.(*mouse.Replay); ok {
+		// Replays don't depend on the game running
+		_ = svc.mouse.Start()
+	} else if settings.MouseTrackingEnabled {
 		svc.startMouseProcessWatcher()
 	}
 
 	// Initialize Watcher with default/current settings
 	defaultCfg := models.WatcherConfig{
-		Path:                 settings.StatsDir,
+		Path:                 appsettings.StatsDirOf(settings),
 		SessionGap:           time.Duration(settings.SessionGapMinutes) * time.Minute,
 		PollInterval:         time.Duration(constants.DefaultPollIntervalSeconds) * time.Second,
 		ParseExistingOnStart: true,
@@ -58,9 +74,7 @@ func NewService(ctx context.Context, settingsSvc *appsettings.Service, benchmark
 
 	svc.watcher = watcher.New(ctx, defaultCfg, tracesSvc)
 	svc.watcher.SetMouseProvider(svc.mouse)
-	svc.watcher.SetOnScenarioParsed(func(rec models.ScenarioRecord) {
-		benchmarkSvc.CheckAndRefreshIfNeeded(rec)
-	})
+	svc.watcher.SetOnScenarioParsed(svc.scenarioParsed)
 
 	benchmarkSvc.SetOnProgressUpdated(func(id int, p models.BenchmarkProgress) {
 		runtime.EventsEmit(ctx, fmt.Sprintf("%s%d", constants.EventBenchmarkProgressPrefix, id), p)
@@ -68,6 +82,12 @@ func NewService(ctx context.Context, settingsSvc *appsettings.Service, benchmark
 			"id":       id,
 			"progress": p,
 		})
+		svc.parsedMu.RLock()
+		fns := append([]func(int, models.BenchmarkProgress){}, svc.onProgress...)
+		svc.parsedMu.RUnlock()
+		for _, fn := range fns {
+			fn(id, p)
+		}
 	})
 
 	return svc
@@ -85,10 +105,9 @@ func (s *Service) StartWatcher(path string) error {
 	}
 
 	// Configure watcher
-	finalPath := current.StatsDir
-	if finalPath == "" {
-		finalPath = appsettings.DefaultStatsDir()
-	}
+	finalPath := appsettings.StatsDirOf(current)
+	// Traces look up scores and orphans in the same folder
+	s.tracesSvc.SetStatsDir(finalPath)
 
 	cfg := models.WatcherConfig{
 		Path:                 finalPath,
@@ -102,9 +121,8 @@ func (s *Service) StartWatcher(path string) error {
 		// Should have been initialized in NewService, but just in case
 		s.watcher = watcher.New(s.ctx, cfg, s.tracesSvc)
 		s.watcher.SetMouseProvider(s.mouse)
-		s.watcher.SetOnScenarioParsed(func(rec models.ScenarioRecord) {
-			s.benchmarkSvc.CheckAndRefreshIfNeeded(rec)
-		})
+		s.watcher.SetRunFilter(s.runFilter)
+		s.watcher.SetOnScenarioParsed(s.scenarioParsed)
 	} else {
 		if err := s.watcher.UpdateConfig(cfg); err != nil {
 			return err
@@ -119,6 +137,77 @@ func (s *Service) StartWatcher(path string) error {
 	return nil
 }
 
+// RegisterOnScenarioParsed adds a listener called for every parsed run (initial scan and live),
+// after the benchmark progress check.
+func (s *Service) RegisterOnScenarioParsed(fn func(models.ScenarioRecord)) {
+	if fn == nil {
+		return
+	}
+	s.parsedMu.Lock()
+	s.onParsed = append(s.onParsed, fn)
+	s.parsedMu.Unlock()
+}
+
+// RegisterOnBenchmarkProgress adds a listener called whenever a benchmark's progress is refreshed.
+func (s *Service) RegisterOnBenchmarkProgress(fn func(int, models.BenchmarkProgress)) {
+	if fn == nil {
+		return
+	}
+	s.parsedMu.Lock()
+	s.onProgress = append(s.onProgress, fn)
+	s.parsedMu.Unlock()
+}
+
+// scenarioParsed is the watcher's parse callback: it refreshes benchmark progress and notifies listeners.
+func (s *Service) scenarioParsed(rec models.ScenarioRecord) {
+	s.benchmarkSvc.CheckAndRefreshIfNeeded(rec)
+	s.parsedMu.RLock()
+	fns := append([]func(models.ScenarioRecord){}, s.onParsed...)
+	s.parsedMu.RUnlock()
+	for _, fn := range fns {
+		fn(rec)
+	}
+}
+
+// SetRunFilter sets which stats files belong to the history (see watcher.SetRunFilter).
+func (s *Service) SetRunFilter(fn func(fileName string, live bool) bool) {
+	s.runFilter = fn
+	s.history.SetRunFilter(fn)
+	if s.watcher != nil {
+		s.watcher.SetRunFilter(fn)
+	}
+}
+
+// ReloadProfile stops the watcher, runs load (which swaps the settings for another profile's) and
+// re-applies the mouse, traces and watcher configuration from the newly loaded settings.
+// The recent history is rebuilt from scratch; remote runs must be added again by the caller.
+func (s *Service) ReloadProfile(load func() error) error {
+	prev := s.settingsSvc.Get()
+	_ = s.StopWatcher()
+	if err := load(); err != nil {
+		if startErr := s.StartWatcher(""); startErr != nil {
+			runtime.LogWarningf(s.ctx, "watcher restart after failed profile load: %v", startErr)
+		}
+		return err
+	}
+	newS := s.settingsSvc.Get()
+
+	s.mouse.SetBufferDuration(time.Duration(newS.MouseBufferMinutes) * time.Minute)
+	if newS.MouseTrackingEnabled != prev.MouseTrackingEnabled {
+		if newS.MouseTrackingEnabled {
+			s.startMouseProcessWatcher()
+		} else {
+			s.stopMouseProcessWatcher()
+		}
+	}
+
+	s.tracesSvc.SetBaseDir(appsettings.ExpandPathPlaceholders(newS.TracesDir))
+	s.tracesSvc.SetStatsDir(appsettings.StatsDirOf(newS))
+
+	s.ClearRemoteRecords()
+	return s.StartWatcher("")
+}
+
 // StopWatcher stops the watcher.
 func (s *Service) StopWatcher() error {
 	if s.watcher == nil {
@@ -135,6 +224,59 @@ func (s *Service) GetRecent(limit int) []models.ScenarioRecord {
 	return s.watcher.GetRecent(limit)
 }
 
+// GetHistory returns every run of the stats folder plus the synced remote runs, most recent first.
+// Unlike GetRecent it is not capped; records only carry the stats needed for whole-history features.
+func (s *Service) GetHistory() []models.ScenarioRecord {
+	if s.watcher == nil {
+		return nil
+	}
+	recs := append(s.history.Records(s.watcher.Path()), s.watcher.RemoteRecords()...)
+	type dated struct {
+		t   time.Time
+		rec models.ScenarioRecord
+	}
+	byDate := make([]dated, len(recs))
+	for i, r := range recs {
+		byDate[i] = dated{util.DatePlayed(r), r}
+	}
+	sort.SliceStable(byDate, func(i, j int) bool { return byDate[i].t.After(byDate[j].t) })
+	for i := range byDate {
+		recs[i] = byDate[i].rec
+	}
+	return recs
+}
+
+// FindRecent returns the recent local scenario with the given stats file name.
+func (s *Service) FindRecent(fileName string) (models.ScenarioRecord, bool) {
+	if s.watcher == nil {
+		return models.ScenarioRecord{}, false
+	}
+	return s.watcher.FindRecent(fileName)
+}
+
+// AddRemoteRecords merges runs synced from Kovaak's into the recent history.
+func (s *Service) AddRemoteRecords(recs []models.ScenarioRecord) int {
+	if s.watcher == nil {
+		return 0
+	}
+	return s.watcher.AddRemoteRecords(recs)
+}
+
+// ClearRemoteRecords drops synced remote runs from the recent history.
+func (s *Service) ClearRemoteRecords() {
+	if s.watcher != nil {
+		s.watcher.ClearRemoteRecords()
+	}
+}
+
+// ReloadTraces refreshes the HasTrace flag of recent scenarios after traces were added or removed on disk.
+func (s *Service) ReloadTraces() int {
+	if s.watcher == nil {
+		return 0
+	}
+	return s.watcher.ReloadTraces()
+}
+
 // IsWatcherRunning indicates if the watcher loop is active.
 func (s *Service) IsWatcherRunning() bool {
 	if s.watcher == nil {
@@ -163,7 +305,7 @@ func (s *Service) OverwriteSettings(newS models.Settings) error {
 
 	// Apply to mouse provider
 	if s.mouse == nil {
-		s.mouse = mouse.New(constants.DefaultMouseSampleHz)
+		s.mouse = s.newMouseProvider()
 	}
 	s.mouse.SetBufferDuration(time.Duration(newS.MouseBufferMinutes) * time.Minute)
 
@@ -193,6 +335,7 @@ func (s *Service) OverwriteSettings(newS models.Settings) error {
 	// Apply traces directory override
 	tracesDir := appsettings.ExpandPathPlaceholders(newS.TracesDir)
 	s.tracesSvc.SetBaseDir(tracesDir)
+	s.tracesSvc.SetStatsDir(appsettings.StatsDirOf(newS))
 
 	prevTraces := prevSettings.TracesDir
 	if s.watcher != nil && appsettings.ExpandPathPlaceholders(prevTraces) != tracesDir {
@@ -208,7 +351,7 @@ func (s *Service) updateWatcher(newS models.Settings, needsRestart bool) error {
 		return nil
 	}
 	cfg := models.WatcherConfig{
-		Path:                 newS.StatsDir,
+		Path:                 appsettings.StatsDirOf(newS),
 		SessionGap:           time.Duration(newS.SessionGapMinutes) * time.Minute,
 		PollInterval:         time.Duration(constants.DefaultPollIntervalSeconds) * time.Second,
 		ParseExistingOnStart: true,
@@ -270,6 +413,9 @@ func (s *Service) SaveSessionNote(sessionID, name, notes string) error {
 // startMouseProcessWatcher starts the process watcher that controls mouse tracking.
 // Mouse tracking only runs when Kovaak's (FPSAimTrainer.exe) is running.
 func (s *Service) startMouseProcessWatcher() {
+	if _, ok := s.mouse.(*mouse.Replay); ok {
+		return // Replays run regardless of the game process
+	}
 	if s.procWatcherStop != nil {
 		return // Already running
 	}
@@ -295,6 +441,28 @@ func (s *Service) startMouseProcessWatcher() {
 	go s.procWatcher.Start(ctx)
 }
 
+// newMouseProvider returns the device tracker, or a replay of the trace file named by
+// EnvMouseReplayVar when set (e.g. on platforms without a native tracker).
+func (s *Service) newMouseProvider() mouse.Provider {
+	path := strings.TrimSpace(appsettings.GetEnv(constants.EnvMouseReplayVar))
+	if path == "" {
+		return mouse.New(constants.DefaultMouseSampleHz)
+	}
+	speed := 1.0
+	if v := strings.TrimSpace(appsettings.GetEnv(constants.EnvMouseReplaySpeedVar)); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			speed = f
+		}
+	}
+	r, err := mouse.NewReplayFromFile(appsettings.ExpandPathPlaceholders(path), mouse.ReplayOptions{Speed: speed})
+	if err != nil {
+		runtime.LogWarningf(s.ctx, "mouse replay unavailable, using device tracker: %v", err)
+		return mouse.New(constants.DefaultMouseSampleHz)
+	}
+	runtime.LogInfof(s.ctx, "mouse replay from %s (speed %g)", path, speed)
+	return r
+}
+
 // stopMouseProcessWatcher stops the process watcher and ensures mouse tracking is stopped.
 func (s *Service) stopMouseProcessWatcher() {
 	if s.procWatcherStop != nil {
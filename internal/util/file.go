@@ -0,0 +1,17 @@
+package util
+
+import "os"
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it over path, so an
+// interrupted write never leaves a truncated file behind.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
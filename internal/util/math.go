@@ -0,0 +1,31 @@
+package util
+
+import "math"
+
+// LinearFit returns the intercept and slope of the least-squares line through (xs, ys). With fewer
+// than two distinct xs the slope is 0 and the intercept the mean of ys.
+func LinearFit(xs, ys []float64) (intercept, slope float64) {
+	n := float64(len(xs))
+	if n == 0 {
+		return 0, 0
+	}
+	var sx, sy, sxx, sxy float64
+	for i := range xs {
+		sx += xs[i]
+		sy += ys[i]
+		sxx += xs[i] * xs[i]
+		sxy += xs[i] * ys[i]
+	}
+	den := n*sxx - sx*sx
+	if den == 0 {
+		return sy / n, 0
+	}
+	slope = (n*sxy - sx*sy) / den
+	return (sy - slope*sx) / n, slope
+}
+
+// Round rounds v to the given number of decimals.
+func Round(v float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	return math.Round(v*p) / p
+}
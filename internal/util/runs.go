@@ -0,0 +1,33 @@
+package util
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"refleks/internal/models"
+)
+
+// Run is the part of a scenario record that history-wide features (goals, stats) work on.
+type Run struct {
+	Scenario string
+	At       time.Time
+	Score    float64
+	Seconds  float64 // Duration
+}
+
+// RunsOf extracts the runs of records, oldest first. Records without a scenario name or a valid
+// Date Played are skipped.
+func RunsOf(records []models.ScenarioRecord) []Run {
+	out := make([]Run, 0, len(records))
+	for _, r := range records {
+		name, _ := r.Stats["Scenario"].(string)
+		at := DatePlayed(r)
+		if strings.TrimSpace(name) == "" || at.IsZero() {
+			continue
+		}
+		out = append(out, Run{Scenario: name, At: at, Score: ToFloat(r.Stats["Score"]), Seconds: ToFloat(r.Stats["Duration"])})
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"time"
+
+	"refleks/internal/models"
+)
+
+// DatePlayed returns a record's "Date Played" stat (RFC 3339, set by the watcher and the remote
+// sync), or the zero time when it is missing or invalid.
+func DatePlayed(rec models.ScenarioRecord) time.Time {
+	s, _ := rec.Stats["Date Played"].(string)
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// ParseTODOnDate parses a clock time string (HH:MM:SS with optional fractional seconds) onto the
+// day of date, in date's location.
+func ParseTODOnDate(s string, date time.Time) (time.Time, bool) {
+	// Support common formats with/without fractional seconds
+	layouts := []string{
+		"15:04:05.000000",
+		"15:04:05.000",
+		"15:04:05",
+	}
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), date.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
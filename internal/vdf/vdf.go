@@ -0,0 +1,197 @@
+// Package vdf parses Valve KeyValues (VDF/ACF) text files such as Steam's loginusers.vdf,
+// libraryfolders.vdf and appmanifest_<appid>.acf.
+package vdf
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeyValues is a parsed KeyValues object. Values are either string or KeyValues.
+// Keys keep their original case; use Get/String/Map for the case-insensitive lookups VDF expects.
+// When a key repeats within an object, the last value wins.
+type KeyValues map[string]any
+
+// Get returns the value of key, matching case-insensitively.
+func (kv KeyValues) Get(key string) (any, bool) {
+	if v, ok := kv[key]; ok {
+		return v, true
+	}
+	for k, v := range kv {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// String returns the string value of key ("" if missing or an object).
+func (kv KeyValues) String(key string) string {
+	v, _ := kv.Get(key)
+	s, _ := v.(string)
+	return s
+}
+
+// Map returns the object value of key (nil if missing or a string).
+func (kv KeyValues) Map(key string) KeyValues {
+	v, _ := kv.Get(key)
+	m, _ := v.(KeyValues)
+	return m
+}
+
+// ParseFile reads and parses a KeyValues file.
+func ParseFile(path string) (KeyValues, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(b))
+}
+
+// Parse parses KeyValues text. Supported: quoted and bare tokens, nested objects, the escapes
+// \n \t \\ \" in quoted strings, // comments, and [$PLATFORM] conditionals (which are ignored).
+func Parse(s string) (KeyValues, error) {
+	p := &parser{s: s, line: 1}
+	root, err := p.object(false)
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokString
+	tokOpen
+	tokClose
+)
+
+type parser struct {
+	s    string
+	i    int
+	line int
+}
+
+// object parses key/value pairs until the closing brace (nested) or end of input (top level).
+func (p *parser) object(nested bool) (KeyValues, error) {
+	out := KeyValues{}
+	for {
+		kind, key, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case tokEOF:
+			if nested {
+				return nil, p.errorf("unexpected end of input, missing '}'")
+			}
+			return out, nil
+		case tokClose:
+			if !nested {
+				return nil, p.errorf("unexpected '}'")
+			}
+			return out, nil
+		case tokOpen:
+			return nil, p.errorf("unexpected '{', expected a key")
+		}
+
+		kind, val, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case tokString:
+			out[key] = val
+		case tokOpen:
+			child, err := p.object(true)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = child
+		default:
+			return nil, p.errorf("missing value for key %q", key)
+		}
+	}
+}
+
+// next returns the next token, skipping whitespace, comments and conditionals.
+func (p *parser) next() (tokenKind, string, error) {
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		switch {
+		case c == '\n':
+			p.line++
+			p.i++
+		case c == ' ' || c == '\t' || c == '\r':
+			p.i++
+		case c == '/' && p.i+1 < len(p.s) && p.s[p.i+1] == '/':
+			for p.i < len(p.s) && p.s[p.i] != '\n' {
+				p.i++
+			}
+		case c == '[':
+			// Platform conditional such as [$WIN32]
+			end := strings.IndexByte(p.s[p.i:], ']')
+			if end < 0 {
+				return tokEOF, "", p.errorf("unterminated conditional")
+			}
+			p.i += end + 1
+		case c == '{':
+			p.i++
+			return tokOpen, "", nil
+		case c == '}':
+			p.i++
+			return tokClose, "", nil
+		case c == '"':
+			return p.quoted()
+		default:
+			start := p.i
+			for p.i < len(p.s) && !strings.ContainsRune(" \t\r\n{}\"", rune(p.s[p.i])) {
+				p.i++
+			}
+			return tokString, p.s[start:p.i], nil
+		}
+	}
+	return tokEOF, "", nil
+}
+
+// quoted reads a quoted string starting at the opening quote.
+func (p *parser) quoted() (tokenKind, string, error) {
+	line := p.line
+	p.i++ // opening quote
+	var sb strings.Builder
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		switch {
+		case c == '"':
+			p.i++
+			return tokString, sb.String(), nil
+		case c == '\\' && p.i+1 < len(p.s):
+			switch e := p.s[p.i+1]; e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '\\', '"':
+				sb.WriteByte(e)
+			default:
+				// Unknown escapes are kept verbatim (e.g. Windows paths with single backslashes)
+				sb.WriteByte(c)
+				sb.WriteByte(e)
+			}
+			p.i += 2
+			continue
+		case c == '\n':
+			p.line++
+		}
+		sb.WriteByte(c)
+		p.i++
+	}
+	return tokEOF, "", fmt.Errorf("vdf: line %d: unterminated string", line)
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("vdf: line %d: %s", p.line, fmt.Sprintf(format, args...))
+}
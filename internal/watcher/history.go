@@ -0,0 +1,84 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"refleks/internal/models"
+)
+
+// historyStatKeys are the stats kept for indexed runs; the rest of a record is dropped to bound memory.
+var historyStatKeys = []string{"Scenario", "Date Played", "Score", "Duration", "Accuracy", "cm/360"}
+
+// History indexes every run of a stats folder. Unlike the watcher's recent list it is not capped,
+// so whole-history features (stats, goals, reviews) see all runs. Runs are parsed once and cached
+// by file name, so later calls only read files that appeared since.
+type History struct {
+	mu        sync.Mutex
+	dir       string
+	runs      map[string]models.ScenarioRecord // by file name, trimmed to historyStatKeys
+	runFilter func(fileName string, live bool) bool
+}
+
+// NewHistory returns an empty history index.
+func NewHistory() *History {
+	return &History{runs: map[string]models.ScenarioRecord{}}
+}
+
+// SetRunFilter sets which stats files belong to the history (see Watcher.SetRunFilter).
+// It is applied on every call, so switching profiles needs no re-parse.
+func (h *History) SetRunFilter(fn func(fileName string, live bool) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.runFilter = fn
+}
+
+// Records returns every run of dir admitted by the run filter, in no particular order. Records
+// carry no events or mouse data and only the stats in historyStatKeys.
+func (h *History) Records(dir string) []models.ScenarioRecord {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if dir != h.dir {
+		h.dir = dir
+		h.runs = map[string]models.ScenarioRecord{}
+	}
+	out := make([]models.ScenarioRecord, 0, len(entries))
+	present := make(map[string]struct{}, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !isKovaaksStatsFile(name) {
+			continue
+		}
+		present[name] = struct{}{}
+		if h.runFilter != nil && !h.runFilter(name, false) {
+			continue
+		}
+		rec, ok := h.runs[name]
+		if !ok {
+			full, _, err := parseRecord(filepath.Join(dir, name))
+			if err != nil {
+				continue // retried next call (the game may still be writing it)
+			}
+			rec = models.ScenarioRecord{FilePath: full.FilePath, FileName: full.FileName, Stats: make(map[string]any, len(historyStatKeys))}
+			for _, k := range historyStatKeys {
+				if v, ok := full.Stats[k]; ok {
+					rec.Stats[k] = v
+				}
+			}
+			h.runs[name] = rec
+		}
+		out = append(out, rec)
+	}
+	// Forget deleted files
+	for name := range h.runs {
+		if _, ok := present[name]; !ok {
+			delete(h.runs, name)
+		}
+	}
+	return out
+}
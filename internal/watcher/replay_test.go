@@ -0,0 +1,134 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"refleks/internal/models"
+	"refleks/internal/mouse"
+	"refleks/internal/traces"
+)
+
+const replayStatsFile = "Replay Test - Challenge - 2026.01.02-15.04.05 Stats.csv"
+
+// writeReplayStats writes a stats file for a run from 15:03:05 to 15:04:05 local time.
+func writeReplayStats(t *testing.T, dir string) {
+	t.Helper()
+	content := "Kill #,Timestamp,Bot,Weapon,TTK,Shots,Hits,Accuracy,Damage Done,Damage Possible,Efficiency,Cheated,OverShots\n" +
+		"1,15:03:10.000,Bot,Gun,0.5s,2,1,0.5,100,100,1,false,0\n" +
+		"\n" +
+		"Kills:,1\n" +
+		"Score:,123.4\n" +
+		"Challenge Start:,15:03:05.000\n" +
+		"Scenario:,Replay Test\n"
+	if err := os.WriteFile(filepath.Join(dir, replayStatsFile), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// replayFixture is 70 s of motion sampled every 10 ms, moving 1 unit right per sample,
+// with the left button held during the second 10 s.
+func replayFixture() []models.MousePoint {
+	out := make([]models.MousePoint, 0, 7001)
+	for i := 0; i <= 7000; i++ {
+		p := models.MousePoint{TS: 1_000_000 + int64(i)*10, X: int32(i)}
+		if i >= 1000 && i < 2000 {
+			p.Buttons = 1
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func TestReplayCaptureToTrace(t *testing.T) {
+	fixture := replayFixture()
+	writers := map[string]func(*bytes.Buffer, traces.ScenarioData) error{
+		"fixture.trace": func(b *bytes.Buffer, d traces.ScenarioData) error { return traces.WriteBinary(b, d) },
+		"fixture.csv": func(b *bytes.Buffer, d traces.ScenarioData) error {
+			return traces.WriteExport(b, d, nil, traces.ExportCSV)
+		},
+	}
+	for name, write := range writers {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			statsDir, tracesDir := t.TempDir(), t.TempDir()
+			writeReplayStats(t, statsDir)
+
+			var buf bytes.Buffer
+			if err := write(&buf, traces.ScenarioData{FileName: "fixture", MouseTrace: fixture}); err != nil {
+				t.Fatal(err)
+			}
+			fixturePath := filepath.Join(t.TempDir(), name)
+			if err := os.WriteFile(fixturePath, buf.Bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			// Replay 70 s at 700x, starting 5 s before the run so the window cuts both ends
+			start := time.Date(2026, 1, 2, 15, 3, 5, 0, time.Local)
+			end := start.Add(time.Minute)
+			replay, err := mouse.NewReplayFromFile(fixturePath, mouse.ReplayOptions{Speed: 700, Anchor: start.Add(-5 * time.Second)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := replay.Start(); err != nil {
+				t.Fatal(err)
+			}
+			defer replay.Stop()
+			select {
+			case <-replay.Done():
+			case <-time.After(5 * time.Second):
+				t.Fatal("replay did not finish")
+			}
+
+			tracesSvc := traces.NewService()
+			tracesSvc.SetBaseDir(tracesDir)
+			w := New(context.Background(), models.WatcherConfig{Path: statsDir}, tracesSvc)
+			w.SetMouseProvider(replay)
+
+			rec, err := w.parseFile(filepath.Join(statsDir, replayStatsFile))
+			if err != nil {
+				t.Fatalf("parseFile: %v", err)
+			}
+			if !rec.HasTrace || rec.MouseTrace != nil {
+				t.Fatalf("HasTrace = %v, MouseTrace = %d points; want a persisted trace only", rec.HasTrace, len(rec.MouseTrace))
+			}
+
+			// Points 500..6500 fall in [start, end]: 5 s after the anchor to 65 s after it
+			data, err := tracesSvc.Load(replayStatsFile)
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			if data.ScenarioName != "Replay Test" || data.Score != 123.4 {
+				t.Errorf("metadata = %q / %v", data.ScenarioName, data.Score)
+			}
+			pts := data.MouseTrace
+			if len(pts) != 6001 {
+				t.Fatalf("saved %d points, want 6001", len(pts))
+			}
+			if pts[0].TS != start.UnixMilli() || pts[len(pts)-1].TS != end.UnixMilli() {
+				t.Errorf("saved span %d..%d, want %d..%d", pts[0].TS, pts[len(pts)-1].TS, start.UnixMilli(), end.UnixMilli())
+			}
+			if pts[0].X != 500 || pts[len(pts)-1].X != 6500 {
+				t.Errorf("saved X %d..%d, want 500..6500", pts[0].X, pts[len(pts)-1].X)
+			}
+			if pts[500].Buttons != 1 || pts[1500].Buttons != 0 {
+				t.Errorf("buttons = %d, %d; want 1, 0", pts[500].Buttons, pts[1500].Buttons)
+			}
+
+			m, err := tracesSvc.Metrics(replayStatsFile)
+			if err != nil {
+				t.Fatalf("Metrics: %v", err)
+			}
+			if m.Points != 6001 || m.DurationMs != 60_000 || m.PathLength != 6000 {
+				t.Errorf("metrics: points %d, duration %d ms, path %v; want 6001, 60000, 6000", m.Points, m.DurationMs, m.PathLength)
+			}
+			if m.Clicks.Clicks != 1 {
+				t.Errorf("clicks = %d, want 1", m.Clicks.Clicks)
+			}
+		})
+	}
+}
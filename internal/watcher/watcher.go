@@ -30,8 +30,10 @@ type Watcher struct {
 	seen    map[string]struct{} // full file path set
 
 	recent    []models.ScenarioRecord
+	remote    []models.ScenarioRecord // runs synced from Kovaak's (Source=remote), oldest first; kept across Clear
 	mouse     MouseProvider
 	tracesSvc *traces.Service
+	runFilter func(fileName string, live bool) bool
 
 	OnScenarioParsed func(models.ScenarioRecord)
 }
@@ -60,6 +62,14 @@ func (w *Watcher) SetMouseProvider(p MouseProvider) {
 	w.mouse = p
 }
 
+// SetRunFilter sets a predicate deciding which stats files are part of the history. live is true for
+// files that appeared while polling and false for files found by the initial scan. Rejected files are never parsed.
+func (w *Watcher) SetRunFilter(fn func(fileName string, live bool) bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.runFilter = fn
+}
+
 // Start begins polling loop. It is safe to call once; subsequent calls return an error.
 func (w *Watcher) Start() error {
 	w.mu.Lock()
@@ -136,6 +146,9 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 	if err != nil {
 		return err
 	}
+	w.mu.RLock()
+	runFilter := w.runFilter
+	w.mu.RUnlock()
 	// Build list with parsed timestamps so we can sort by date, not filename
 	type fileRec struct {
 		path string
@@ -163,6 +176,14 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 			}
 		}
 
+		if runFilter != nil && !runFilter(name, !includeAll) {
+			// Not part of this history (e.g. another profile's run); don't look at it again
+			w.mu.Lock()
+			w.seen[full] = struct{}{}
+			w.mu.Unlock()
+			continue
+		}
+
 		info, err := parser.ParseFilename(name)
 		if err != nil {
 			continue
@@ -218,13 +239,59 @@ func (w *Watcher) scanOnce(includeAll bool) error {
 }
 
 func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
-	info, err := parser.ParseFilename(filepath.Base(fullPath))
+	rec, info, err := parseRecord(fullPath)
 	if err != nil {
 		return models.ScenarioRecord{}, err
 	}
+	stats, events := rec.Stats, rec.Events
+
+	// Optionally enrich with mouse trace based on Challenge Start -> DatePlayed interval
+	w.mu.RLock()
+	mp := w.mouse
+	w.mu.RUnlock()
+	if mp != nil && mp.Enabled() {
+		start, end := deriveScenarioWindow(info.DatePlayed, stats, events)
+		if !start.IsZero() && !end.IsZero() && start.Before(end) {
+			rec.MouseTrace = mp.GetRange(start, end)
+		}
+	}
+
+	// If we captured a trace, persist it to disk for future reloads.
+	if len(rec.MouseTrace) > 0 {
+		// Only write if not already present to avoid churn.
+		if !w.tracesSvc.Exists(rec.FileName) {
+			_ = w.tracesSvc.Save(traces.ScenarioData{
+				Version:      traces.Version2,
+				FileName:     rec.FileName,
+				ScenarioName: info.ScenarioName,
+				DatePlayed:   info.DatePlayed.Format(time.RFC3339),
+				Score:        util.ToFloat(stats["Score"]),
+				MouseTrace:   rec.MouseTrace,
+			})
+		}
+		// We have a trace, but we don't send it immediately to save bandwidth/memory.
+		// The frontend will request it if needed.
+		rec.HasTrace = true
+		rec.MouseTrace = nil
+	} else {
+		// No live capture available (e.g., after restart). Check if persisted data exists.
+		if w.tracesSvc.Exists(rec.FileName) {
+			rec.HasTrace = true
+		}
+	}
+	return rec, nil
+}
+
+// parseRecord parses a stats file into a record with the derived stats (Date Played, Accuracy,
+// Real Avg TTK, cm/360, Duration) but without mouse data.
+func parseRecord(fullPath string) (models.ScenarioRecord, parser.FilenameInfo, error) {
+	info, err := parser.ParseFilename(filepath.Base(fullPath))
+	if err != nil {
+		return models.ScenarioRecord{}, info, err
+	}
 	events, stats, err := parser.ParseStatsFile(fullPath)
 	if err != nil {
-		return models.ScenarioRecord{}, err
+		return models.ScenarioRecord{}, info, err
 	}
 
 	// Augment stats with derived fields
@@ -251,7 +318,7 @@ func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
 			if len(row) < 2 {
 				continue
 			}
-			if t, ok := parseTODOnDate(row[1], info.DatePlayed); ok {
+			if t, ok := util.ParseTODOnDate(row[1], info.DatePlayed); ok {
 				times = append(times, t)
 			}
 		}
@@ -282,49 +349,12 @@ func (w *Watcher) parseFile(fullPath string) (models.ScenarioRecord, error) {
 		stats["Duration"] = duration
 	}
 
-	rec := models.ScenarioRecord{
+	return models.ScenarioRecord{
 		FilePath: fullPath,
 		FileName: filepath.Base(fullPath),
 		Stats:    stats,
 		Events:   events,
-	}
-
-	// Optionally enrich with mouse trace based on Challenge Start -> DatePlayed interval
-	w.mu.RLock()
-	mp := w.mouse
-	w.mu.RUnlock()
-	if mp != nil && mp.Enabled() {
-		start, end := deriveScenarioWindow(info.DatePlayed, stats, events)
-		if !start.IsZero() && !end.IsZero() && start.Before(end) {
-			rec.MouseTrace = mp.GetRange(start, end)
-			// debug
-			runtime.LogDebugf(w.ctx, "MouseTrace: %d points for %s in window %s - %s", len(rec.MouseTrace), rec.FileName, start.Format(time.RFC3339), end.Format(time.RFC3339))
-		}
-	}
-
-	// If we captured a trace, persist it to disk for future reloads.
-	if len(rec.MouseTrace) > 0 {
-		// Only write if not already present to avoid churn.
-		if !w.tracesSvc.Exists(rec.FileName) {
-			_ = w.tracesSvc.Save(traces.ScenarioData{
-				Version:      1,
-				FileName:     rec.FileName,
-				ScenarioName: info.ScenarioName,
-				DatePlayed:   info.DatePlayed.Format(time.RFC3339),
-				MouseTrace:   rec.MouseTrace,
-			})
-		}
-		// We have a trace, but we don't send it immediately to save bandwidth/memory.
-		// The frontend will request it if needed.
-		rec.HasTrace = true
-		rec.MouseTrace = nil
-	} else {
-		// No live capture available (e.g., after restart). Check if persisted data exists.
-		if w.tracesSvc.Exists(rec.FileName) {
-			rec.HasTrace = true
-		}
-	}
-	return rec, nil
+	}, info, nil
 }
 
 // deriveScenarioWindow attempts to compute the [start, end] timespan of a scenario.
@@ -335,7 +365,7 @@ func deriveScenarioWindow(end time.Time, stats map[string]any, events [][]string
 	var start time.Time
 	if v, ok := stats["Challenge Start"]; ok {
 		if s, ok := v.(string); ok {
-			if t, ok := parseTODOnDate(s, end); ok {
+			if t, ok := util.ParseTODOnDate(s, end); ok {
 				start = t
 			}
 		}
@@ -344,7 +374,7 @@ func deriveScenarioWindow(end time.Time, stats map[string]any, events [][]string
 	// Fallback to the first event timestamp's time-of-day
 	if start.IsZero() && len(events) > 0 && len(events[0]) > 1 {
 		ts := events[0][1]
-		if t, ok := parseTODOnDate(ts, end); ok {
+		if t, ok := util.ParseTODOnDate(ts, end); ok {
 			start = t
 		}
 	}
@@ -359,43 +389,96 @@ func deriveScenarioWindow(end time.Time, stats map[string]any, events [][]string
 	return start, end
 }
 
-// parseTODOnDate parses a clock time string onto the provided date.
-func parseTODOnDate(s string, date time.Time) (time.Time, bool) {
-	// Support common formats with/without fractional seconds
-	layouts := []string{
-		"15:04:05.000000",
-		"15:04:05.000",
-		"15:04:05",
-	}
-	for _, layout := range layouts {
-		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
-			return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), date.Location()), true
-		}
-	}
-	return time.Time{}, false
-}
-
 // removed duplicate toFloat: use util.ToFloat instead
 
-// GetRecent returns up to limit most recent scenarios.
+// GetRecent returns up to limit most recent scenarios, including runs synced from Kovaak's.
 func (w *Watcher) GetRecent(limit int) []models.ScenarioRecord {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
-	total := len(w.recent)
+	total := len(w.recent) + len(w.remote)
 	if total == 0 {
 		return nil
 	}
 	if limit <= 0 || limit > total {
 		limit = total
 	}
-	out := make([]models.ScenarioRecord, limit)
-	// Return most-recent-first: copy from the end backwards
-	for i := 0; i < limit; i++ {
-		out[i] = w.recent[total-1-i]
+	out := make([]models.ScenarioRecord, 0, limit)
+	// Return most-recent-first: merge both lists from the end backwards
+	i, j := len(w.recent)-1, len(w.remote)-1
+	for len(out) < limit {
+		if j < 0 || (i >= 0 && !util.DatePlayed(w.recent[i]).Before(util.DatePlayed(w.remote[j]))) {
+			out = append(out, w.recent[i])
+			i--
+		} else {
+			out = append(out, w.remote[j])
+			j--
+		}
 	}
 	return out
 }
 
+// FindRecent returns the in-memory local record with the given stats file name.
+func (w *Watcher) FindRecent(fileName string) (models.ScenarioRecord, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	for i := len(w.recent) - 1; i >= 0; i-- {
+		if w.recent[i].FileName == fileName {
+			return w.recent[i], true
+		}
+	}
+	return models.ScenarioRecord{}, false
+}
+
+// AddRemoteRecords merges runs synced from Kovaak's into the history and emits ScenarioAdded
+// for each new one. Records already present (same FilePath) are skipped. Returns how many were added.
+func (w *Watcher) AddRemoteRecords(recs []models.ScenarioRecord) int {
+	w.mu.Lock()
+	known := make(map[string]struct{}, len(w.remote))
+	for _, r := range w.remote {
+		known[r.FilePath] = struct{}{}
+	}
+	var added []models.ScenarioRecord
+	for _, r := range recs {
+		if _, ok := known[r.FilePath]; ok {
+			continue
+		}
+		known[r.FilePath] = struct{}{}
+		w.remote = append(w.remote, r)
+		added = append(added, r)
+	}
+	sort.SliceStable(w.remote, func(i, j int) bool { return util.DatePlayed(w.remote[i]).Before(util.DatePlayed(w.remote[j])) })
+	if cap := w.effectiveRecentCap(); cap > 0 && len(w.remote) > cap {
+		w.remote = w.remote[len(w.remote)-cap:]
+	}
+	w.mu.Unlock()
+
+	for _, rec := range added {
+		runtime.EventsEmit(w.ctx, constants.EventScenarioAdded, rec)
+	}
+	return len(added)
+}
+
+// RemoteRecords returns the runs synced from Kovaak's, oldest first.
+func (w *Watcher) RemoteRecords() []models.ScenarioRecord {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return append([]models.ScenarioRecord{}, w.remote...)
+}
+
+// ClearRemoteRecords drops all synced remote runs.
+func (w *Watcher) ClearRemoteRecords() {
+	w.mu.Lock()
+	w.remote = nil
+	w.mu.Unlock()
+}
+
+// Path returns the watched stats folder.
+func (w *Watcher) Path() string {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.cfg.Path
+}
+
 // IsRunning indicates if the watcher loop is active.
 func (w *Watcher) IsRunning() bool {
 	w.mu.RLock()
@@ -415,16 +498,16 @@ func (w *Watcher) UpdateConfig(cfg models.WatcherConfig) error {
 }
 
 // ReloadTraces checks for persisted mouse traces for recent scenarios.
-// If a record didn't have a trace but now does, a 'ScenarioUpdated' event is emitted.
+// If a record's trace appeared or disappeared, a 'ScenarioUpdated' event is emitted.
 func (w *Watcher) ReloadTraces() int {
 	// Copy updated records to emit outside the lock
 	var toEmit []models.ScenarioRecord
 	w.mu.Lock()
 	for i := range w.recent {
 		rec := w.recent[i]
-		// Check if trace exists on disk
-		if !rec.HasTrace && w.tracesSvc.Exists(rec.FileName) {
-			rec.HasTrace = true
+		// Check if trace exists on disk (it may also have been pruned since)
+		if exists := w.tracesSvc.Exists(rec.FileName); exists != rec.HasTrace {
+			rec.HasTrace = exists
 			w.recent[i] = rec
 			toEmit = append(toEmit, rec)
 		}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"embed"
 	"flag"
+	"os"
+
+	"refleks/internal/models"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/logger"
@@ -17,8 +20,31 @@ var assets embed.FS
 
 func main() {
 	monitor := flag.Bool("monitor", false, "Start in monitor mode (hidden)")
+	migrateTraces := flag.Bool("migrate-traces", false, "Rewrite stored mouse traces in the current format and exit")
+	exportTraces := flag.Bool("export-traces", false, "Export stored mouse traces to CSV/NDJSON and exit")
+	exportFormat := flag.String("format", "csv", "Trace export format: csv or ndjson")
+	exportScenario := flag.String("scenario", "", "Trace export: only this scenario")
+	exportFrom := flag.String("from", "", "Trace export: runs played on or after this date (YYYY-MM-DD or RFC3339)")
+	exportTo := flag.String("to", "", "Trace export: runs played on or before this date (YYYY-MM-DD or RFC3339)")
+	exportFiles := flag.String("file", "", "Trace export: comma-separated stats file names (overrides -scenario/-from/-to)")
+	exportOut := flag.String("out", "", "Trace export: output directory (default ~/.refleks/exports/<timestamp>)")
 	flag.Parse()
 
+	// Headless maintenance commands
+	if *migrateTraces {
+		os.Exit(runMigrateTraces())
+	}
+	if *exportTraces {
+		os.Exit(runExportTraces(models.TraceExportOptions{
+			Format:    *exportFormat,
+			FileNames: splitList(*exportFiles),
+			Scenario:  *exportScenario,
+			From:      *exportFrom,
+			To:        *exportTo,
+			OutputDir: *exportOut,
+		}))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 